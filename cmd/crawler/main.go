@@ -0,0 +1,96 @@
+// Command crawler runs the Links 'R' Us crawler against the linkgraph and
+// textindexer services. Several instances can run side by side, each
+// crawling its own partition of the graph.
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/bruceneco/links-r-us/cmd/internal/service"
+	"github.com/bruceneco/links-r-us/internal/application/crawler"
+	"github.com/bruceneco/links-r-us/internal/partition"
+	"golang.org/x/sync/errgroup"
+)
+
+// config holds the settings of the crawler service.
+type config struct {
+	graph   service.GraphConfig
+	indexer service.IndexerConfig
+
+	workers         int
+	interval        time.Duration
+	reCrawlInterval time.Duration
+	partitionSRV    string
+	partition       int
+	numPartitions   int
+	healthAddr      string
+}
+
+func main() {
+	cfg := parseConfig(os.Args[1:])
+	service.Main("crawler", func(ctx context.Context, logger *slog.Logger) error {
+		return run(ctx, logger, cfg)
+	})
+}
+
+// parseConfig reads the service settings from args, falling back to the
+// environment when a flag is not provided.
+func parseConfig(args []string) config {
+	var cfg config
+	fs := flag.NewFlagSet("crawler", flag.ExitOnError)
+	cfg.graph.RegisterFlags(fs, service.GraphGRPC)
+	cfg.indexer.RegisterFlags(fs, service.IndexerGRPC)
+	fs.IntVar(&cfg.workers, "workers", service.EnvIntOr("LINKSRUS_CRAWLER_WORKERS", 4), "number of links fetched concurrently")
+	fs.DurationVar(&cfg.interval, "interval", service.EnvDurationOr("LINKSRUS_CRAWLER_INTERVAL", 5*time.Minute), "time between passes")
+	fs.DurationVar(&cfg.reCrawlInterval, "recrawl-interval", service.EnvDurationOr("LINKSRUS_RECRAWL_INTERVAL", 7*24*time.Hour), "time after which a link is fetched again")
+	fs.StringVar(&cfg.partitionSRV, "partition-srv", service.EnvOr("LINKSRUS_PARTITION_SRV", ""), "SRV record listing the crawler instances, used to pick the partition of this instance (overrides -partition and -num-partitions)")
+	fs.IntVar(&cfg.partition, "partition", service.EnvIntOr("LINKSRUS_PARTITION", 0), "partition of the graph crawled by this instance")
+	fs.IntVar(&cfg.numPartitions, "num-partitions", service.EnvIntOr("LINKSRUS_NUM_PARTITIONS", 1), "number of partitions the graph is split into")
+	fs.StringVar(&cfg.healthAddr, "health-addr", service.EnvOr("LINKSRUS_HEALTH_ADDR", ":8083"), "address the health endpoint listens on (disabled if empty)")
+	_ = fs.Parse(args)
+	return cfg
+}
+
+// run crawls the graph until ctx is cancelled.
+func run(ctx context.Context, logger *slog.Logger, cfg config) error {
+	graph, closeGraph, err := service.OpenGraph(ctx, logger, cfg.graph)
+	if err != nil {
+		return err
+	}
+	defer closeGraph()
+
+	indexer, closeIndexer, err := service.OpenIndexer(cfg.indexer)
+	if err != nil {
+		return err
+	}
+	defer closeIndexer()
+
+	var detector partition.Detector = partition.Fixed{Partition: cfg.partition, NumPartitions: cfg.numPartitions}
+	if cfg.partitionSRV != "" {
+		detector = partition.NewDNSDetector(cfg.partitionSRV)
+	}
+	crawlerSvc, err := crawler.NewCrawler(crawler.Config{
+		Graph:             graph,
+		Indexer:           indexer,
+		Workers:           cfg.workers,
+		PartitionDetector: detector,
+		Interval:          cfg.interval,
+		ReCrawlInterval:   cfg.reCrawlInterval,
+		Logger:            logger,
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("crawler started")
+	group, ctx := errgroup.WithContext(ctx)
+	group.Go(func() error { return crawlerSvc.Run(ctx) })
+	group.Go(func() error { return service.ServeHealth(ctx, logger, cfg.healthAddr) })
+	err = group.Wait()
+	logger.Info("shutting down")
+	return err
+}
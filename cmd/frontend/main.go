@@ -0,0 +1,74 @@
+// Command frontend serves the Links 'R' Us search pages on top of the
+// linkgraph and textindexer services. Its health endpoint is served on the
+// same address as the pages.
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/bruceneco/links-r-us/cmd/internal/service"
+	"github.com/bruceneco/links-r-us/internal/adapters/frontend"
+)
+
+// config holds the settings of the frontend service.
+type config struct {
+	graph   service.GraphConfig
+	indexer service.IndexerConfig
+
+	listenAddr     string
+	resultsPerPage int
+}
+
+func main() {
+	cfg := parseConfig(os.Args[1:])
+	service.Main("frontend", func(ctx context.Context, logger *slog.Logger) error {
+		return run(ctx, logger, cfg)
+	})
+}
+
+// parseConfig reads the service settings from args, falling back to the
+// environment when a flag is not provided.
+func parseConfig(args []string) config {
+	var cfg config
+	fs := flag.NewFlagSet("frontend", flag.ExitOnError)
+	cfg.graph.RegisterFlags(fs, service.GraphGRPC)
+	cfg.indexer.RegisterFlags(fs, service.IndexerGRPC)
+	fs.StringVar(&cfg.listenAddr, "listen-addr", service.EnvOr("LINKSRUS_LISTEN_ADDR", ":8080"), "address the frontend listens on")
+	fs.IntVar(&cfg.resultsPerPage, "results-per-page", service.EnvIntOr("LINKSRUS_RESULTS_PER_PAGE", 10), "number of search results per page")
+	_ = fs.Parse(args)
+	return cfg
+}
+
+// run serves the frontend until ctx is cancelled.
+func run(ctx context.Context, logger *slog.Logger, cfg config) error {
+	graph, closeGraph, err := service.OpenGraph(ctx, logger, cfg.graph)
+	if err != nil {
+		return err
+	}
+	defer closeGraph()
+
+	indexer, closeIndexer, err := service.OpenIndexer(cfg.indexer)
+	if err != nil {
+		return err
+	}
+	defer closeIndexer()
+
+	fe, err := frontend.NewFrontend(frontend.Config{
+		Graph:          graph,
+		Indexer:        indexer,
+		ListenAddr:     cfg.listenAddr,
+		ResultsPerPage: cfg.resultsPerPage,
+		Logger:         logger,
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("frontend started")
+	err = fe.Run(ctx)
+	logger.Info("shutting down")
+	return err
+}
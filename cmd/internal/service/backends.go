@@ -0,0 +1,249 @@
+// Package service holds the wiring shared by the Links 'R' Us binaries:
+// selecting and opening the graph and indexer backends, reading settings
+// from the environment and serving health checks and gRPC APIs.
+package service
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/repository/bolt"
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/repository/cdb"
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/repository/memory"
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/repository/sqlite"
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/linkgraph"
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/textindexer"
+	"github.com/bruceneco/links-r-us/internal/adapters/repository/migrations"
+	indexbleve "github.com/bruceneco/links-r-us/internal/adapters/textindexer/store/bleve"
+	indexmemory "github.com/bruceneco/links-r-us/internal/adapters/textindexer/store/memory"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	_ "github.com/lib/pq"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Graph backends selectable with GraphConfig.Backend.
+const (
+	GraphMemory   = "memory"
+	GraphCDB      = "cdb"
+	GraphPostgres = "postgres"
+	GraphSQLite   = "sqlite"
+	GraphBolt     = "bolt"
+	GraphGRPC     = "grpc"
+)
+
+// Indexer backends selectable with IndexerConfig.Backend.
+const (
+	IndexerMemory = "memory"
+	IndexerBleve  = "bleve"
+	IndexerGRPC   = "grpc"
+)
+
+// GraphConfig selects and configures a graph backend.
+type GraphConfig struct {
+	Backend                string
+	MemorySnapshot         string
+	MemorySnapshotInterval time.Duration
+	MemoryWAL              string
+	CDBDSN                 string
+	PostgresDSN            string
+	AutoMigrate            bool
+	SQLitePath             string
+	BoltPath               string
+	// Addr is the address of the linkgraph service used by the grpc
+	// backend.
+	Addr string
+}
+
+// RegisterFlags binds the graph settings to fs, falling back to the
+// environment and then to defaultBackend and the default paths.
+func (cfg *GraphConfig) RegisterFlags(fs *flag.FlagSet, defaultBackend string) {
+	fs.StringVar(&cfg.Backend, "graph", EnvOr("LINKSRUS_GRAPH", defaultBackend), "graph backend to use (memory, cdb, postgres, sqlite, bolt or grpc)")
+	fs.StringVar(&cfg.MemorySnapshot, "memory-snapshot", EnvOr("LINKSRUS_MEMORY_SNAPSHOT", ""), "snapshot file the memory graph backend is restored from and saved to periodically and on shutdown (disabled if empty)")
+	fs.DurationVar(&cfg.MemorySnapshotInterval, "memory-snapshot-interval", EnvDurationOr("LINKSRUS_MEMORY_SNAPSHOT_INTERVAL", 10*time.Minute), "how often the memory graph backend saves a snapshot while running (disabled if 0)")
+	fs.StringVar(&cfg.MemoryWAL, "memory-wal", EnvOr("LINKSRUS_MEMORY_WAL", ""), "write-ahead log used by the memory graph backend between snapshots (requires -memory-snapshot)")
+	fs.StringVar(&cfg.CDBDSN, "cdb-dsn", EnvOr("LINKSRUS_CDB_DSN", cdb.DSNFromEnv()), "CockroachDB DSN used by the cdb graph backend")
+	fs.StringVar(&cfg.PostgresDSN, "postgres-dsn", EnvOr("LINKSRUS_POSTGRES_DSN", cdb.PostgresDSNFromEnv()), "PostgreSQL DSN used by the postgres graph backend")
+	fs.BoolVar(&cfg.AutoMigrate, "auto-migrate", EnvBoolOr("LINKSRUS_AUTO_MIGRATE", true), "apply pending schema migrations at startup when using the cdb or postgres graph backends")
+	fs.StringVar(&cfg.SQLitePath, "sqlite-path", EnvOr("LINKSRUS_SQLITE_PATH", "linksrus.db"), "database file used by the sqlite graph backend")
+	fs.StringVar(&cfg.BoltPath, "bolt-path", EnvOr("LINKSRUS_BOLT_PATH", "linksrus.bolt"), "database file used by the bolt graph backend")
+	fs.StringVar(&cfg.Addr, "linkgraph-addr", EnvOr("LINKSRUS_LINKGRAPH_ADDR", "localhost:9091"), "address of the linkgraph service used by the grpc graph backend")
+}
+
+// IndexerConfig selects and configures a text indexer backend.
+type IndexerConfig struct {
+	Backend string
+	Path    string
+	// Addr is the address of the textindexer service used by the grpc
+	// backend.
+	Addr string
+}
+
+// RegisterFlags binds the indexer settings to fs, falling back to the
+// environment and then to defaultBackend and the default paths.
+func (cfg *IndexerConfig) RegisterFlags(fs *flag.FlagSet, defaultBackend string) {
+	fs.StringVar(&cfg.Backend, "indexer", EnvOr("LINKSRUS_INDEXER", defaultBackend), "text indexer backend to use (memory, bleve or grpc)")
+	fs.StringVar(&cfg.Path, "index-path", EnvOr("LINKSRUS_INDEX_PATH", "linksrus.bleve"), "directory holding the on-disk index of the bleve indexer backend")
+	fs.StringVar(&cfg.Addr, "textindexer-addr", EnvOr("LINKSRUS_TEXTINDEXER_ADDR", "localhost:9092"), "address of the textindexer service used by the grpc indexer backend")
+}
+
+// OpenGraph creates the graph repository selected by cfg along with a func
+// that releases its resources.
+func OpenGraph(ctx context.Context, logger *slog.Logger, cfg GraphConfig) (repository.GraphRepository, func(), error) {
+	switch cfg.Backend {
+	case GraphMemory:
+		if cfg.MemorySnapshot == "" {
+			if cfg.MemoryWAL != "" {
+				return nil, nil, fmt.Errorf("memory graph: -memory-wal requires -memory-snapshot")
+			}
+			return memory.NewInMemoryGraph(), func() {}, nil
+		}
+		g, err := memory.OpenInMemoryGraph(memory.PersistenceConfig{
+			SnapshotPath: cfg.MemorySnapshot,
+			WALPath:      cfg.MemoryWAL,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		stopSnapshots := saveSnapshots(ctx, logger, g, cfg.MemorySnapshotInterval)
+		return g, func() {
+			stopSnapshots()
+			if err := g.SaveSnapshot(); err != nil {
+				logger.Error("failed to save graph snapshot", "err", err)
+			}
+			_ = g.Close()
+		}, nil
+	case GraphCDB, GraphPostgres:
+		dsn := cfg.CDBDSN
+		if cfg.Backend == GraphPostgres {
+			dsn = cfg.PostgresDSN
+		}
+		db, err := sql.Open("postgres", dsn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open %s: %w", cfg.Backend, err)
+		}
+		if err = db.Ping(); err != nil {
+			_ = db.Close()
+			return nil, nil, fmt.Errorf("ping %s: %w", cfg.Backend, err)
+		}
+		if cfg.AutoMigrate {
+			if err = migrate(ctx, db, cfg.Backend); err != nil {
+				_ = db.Close()
+				return nil, nil, err
+			}
+		}
+		g := cdb.NewGraphCDBRepository(db)
+		return g, func() {
+			stats := g.RetryStats()
+			logger.Info("graph retries", "retries", stats.Retries, "exhausted", stats.Exhausted)
+			_ = db.Close()
+		}, nil
+	case GraphSQLite:
+		db, err := sqlite.Open(ctx, cfg.SQLitePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sqlite.NewGraphSQLiteRepository(db), func() { _ = db.Close() }, nil
+	case GraphBolt:
+		db, err := bolt.Open(cfg.BoltPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return bolt.NewGraphBoltRepository(db), func() { _ = db.Close() }, nil
+	case GraphGRPC:
+		conn, err := Dial(cfg.Addr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("dial linkgraph: %w", err)
+		}
+		return linkgraph.NewClient(conn), func() { _ = conn.Close() }, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported graph backend %q", cfg.Backend)
+	}
+}
+
+// OpenIndexer creates the text indexer selected by cfg along with a func
+// that releases its resources.
+func OpenIndexer(cfg IndexerConfig) (ports.TextIndexer, func(), error) {
+	var (
+		indexer ports.TextIndexer
+		err     error
+	)
+	switch cfg.Backend {
+	case IndexerMemory:
+		indexer, err = indexmemory.NewInMemoryIndexer()
+	case IndexerBleve:
+		indexer, err = indexbleve.OpenBleveIndexer(cfg.Path)
+	case IndexerGRPC:
+		conn, err := Dial(cfg.Addr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("dial textindexer: %w", err)
+		}
+		return textindexer.NewClient(conn), func() { _ = conn.Close() }, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported indexer backend %q", cfg.Backend)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if closer, ok := indexer.(io.Closer); ok {
+		return indexer, func() { _ = closer.Close() }, nil
+	}
+	return indexer, func() {}, nil
+}
+
+// Dial creates a client connection to the gRPC service at addr. The
+// connection is established lazily by the first request.
+func Dial(addr string) (*grpc.ClientConn, error) {
+	return grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+// saveSnapshots saves a snapshot of g every interval, which also truncates
+// its write-ahead log, until the returned func is called. It is a no-op if
+// interval is not positive.
+func saveSnapshots(ctx context.Context, logger *slog.Logger, g *memory.InMemoryGraph, interval time.Duration) func() {
+	if interval <= 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := g.SaveSnapshot(); err != nil {
+					logger.Error("failed to save graph snapshot", "err", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// migrate applies the pending schema migrations for the selected SQL graph
+// backend.
+func migrate(ctx context.Context, db *sql.DB, backend string) error {
+	dialect := migrations.CockroachDB
+	if backend == GraphPostgres {
+		dialect = migrations.Postgres
+	}
+	m, err := migrations.New(db, dialect)
+	if err != nil {
+		return err
+	}
+	return m.Up(ctx)
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// EnvOr returns the value of the environment variable key, or fallback if it
+// is unset.
+func EnvOr(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return fallback
+}
+
+// EnvIntOr returns the integer held by the environment variable key, or
+// fallback if it is unset or malformed.
+func EnvIntOr(key string, fallback int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return v
+	}
+	return fallback
+}
+
+// EnvDurationOr returns the duration held by the environment variable key,
+// or fallback if it is unset or malformed.
+func EnvDurationOr(key string, fallback time.Duration) time.Duration {
+	if v, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return v
+	}
+	return fallback
+}
+
+// EnvBoolOr returns the boolean held by the environment variable key, or
+// fallback if it is unset or malformed.
+func EnvBoolOr(key string, fallback bool) bool {
+	if v, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return v
+	}
+	return fallback
+}
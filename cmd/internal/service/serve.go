@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+// Main runs a Links 'R' Us binary: it calls run with a context cancelled on
+// SIGINT or SIGTERM and exits with a non-zero status if run fails.
+func Main(name string, run func(ctx context.Context, logger *slog.Logger) error) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, logger); err != nil {
+		logger.Error(name+" exited with error", "err", err)
+		stop()
+		os.Exit(1)
+	}
+}
+
+// ServeHealth answers GET /healthz on addr with 200 OK until ctx is
+// cancelled. It returns immediately if addr is empty.
+func ServeHealth(ctx context.Context, logger *slog.Logger, addr string) error {
+	if addr == "" {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("ok\n"))
+	})
+
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("health: %w", err)
+	}
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(l) }()
+	logger.Info("health endpoint listening", "addr", l.Addr().String())
+
+	select {
+	case err = <-errCh:
+		return fmt.Errorf("health: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err = srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("health: shutdown: %w", err)
+	}
+	return nil
+}
+
+// ServeGRPC serves srv on addr until ctx is cancelled, then lets in-flight
+// requests complete before returning.
+func ServeGRPC(ctx context.Context, logger *slog.Logger, addr string, srv *grpc.Server) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("grpc: %w", err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(l) }()
+	logger.Info("grpc server listening", "addr", l.Addr().String())
+
+	select {
+	case err = <-errCh:
+		return fmt.Errorf("grpc: %w", err)
+	case <-ctx.Done():
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		// Streams still open after the timeout are cut short.
+		srv.Stop()
+	}
+	if err = <-errCh; err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("grpc: %w", err)
+	}
+	return nil
+}
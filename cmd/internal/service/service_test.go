@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/repository/memory"
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/linkgraph"
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/linkgraph/linkgraphpb"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// freeAddr returns a local address nothing is listening on.
+func freeAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+	return addr
+}
+
+func TestOpenUnsupportedBackends(t *testing.T) {
+	_, _, err := OpenGraph(context.TODO(), discard, GraphConfig{Backend: "nope"})
+	assert.ErrorContains(t, err, `unsupported graph backend "nope"`)
+
+	_, _, err = OpenIndexer(IndexerConfig{Backend: "nope"})
+	assert.ErrorContains(t, err, `unsupported indexer backend "nope"`)
+}
+
+func TestGRPCGraphBackend(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	addr := freeAddr(t)
+	srv := grpc.NewServer()
+	linkgraphpb.RegisterLinkGraphServer(srv, linkgraph.NewServer(memory.NewInMemoryGraph()))
+	served := make(chan error, 1)
+	go func() { served <- ServeGRPC(ctx, discard, addr, srv) }()
+
+	g, closeGraph, err := OpenGraph(ctx, discard, GraphConfig{Backend: GraphGRPC, Addr: addr})
+	require.NoError(t, err)
+	defer closeGraph()
+
+	link := &domain.Link{URL: "https://example.com/"}
+	require.Eventually(t, func() bool { return g.UpsertLink(ctx, link) == nil }, 5*time.Second, 10*time.Millisecond)
+	found, err := g.FindLinkByURL(ctx, link.URL)
+	require.NoError(t, err)
+	assert.Equal(t, link.ID, found.ID)
+
+	cancel()
+	assert.NoError(t, <-served)
+}
+
+func TestServeHealth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	addr := freeAddr(t)
+	served := make(chan error, 1)
+	go func() { served <- ServeHealth(ctx, discard, addr) }()
+
+	var res *http.Response
+	require.Eventually(t, func() bool {
+		var err error
+		res, err = http.Get("http://" + addr + "/healthz")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	_ = res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	cancel()
+	assert.NoError(t, <-served)
+	assert.NoError(t, ServeHealth(ctx, discard, ""), "expected an empty address to disable the endpoint")
+}
@@ -0,0 +1,63 @@
+// Command linkgraph serves the link graph over gRPC so that the other
+// Links 'R' Us services can share it.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/bruceneco/links-r-us/cmd/internal/service"
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/linkgraph"
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/linkgraph/linkgraphpb"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+)
+
+// config holds the settings of the linkgraph service.
+type config struct {
+	graph      service.GraphConfig
+	grpcAddr   string
+	healthAddr string
+}
+
+func main() {
+	cfg := parseConfig(os.Args[1:])
+	service.Main("linkgraph", func(ctx context.Context, logger *slog.Logger) error {
+		return run(ctx, logger, cfg)
+	})
+}
+
+// parseConfig reads the service settings from args, falling back to the
+// environment when a flag is not provided.
+func parseConfig(args []string) config {
+	var cfg config
+	fs := flag.NewFlagSet("linkgraph", flag.ExitOnError)
+	cfg.graph.RegisterFlags(fs, service.GraphMemory)
+	fs.StringVar(&cfg.grpcAddr, "grpc-addr", service.EnvOr("LINKSRUS_GRPC_ADDR", ":9091"), "address the gRPC API listens on")
+	fs.StringVar(&cfg.healthAddr, "health-addr", service.EnvOr("LINKSRUS_HEALTH_ADDR", ":8081"), "address the health endpoint listens on (disabled if empty)")
+	_ = fs.Parse(args)
+	return cfg
+}
+
+// run serves the graph selected by cfg until ctx is cancelled.
+func run(ctx context.Context, logger *slog.Logger, cfg config) error {
+	graph, closeGraph, err := service.OpenGraph(ctx, logger, cfg.graph)
+	if err != nil {
+		return err
+	}
+	defer closeGraph()
+
+	srv := grpc.NewServer()
+	linkgraphpb.RegisterLinkGraphServer(srv, linkgraph.NewServer(graph))
+
+	logger.Info("linkgraph started", "graph", fmt.Sprintf("%T", graph))
+	group, ctx := errgroup.WithContext(ctx)
+	group.Go(func() error { return service.ServeGRPC(ctx, logger, cfg.grpcAddr, srv) })
+	group.Go(func() error { return service.ServeHealth(ctx, logger, cfg.healthAddr) })
+	err = group.Wait()
+	logger.Info("shutting down")
+	return err
+}
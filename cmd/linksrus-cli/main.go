@@ -0,0 +1,468 @@
+// Command linksrus-cli lets operators inspect and edit the link graph and
+// query the text index.
+//
+// Usage:
+//
+//	linksrus-cli [flags] link add <url>
+//	linksrus-cli [flags] link get <id>
+//	linksrus-cli [flags] links list [-from ID] [-to ID] [-before RFC3339]
+//	linksrus-cli [flags] edges list [-from ID] [-to ID] [-before RFC3339]
+//	linksrus-cli [flags] edges prune <src> [-before RFC3339]
+//	linksrus-cli [flags] stats [-within DURATION] [-partitions N]
+//	linksrus-cli [flags] search [-phrase] [-offset N] [-limit N] <query>
+//	linksrus-cli [flags] migrate <up|down|status|force> [-dialect DIALECT] [-steps N] [version]
+//
+// The graph is read from the CockroachDB database given by -dsn, or from
+// the linkgraph service if -linkgraph-addr is set. search queries the
+// textindexer service if -textindexer-addr is set, and the bleve index at
+// -index-path otherwise; a local index cannot be opened while a service
+// holds it. migrate always needs -dsn.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bruceneco/links-r-us/cmd/internal/service"
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/repository/cdb"
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/linkgraph"
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/textindexer"
+	"github.com/bruceneco/links-r-us/internal/adapters/repository/migrations"
+	indexbleve "github.com/bruceneco/links-r-us/internal/adapters/textindexer/store/bleve"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+)
+
+var errUsage = errors.New("usage: linksrus-cli [flags] <link|links|edges|stats|search|migrate> [subcommand] [args]")
+
+// config holds the global flags selecting the backends the CLI talks to.
+type config struct {
+	dsn             string
+	linkGraphAddr   string
+	textIndexerAddr string
+	indexPath       string
+}
+
+func main() {
+	cfg, args, err := parseConfig(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	} else if err != nil {
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err = run(ctx, cfg, args, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		stop()
+		os.Exit(1)
+	}
+}
+
+// parseConfig parses the global flags, falling back to the environment
+// when a flag is not provided, and returns the remaining arguments.
+func parseConfig(args []string) (config, []string, error) {
+	var cfg config
+	fs := flag.NewFlagSet("linksrus-cli", flag.ContinueOnError)
+	fs.StringVar(&cfg.dsn, "dsn", service.EnvOr("LINKSRUS_CDB_DSN", cdb.DSNFromEnv()), "CockroachDB DSN of the link graph")
+	fs.StringVar(&cfg.linkGraphAddr, "linkgraph-addr", os.Getenv("LINKSRUS_LINKGRAPH_ADDR"), "address of the linkgraph service; takes precedence over -dsn for graph commands")
+	fs.StringVar(&cfg.textIndexerAddr, "textindexer-addr", os.Getenv("LINKSRUS_TEXTINDEXER_ADDR"), "address of the textindexer service queried by search; takes precedence over -index-path")
+	fs.StringVar(&cfg.indexPath, "index-path", service.EnvOr("LINKSRUS_INDEX_PATH", "linksrus.bleve"), "directory holding the bleve index queried by search")
+	if err := fs.Parse(args); err != nil {
+		return config{}, nil, err
+	}
+	return cfg, fs.Args(), nil
+}
+
+// run opens the backends needed by the command in args and executes it.
+func run(ctx context.Context, cfg config, args []string, out io.Writer) error {
+	if len(args) == 0 || (args[0] != "stats" && len(args) < 2) {
+		return errUsage
+	}
+
+	c := &cli{out: out}
+	var (
+		closeBackend func()
+		err          error
+	)
+	switch args[0] {
+	case "search":
+		c.indexer, closeBackend, err = openIndexer(cfg)
+	case "migrate":
+		c.db, closeBackend, err = openDB(cfg)
+	default:
+		c.graph, closeBackend, err = openGraph(cfg)
+	}
+	if err != nil {
+		return err
+	}
+	defer closeBackend()
+
+	return c.dispatch(ctx, args)
+}
+
+// openDB opens the CockroachDB database of the link graph.
+func openDB(cfg config) (*sql.DB, func(), error) {
+	if cfg.dsn == "" {
+		return nil, nil, errors.New("a -dsn is required")
+	}
+	db, err := sql.Open("postgres", cfg.dsn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open cdb: %w", err)
+	}
+	return db, func() { _ = db.Close() }, nil
+}
+
+// openGraph opens the link graph, through the linkgraph service if its
+// address is configured and through the database otherwise.
+func openGraph(cfg config) (repository.GraphRepository, func(), error) {
+	if cfg.linkGraphAddr != "" {
+		conn, err := service.Dial(cfg.linkGraphAddr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("dial linkgraph: %w", err)
+		}
+		return linkgraph.NewClient(conn), func() { _ = conn.Close() }, nil
+	}
+	db, closeDB, err := openDB(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cdb.NewGraphCDBRepository(db), closeDB, nil
+}
+
+// openIndexer opens the text index, through the textindexer service if its
+// address is configured and from the local bleve index otherwise.
+func openIndexer(cfg config) (ports.TextIndexer, func(), error) {
+	if cfg.textIndexerAddr != "" {
+		conn, err := service.Dial(cfg.textIndexerAddr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("dial textindexer: %w", err)
+		}
+		return textindexer.NewClient(conn), func() { _ = conn.Close() }, nil
+	}
+	// Opening a missing index would create an empty one; report it instead.
+	if _, err := os.Stat(cfg.indexPath); err != nil {
+		return nil, nil, fmt.Errorf("open index: %w", err)
+	}
+	idx, err := indexbleve.OpenBleveIndexer(cfg.indexPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return idx, func() { _ = idx.Close() }, nil
+}
+
+// cli executes operator commands against a graph repository, a text
+// indexer or the graph database, depending on the command.
+type cli struct {
+	db      *sql.DB
+	graph   repository.GraphRepository
+	indexer ports.TextIndexer
+	out     io.Writer
+}
+
+// dispatch executes the command in args.
+func (c *cli) dispatch(ctx context.Context, args []string) error {
+	switch args[0] {
+	case "stats":
+		return c.stats(ctx, args[1:])
+	case "search":
+		return c.search(ctx, args[1:])
+	}
+
+	switch cmd := args[0] + " " + args[1]; cmd {
+	case "link add":
+		return c.addLink(ctx, args[2:])
+	case "link get":
+		return c.getLink(ctx, args[2:])
+	case "links list":
+		return c.listLinks(ctx, args[2:])
+	case "edges list":
+		return c.listEdges(ctx, args[2:])
+	case "edges prune":
+		return c.pruneEdges(ctx, args[2:])
+	case "migrate up", "migrate down", "migrate status", "migrate force":
+		return c.migrate(ctx, args[1], args[2:])
+	default:
+		return fmt.Errorf("unknown command %q\n%w", cmd, errUsage)
+	}
+}
+
+func (c *cli) addLink(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: link add <url>")
+	}
+
+	link := &domain.Link{URL: args[0]}
+	if err := c.graph.UpsertLink(ctx, link); err != nil {
+		return err
+	}
+	return c.printLinks(link)
+}
+
+func (c *cli) getLink(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: link get <id>")
+	}
+
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid link id: %w", err)
+	}
+	link, err := c.graph.FindLink(ctx, id)
+	if err != nil {
+		return err
+	}
+	return c.printLinks(link)
+}
+
+func (c *cli) listLinks(ctx context.Context, args []string) error {
+	ids, before, err := parseRangeFlags("links list", args)
+	if err != nil {
+		return err
+	}
+
+	it, err := c.graph.Links(ctx, ids, before)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = it.Close() }()
+
+	var links []*domain.Link
+	for it.Next() {
+		links = append(links, it.Link())
+	}
+	if err = it.Error(); err != nil {
+		return err
+	}
+	return c.printLinks(links...)
+}
+
+func (c *cli) listEdges(ctx context.Context, args []string) error {
+	ids, before, err := parseRangeFlags("edges list", args)
+	if err != nil {
+		return err
+	}
+
+	it, err := c.graph.Edges(ctx, ids, before)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = it.Close() }()
+
+	w := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSRC\tDST\tUPDATED AT\tKIND\tNOFOLLOW\tANCHOR TEXT")
+	for it.Next() {
+		e := it.Edge()
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\t%q\n", e.ID, e.Src, e.Dst, formatTime(e.UpdatedAt), e.Kind, e.Rel.Has(domain.EdgeRelNoFollow), e.AnchorText)
+	}
+	if err = it.Error(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func (c *cli) pruneEdges(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errors.New("usage: edges prune <src> [-before RFC3339]")
+	}
+
+	src, err := uuid.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid source link id: %w", err)
+	}
+	fs := flag.NewFlagSet("edges prune", flag.ContinueOnError)
+	before := fs.String("before", "", "remove edges updated before this RFC3339 timestamp (default now)")
+	if err = fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	updatedBefore, err := parseTime(*before)
+	if err != nil {
+		return err
+	}
+	return c.graph.RemoveStaleEdges(ctx, src, updatedBefore)
+}
+
+func (c *cli) stats(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	within := fs.Duration("within", 24*time.Hour, "window used to count recently retrieved links")
+	numPartitions := fs.Int("partitions", 1, "number of partitions used to break down the edge count")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	stats, err := c.graph.Stats(ctx, repository.GraphStatsOptions{
+		RetrievedWithin: *within,
+		NumPartitions:   *numPartitions,
+	})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "links\t%d\n", stats.Links)
+	fmt.Fprintf(w, "edges\t%d\n", stats.Edges)
+	fmt.Fprintf(w, "never retrieved links\t%d\n", stats.UnretrievedLinks)
+	fmt.Fprintf(w, "links retrieved within %s\t%d\n", *within, stats.RecentlyRetrievedLinks)
+	for p, count := range stats.EdgesPerPartition {
+		fmt.Fprintf(w, "edges in partition %d\t%d\n", p, count)
+	}
+	printHistogram(w, "in-degree", stats.InDegreeHistogram)
+	printHistogram(w, "out-degree", stats.OutDegreeHistogram)
+	return w.Flush()
+}
+
+func (c *cli) search(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	phrase := fs.Bool("phrase", false, "match the query as an exact phrase")
+	offset := fs.Uint64("offset", 0, "number of results to skip")
+	limit := fs.Int("limit", 10, "maximum number of results to print")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New("usage: search [-phrase] [-offset N] [-limit N] <query>")
+	}
+
+	query := &ports.DocumentQuery{Type: ports.DocumentQueryTypeMatch, Expression: strings.Join(fs.Args(), " "), Offset: *offset}
+	if *phrase {
+		query.Type = ports.DocumentQueryTypePhrase
+	}
+	it, err := c.indexer.Search(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = it.Close() }()
+
+	w := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "matches: %d\n", it.TotalCount())
+	fmt.Fprintln(w, "LINK ID\tPAGERANK\tURL\tTITLE")
+	for n := 0; n < *limit && it.Next(); n++ {
+		d := it.Document()
+		fmt.Fprintf(w, "%s\t%.6f\t%s\t%s\n", d.LinkID, d.PageRank, d.URL, d.Title)
+	}
+	if err = it.Error(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func printHistogram(w io.Writer, name string, histogram map[int]int) {
+	degrees := make([]int, 0, len(histogram))
+	for degree := range histogram {
+		degrees = append(degrees, degree)
+	}
+	sort.Ints(degrees)
+	for _, degree := range degrees {
+		fmt.Fprintf(w, "links with %s %d\t%d\n", name, degree, histogram[degree])
+	}
+}
+
+func (c *cli) printLinks(links ...*domain.Link) error {
+	w := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tURL\tRETRIEVED AT\tSTATUS\tFAILURES\tLAST ERROR")
+	for _, l := range links {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\n", l.ID, l.URL, formatTime(l.RetrievedAt), l.StatusCode, l.FailureCount, l.LastError)
+	}
+	return w.Flush()
+}
+
+func (c *cli) migrate(ctx context.Context, action string, args []string) error {
+	fs := flag.NewFlagSet("migrate "+action, flag.ContinueOnError)
+	dialect := fs.String("dialect", string(migrations.CockroachDB), "migrations to use (cockroachdb or postgres)")
+	steps := fs.Int("steps", 1, "number of migrations to roll back; 0 rolls back all of them (down only)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	m, err := migrations.New(c.db, migrations.Dialect(*dialect))
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "up":
+		return m.Up(ctx)
+	case "down":
+		return m.Down(ctx, *steps)
+	case "force":
+		if fs.NArg() != 1 {
+			return errors.New("usage: migrate force [-dialect DIALECT] <version>")
+		}
+		version, err := strconv.ParseUint(fs.Arg(0), 10, 0)
+		if err != nil {
+			return fmt.Errorf("invalid version: %w", err)
+		}
+		return m.Force(ctx, uint(version))
+	}
+
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "version\t%d\n", status.Version)
+	fmt.Fprintf(w, "dirty\t%t\n", status.Dirty)
+	for _, pending := range status.Pending {
+		fmt.Fprintf(w, "pending\t%d_%s\n", pending.Version, pending.Name)
+	}
+	return w.Flush()
+}
+
+// parseRangeFlags parses the -from, -to and -before flags shared by the list
+// subcommands. The range defaults to the full UUID space and the timestamp
+// to the current time.
+func parseRangeFlags(name string, args []string) (ids repository.IDRange, before time.Time, err error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fromStr := fs.String("from", uuid.Nil.String(), "first ID of the range")
+	toStr := fs.String("to", repository.MaxID.String(), "end of the range, excluded unless it is the maximum UUID")
+	beforeStr := fs.String("before", "", "only include entries older than this RFC3339 timestamp (default now)")
+	if err = fs.Parse(args); err != nil {
+		return
+	}
+
+	if ids.From, err = uuid.Parse(*fromStr); err != nil {
+		err = fmt.Errorf("invalid -from: %w", err)
+		return
+	}
+	if ids.To, err = uuid.Parse(*toStr); err != nil {
+		err = fmt.Errorf("invalid -to: %w", err)
+		return
+	}
+	before, err = parseTime(*beforeStr)
+	return
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Now(), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp: %w", err)
+	}
+	return t, nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
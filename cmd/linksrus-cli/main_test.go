@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/repository/memory"
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/linkgraph"
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/linkgraph/linkgraphpb"
+	indexmemory "github.com/bruceneco/links-r-us/internal/adapters/textindexer/store/memory"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// newCLI returns a cli backed by an in-memory graph and indexer, along with
+// the buffer receiving its output.
+func newCLI(t *testing.T) (*cli, *bytes.Buffer) {
+	indexer, err := indexmemory.NewInMemoryIndexer()
+	require.NoError(t, err)
+	out := new(bytes.Buffer)
+	return &cli{graph: memory.NewInMemoryGraph(), indexer: indexer, out: out}, out
+}
+
+func (c *cli) exec(t *testing.T, out *bytes.Buffer, args ...string) string {
+	out.Reset()
+	require.NoError(t, c.dispatch(context.TODO(), args), strings.Join(args, " "))
+	return out.String()
+}
+
+func TestParseConfig(t *testing.T) {
+	t.Setenv("LINKSRUS_LINKGRAPH_ADDR", "linkgraph:9091")
+	t.Setenv("LINKSRUS_TEXTINDEXER_ADDR", "")
+	cfg, args, err := parseConfig([]string{"-dsn", "postgres://db", "-index-path", "/tmp/idx", "links", "list", "-from", "x"})
+	require.NoError(t, err)
+	assert.Equal(t, config{
+		dsn:           "postgres://db",
+		linkGraphAddr: "linkgraph:9091",
+		indexPath:     "/tmp/idx",
+	}, cfg)
+	assert.Equal(t, []string{"links", "list", "-from", "x"}, args)
+}
+
+func TestRunUsage(t *testing.T) {
+	for _, args := range [][]string{nil, {"link"}, {"search"}} {
+		err := run(context.TODO(), config{}, args, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errUsage, args)
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	ctx := context.TODO()
+	c, out := newCLI(t)
+
+	assert.Contains(t, c.exec(t, out, "link", "add", "https://example.com/"), "https://example.com/")
+	src, err := c.graph.FindLinkByURL(ctx, "https://example.com/")
+	require.NoError(t, err)
+	dst := &domain.Link{URL: "https://example.com/about"}
+	require.NoError(t, c.graph.UpsertLink(ctx, dst))
+	require.NoError(t, c.graph.UpsertEdge(ctx, &domain.Edge{Src: src.ID, Dst: dst.ID, AnchorText: "About us"}))
+
+	assert.Contains(t, c.exec(t, out, "link", "get", src.ID.String()), "https://example.com/")
+	listed := c.exec(t, out, "links", "list")
+	assert.Contains(t, listed, src.URL)
+	assert.Contains(t, listed, dst.URL)
+	assert.Contains(t, c.exec(t, out, "edges", "list"), `"About us"`)
+	assert.Regexp(t, `links with out-degree 1\s+1\n`, c.exec(t, out, "stats"))
+
+	c.exec(t, out, "edges", "prune", src.ID.String())
+	_, outDegree, err := c.graph.Degree(ctx, src.ID)
+	require.NoError(t, err)
+	assert.Zero(t, outDegree)
+
+	err = c.dispatch(ctx, []string{"link", "remove", src.ID.String()})
+	assert.ErrorIs(t, err, errUsage)
+	assert.Error(t, c.dispatch(ctx, []string{"link", "get", "not-an-id"}))
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.TODO()
+	c, out := newCLI(t)
+	for _, doc := range []*domain.Document{
+		{LinkID: linkID(t, c, "https://example.com/penguins"), URL: "https://example.com/penguins", Title: "Penguins", Content: "a penguin colony by the sea"},
+		{LinkID: linkID(t, c, "https://example.com/seals"), URL: "https://example.com/seals", Title: "Seals", Content: "a seal colony and a penguin"},
+	} {
+		require.NoError(t, c.indexer.Index(ctx, doc))
+	}
+
+	res := c.exec(t, out, "search", "penguin")
+	assert.Contains(t, res, "matches: 2")
+	assert.Contains(t, res, "https://example.com/penguins")
+	assert.Contains(t, res, "https://example.com/seals")
+
+	res = c.exec(t, out, "search", "-phrase", "penguin", "colony")
+	assert.Contains(t, res, "matches: 1")
+	assert.NotContains(t, res, "https://example.com/seals")
+
+	assert.Error(t, c.dispatch(ctx, []string{"search", "-phrase"}))
+}
+
+func TestRunSearchMissingIndex(t *testing.T) {
+	cfg := config{indexPath: filepath.Join(t.TempDir(), "missing.bleve")}
+	err := run(context.TODO(), cfg, []string{"search", "penguin"}, new(bytes.Buffer))
+	assert.ErrorContains(t, err, "open index")
+}
+
+func TestRunLinkGraphService(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	g := memory.NewInMemoryGraph()
+	linkgraphpb.RegisterLinkGraphServer(srv, linkgraph.NewServer(g))
+	go func() { _ = srv.Serve(l) }()
+	defer srv.Stop()
+
+	out := new(bytes.Buffer)
+	cfg := config{linkGraphAddr: l.Addr().String()}
+	require.NoError(t, run(context.TODO(), cfg, []string{"link", "add", "https://example.com/"}, out))
+	assert.Contains(t, out.String(), "https://example.com/")
+
+	_, err = g.FindLinkByURL(context.TODO(), "https://example.com/")
+	assert.NoError(t, err, "expected the link to be added through the service")
+}
+
+// linkID upserts a link for url and returns its ID.
+func linkID(t *testing.T, c *cli, url string) uuid.UUID {
+	link := &domain.Link{URL: url}
+	require.NoError(t, c.graph.UpsertLink(context.TODO(), link))
+	return link.ID
+}
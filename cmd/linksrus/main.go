@@ -0,0 +1,124 @@
+// Command linksrus runs every Links 'R' Us component inside a single process.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/bruceneco/links-r-us/cmd/internal/service"
+	"github.com/bruceneco/links-r-us/internal/adapters/frontend"
+	"github.com/bruceneco/links-r-us/internal/application/crawler"
+	"github.com/bruceneco/links-r-us/internal/application/pagerank"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"golang.org/x/sync/errgroup"
+)
+
+// components groups the shared dependencies handed to each service hosted
+// by the monolith.
+type components struct {
+	graph   repository.GraphRepository
+	indexer ports.TextIndexer
+}
+
+// config holds the settings used to wire the monolith together.
+type config struct {
+	graph   service.GraphConfig
+	indexer service.IndexerConfig
+
+	listenAddr       string
+	crawlerWorkers   int
+	crawlerInterval  time.Duration
+	reCrawlInterval  time.Duration
+	pageRankInterval time.Duration
+	ignoreNoFollow   bool
+}
+
+func main() {
+	cfg := parseConfig(os.Args[1:])
+	service.Main("linksrus", func(ctx context.Context, logger *slog.Logger) error {
+		return run(ctx, logger, cfg)
+	})
+}
+
+// parseConfig reads the monolith settings from args, falling back to the
+// environment when a flag is not provided.
+func parseConfig(args []string) config {
+	var cfg config
+	fs := flag.NewFlagSet("linksrus", flag.ExitOnError)
+	cfg.graph.RegisterFlags(fs, service.GraphMemory)
+	cfg.indexer.RegisterFlags(fs, service.IndexerMemory)
+	fs.StringVar(&cfg.listenAddr, "listen-addr", service.EnvOr("LINKSRUS_LISTEN_ADDR", ":8080"), "address the frontend listens on")
+	fs.IntVar(&cfg.crawlerWorkers, "crawler-workers", service.EnvIntOr("LINKSRUS_CRAWLER_WORKERS", 4), "number of links the crawler fetches concurrently")
+	fs.DurationVar(&cfg.crawlerInterval, "crawler-interval", service.EnvDurationOr("LINKSRUS_CRAWLER_INTERVAL", 5*time.Minute), "time between crawler passes")
+	fs.DurationVar(&cfg.reCrawlInterval, "recrawl-interval", service.EnvDurationOr("LINKSRUS_RECRAWL_INTERVAL", 7*24*time.Hour), "time after which the crawler fetches a link again")
+	fs.DurationVar(&cfg.pageRankInterval, "pagerank-interval", service.EnvDurationOr("LINKSRUS_PAGERANK_INTERVAL", time.Hour), "time between PageRank passes")
+	fs.BoolVar(&cfg.ignoreNoFollow, "pagerank-ignore-nofollow", service.EnvBoolOr("LINKSRUS_PAGERANK_IGNORE_NOFOLLOW", false), "leave rel=nofollow edges out of the PageRank computation")
+	_ = fs.Parse(args)
+	return cfg
+}
+
+// run wires the components and blocks until ctx is cancelled.
+func run(ctx context.Context, logger *slog.Logger, cfg config) error {
+	graph, closeGraph, err := service.OpenGraph(ctx, logger, cfg.graph)
+	if err != nil {
+		return err
+	}
+	defer closeGraph()
+
+	indexer, closeIndexer, err := service.OpenIndexer(cfg.indexer)
+	if err != nil {
+		return err
+	}
+	defer closeIndexer()
+
+	return serve(ctx, logger, cfg, components{graph: graph, indexer: indexer})
+}
+
+// serve hosts the crawler, PageRank calculator and frontend until ctx is
+// cancelled or one of them fails, in which case the others are stopped too.
+func serve(ctx context.Context, logger *slog.Logger, cfg config, c components) error {
+	crawlerSvc, err := crawler.NewCrawler(crawler.Config{
+		Graph:           c.graph,
+		Indexer:         c.indexer,
+		Workers:         cfg.crawlerWorkers,
+		Interval:        cfg.crawlerInterval,
+		ReCrawlInterval: cfg.reCrawlInterval,
+		Logger:          logger.With("service", "crawler"),
+	})
+	if err != nil {
+		return err
+	}
+	calculator, err := pagerank.NewCalculator(pagerank.Config{
+		Graph:          c.graph,
+		Indexer:        c.indexer,
+		Interval:       cfg.pageRankInterval,
+		IgnoreNoFollow: cfg.ignoreNoFollow,
+		Logger:         logger.With("service", "pagerank"),
+	})
+	if err != nil {
+		return err
+	}
+	fe, err := frontend.NewFrontend(frontend.Config{
+		Graph:      c.graph,
+		Indexer:    c.indexer,
+		ListenAddr: cfg.listenAddr,
+		Logger:     logger.With("service", "frontend"),
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("linksrus started", "graph", fmt.Sprintf("%T", c.graph), "indexer", fmt.Sprintf("%T", c.indexer))
+	group, ctx := errgroup.WithContext(ctx)
+	group.Go(func() error { return crawlerSvc.Run(ctx) })
+	group.Go(func() error { return calculator.Run(ctx) })
+	group.Go(func() error { return fe.Run(ctx) })
+	err = group.Wait()
+	logger.Info("shutting down")
+	return err
+}
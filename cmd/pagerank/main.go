@@ -0,0 +1,81 @@
+// Command pagerank periodically computes the PageRank scores of the link
+// graph served by the linkgraph service and stores them in the textindexer
+// service.
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/bruceneco/links-r-us/cmd/internal/service"
+	"github.com/bruceneco/links-r-us/internal/application/pagerank"
+	"golang.org/x/sync/errgroup"
+)
+
+// config holds the settings of the pagerank service.
+type config struct {
+	graph   service.GraphConfig
+	indexer service.IndexerConfig
+
+	interval       time.Duration
+	ignoreNoFollow bool
+	healthAddr     string
+}
+
+func main() {
+	cfg := parseConfig(os.Args[1:])
+	service.Main("pagerank", func(ctx context.Context, logger *slog.Logger) error {
+		return run(ctx, logger, cfg)
+	})
+}
+
+// parseConfig reads the service settings from args, falling back to the
+// environment when a flag is not provided.
+func parseConfig(args []string) config {
+	var cfg config
+	fs := flag.NewFlagSet("pagerank", flag.ExitOnError)
+	cfg.graph.RegisterFlags(fs, service.GraphGRPC)
+	cfg.indexer.RegisterFlags(fs, service.IndexerGRPC)
+	fs.DurationVar(&cfg.interval, "interval", service.EnvDurationOr("LINKSRUS_PAGERANK_INTERVAL", time.Hour), "time between passes")
+	fs.BoolVar(&cfg.ignoreNoFollow, "ignore-nofollow", service.EnvBoolOr("LINKSRUS_PAGERANK_IGNORE_NOFOLLOW", false), "leave rel=nofollow edges out of the computation")
+	fs.StringVar(&cfg.healthAddr, "health-addr", service.EnvOr("LINKSRUS_HEALTH_ADDR", ":8084"), "address the health endpoint listens on (disabled if empty)")
+	_ = fs.Parse(args)
+	return cfg
+}
+
+// run updates the PageRank scores until ctx is cancelled.
+func run(ctx context.Context, logger *slog.Logger, cfg config) error {
+	graph, closeGraph, err := service.OpenGraph(ctx, logger, cfg.graph)
+	if err != nil {
+		return err
+	}
+	defer closeGraph()
+
+	indexer, closeIndexer, err := service.OpenIndexer(cfg.indexer)
+	if err != nil {
+		return err
+	}
+	defer closeIndexer()
+
+	calculator, err := pagerank.NewCalculator(pagerank.Config{
+		Graph:          graph,
+		Indexer:        indexer,
+		Interval:       cfg.interval,
+		IgnoreNoFollow: cfg.ignoreNoFollow,
+		Logger:         logger,
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("pagerank started")
+	group, ctx := errgroup.WithContext(ctx)
+	group.Go(func() error { return calculator.Run(ctx) })
+	group.Go(func() error { return service.ServeHealth(ctx, logger, cfg.healthAddr) })
+	err = group.Wait()
+	logger.Info("shutting down")
+	return err
+}
@@ -0,0 +1,63 @@
+// Command textindexer serves the text index over gRPC so that the other
+// Links 'R' Us services can share it.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/bruceneco/links-r-us/cmd/internal/service"
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/textindexer"
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/textindexer/textindexerpb"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+)
+
+// config holds the settings of the textindexer service.
+type config struct {
+	indexer    service.IndexerConfig
+	grpcAddr   string
+	healthAddr string
+}
+
+func main() {
+	cfg := parseConfig(os.Args[1:])
+	service.Main("textindexer", func(ctx context.Context, logger *slog.Logger) error {
+		return run(ctx, logger, cfg)
+	})
+}
+
+// parseConfig reads the service settings from args, falling back to the
+// environment when a flag is not provided.
+func parseConfig(args []string) config {
+	var cfg config
+	fs := flag.NewFlagSet("textindexer", flag.ExitOnError)
+	cfg.indexer.RegisterFlags(fs, service.IndexerMemory)
+	fs.StringVar(&cfg.grpcAddr, "grpc-addr", service.EnvOr("LINKSRUS_GRPC_ADDR", ":9092"), "address the gRPC API listens on")
+	fs.StringVar(&cfg.healthAddr, "health-addr", service.EnvOr("LINKSRUS_HEALTH_ADDR", ":8082"), "address the health endpoint listens on (disabled if empty)")
+	_ = fs.Parse(args)
+	return cfg
+}
+
+// run serves the indexer selected by cfg until ctx is cancelled.
+func run(ctx context.Context, logger *slog.Logger, cfg config) error {
+	indexer, closeIndexer, err := service.OpenIndexer(cfg.indexer)
+	if err != nil {
+		return err
+	}
+	defer closeIndexer()
+
+	srv := grpc.NewServer()
+	textindexerpb.RegisterTextIndexerServer(srv, textindexer.NewServer(indexer))
+
+	logger.Info("textindexer started", "indexer", fmt.Sprintf("%T", indexer))
+	group, ctx := errgroup.WithContext(ctx)
+	group.Go(func() error { return service.ServeGRPC(ctx, logger, cfg.grpcAddr, srv) })
+	group.Go(func() error { return service.ServeHealth(ctx, logger, cfg.healthAddr) })
+	err = group.Wait()
+	logger.Info("shutting down")
+	return err
+}
@@ -0,0 +1,249 @@
+// Package frontend serves the web pages users search the index and submit
+// new links through.
+package frontend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+)
+
+const (
+	defaultListenAddr     = ":8080"
+	defaultResultsPerPage = 10
+	shutdownTimeout       = 10 * time.Second
+)
+
+// Config holds the dependencies and settings of a Frontend. Zero values are
+// replaced with sensible defaults.
+type Config struct {
+	// Graph receives the links submitted by users.
+	Graph repository.GraphRepository
+	// Indexer is searched for the documents matching user queries.
+	Indexer ports.TextIndexer
+
+	// ListenAddr is the address Run serves the pages on.
+	ListenAddr string
+	// ResultsPerPage is the number of search results shown per page.
+	ResultsPerPage int
+
+	// Logger receives the errors encountered while serving requests.
+	Logger *slog.Logger
+}
+
+func (cfg *Config) validate() error {
+	var err error
+	if cfg.Graph == nil {
+		err = errors.Join(err, errors.New("graph not provided"))
+	}
+	if cfg.Indexer == nil {
+		err = errors.Join(err, errors.New("indexer not provided"))
+	}
+	if cfg.ListenAddr == "" {
+		cfg.ListenAddr = defaultListenAddr
+	}
+	if cfg.ResultsPerPage <= 0 {
+		cfg.ResultsPerPage = defaultResultsPerPage
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	return err
+}
+
+// Frontend is the http.Handler serving the search, link submission and
+// health check pages.
+type Frontend struct {
+	cfg Config
+	mux *http.ServeMux
+}
+
+// NewFrontend creates a Frontend using cfg.
+func NewFrontend(cfg Config) (*Frontend, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("frontend: config validation failed: %w", err)
+	}
+
+	f := &Frontend{cfg: cfg, mux: http.NewServeMux()}
+	f.mux.HandleFunc("GET /{$}", f.renderIndex)
+	f.mux.HandleFunc("GET /search", f.renderSearch)
+	f.mux.HandleFunc("GET /submit", f.renderSubmit)
+	f.mux.HandleFunc("POST /submit", f.submitLink)
+	f.mux.HandleFunc("GET /healthz", f.health)
+	return f, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (f *Frontend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mux.ServeHTTP(w, r)
+}
+
+// Run serves the pages on cfg.ListenAddr until ctx is cancelled, and then
+// waits for in-flight requests to complete.
+func (f *Frontend) Run(ctx context.Context) error {
+	l, err := net.Listen("tcp", f.cfg.ListenAddr)
+	if err != nil {
+		return fmt.Errorf("frontend: %w", err)
+	}
+	srv := &http.Server{
+		Handler:           f,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(l) }()
+	f.cfg.Logger.Info("frontend listening", "addr", l.Addr().String())
+
+	select {
+	case err = <-errCh:
+		return fmt.Errorf("frontend: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err = srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("frontend: shutdown: %w", err)
+	}
+	return nil
+}
+
+func (f *Frontend) renderIndex(w http.ResponseWriter, _ *http.Request) {
+	f.render(w, http.StatusOK, indexTemplate, nil)
+}
+
+// searchPage is the data of the search results template.
+type searchPage struct {
+	Query      string
+	Results    []*domain.Document
+	TotalCount uint64
+	From       uint64
+	To         uint64
+	PrevOffset int64
+	NextOffset int64
+}
+
+// renderSearch shows a page of the documents matching the q query
+// parameter, starting at the offset parameter. Queries enclosed in double
+// quotes match the exact phrase.
+func (f *Frontend) renderSearch(w http.ResponseWriter, r *http.Request) {
+	expr := strings.TrimSpace(r.URL.Query().Get("q"))
+	if expr == "" {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	offset, _ := strconv.ParseUint(r.URL.Query().Get("offset"), 10, 64)
+
+	query := &ports.DocumentQuery{Type: ports.DocumentQueryTypeMatch, Expression: expr, Offset: offset}
+	if len(expr) > 1 && strings.HasPrefix(expr, `"`) && strings.HasSuffix(expr, `"`) {
+		query.Type = ports.DocumentQueryTypePhrase
+		query.Expression = strings.Trim(expr, `"`)
+	}
+
+	results, total, err := f.search(r.Context(), query)
+	if err != nil {
+		f.cfg.Logger.Error("search failed", "query", expr, "err", err)
+		http.Error(w, "search failed", http.StatusInternalServerError)
+		return
+	}
+
+	page := searchPage{
+		Query:      expr,
+		Results:    results,
+		TotalCount: total,
+		From:       offset + 1,
+		To:         offset + uint64(len(results)),
+		PrevOffset: -1,
+		NextOffset: -1,
+	}
+	if offset > 0 {
+		page.PrevOffset = int64(offset) - int64(min(offset, uint64(f.cfg.ResultsPerPage)))
+	}
+	if page.To < total {
+		page.NextOffset = int64(page.To)
+	}
+	f.render(w, http.StatusOK, searchTemplate, page)
+}
+
+// search returns a page of the documents matching query along with the
+// total number of matches.
+func (f *Frontend) search(ctx context.Context, query *ports.DocumentQuery) ([]*domain.Document, uint64, error) {
+	it, err := f.cfg.Indexer.Search(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = it.Close() }()
+
+	var results []*domain.Document
+	for len(results) < f.cfg.ResultsPerPage && it.Next() {
+		results = append(results, it.Document())
+	}
+	if err = it.Error(); err != nil {
+		return nil, 0, err
+	}
+	return results, it.TotalCount(), nil
+}
+
+// submitPage is the data of the link submission template.
+type submitPage struct {
+	URL     string
+	Message string
+	Failed  bool
+}
+
+func (f *Frontend) renderSubmit(w http.ResponseWriter, _ *http.Request) {
+	f.render(w, http.StatusOK, submitTemplate, submitPage{})
+}
+
+// submitLink adds the link in the url form field to the graph, so it is
+// fetched by the next crawler pass.
+func (f *Frontend) submitLink(w http.ResponseWriter, r *http.Request) {
+	raw := strings.TrimSpace(r.PostFormValue("url"))
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		f.render(w, http.StatusBadRequest, submitTemplate, submitPage{
+			URL:     raw,
+			Message: "Please enter an absolute http or https URL.",
+			Failed:  true,
+		})
+		return
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	if err = f.cfg.Graph.UpsertLink(r.Context(), &domain.Link{URL: u.String()}); err != nil {
+		f.cfg.Logger.Error("link submission failed", "url", u.String(), "err", err)
+		http.Error(w, "link submission failed", http.StatusInternalServerError)
+		return
+	}
+	f.render(w, http.StatusOK, submitTemplate, submitPage{
+		Message: fmt.Sprintf("%s will be crawled shortly.", u.String()),
+	})
+}
+
+// health reports that the frontend is serving requests.
+func (f *Frontend) health(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = fmt.Fprintln(w, "ok")
+}
+
+func (f *Frontend) render(w http.ResponseWriter, status int, tmpl *template.Template, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := tmpl.Execute(w, data); err != nil {
+		f.cfg.Logger.Error("failed to render page", "template", tmpl.Name(), "err", err)
+	}
+}
@@ -0,0 +1,109 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/repository/memory"
+	indexmemory "github.com/bruceneco/links-r-us/internal/adapters/textindexer/store/memory"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFrontend(t *testing.T) (*Frontend, repository.GraphRepository, ports.TextIndexer) {
+	indexer, err := indexmemory.NewInMemoryIndexer()
+	require.NoError(t, err)
+	graph := memory.NewInMemoryGraph()
+	f, err := NewFrontend(Config{Graph: graph, Indexer: indexer, ResultsPerPage: 2})
+	require.NoError(t, err)
+	return f, graph, indexer
+}
+
+func serve(f *Frontend, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	f.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSearch(t *testing.T) {
+	f, _, indexer := newTestFrontend(t)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, indexer.Index(context.TODO(), &domain.Document{
+			LinkID:  uuid.New(),
+			URL:     fmt.Sprintf("https://example.com/%d", i),
+			Title:   fmt.Sprintf("Gopher page %d", i),
+			Content: "all about gophers",
+		}))
+	}
+
+	rec := serve(f, httptest.NewRequest(http.MethodGet, "/search?q=gophers", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, "Results 1-2 of 3")
+	assert.Equal(t, 2, strings.Count(body, "<li>"))
+	assert.Contains(t, body, "offset=2")
+
+	rec = serve(f, httptest.NewRequest(http.MethodGet, "/search?q=gophers&offset=2", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	body = rec.Body.String()
+	assert.Contains(t, body, "Results 3-3 of 3")
+	assert.Contains(t, body, "offset=0")
+	assert.NotContains(t, body, "Next")
+
+	rec = serve(f, httptest.NewRequest(http.MethodGet, "/search?q=%22penguin+colony%22", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "No results found")
+
+	rec = serve(f, httptest.NewRequest(http.MethodGet, "/search?q=", nil))
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+}
+
+func TestSubmitLink(t *testing.T) {
+	f, graph, _ := newTestFrontend(t)
+
+	form := url.Values{"url": {"https://example.com/new#section"}}
+	req := httptest.NewRequest(http.MethodPost, "/submit", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := serve(f, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "will be crawled shortly")
+
+	_, err := graph.FindLinkByURL(context.TODO(), "https://example.com/new")
+	assert.NoError(t, err)
+
+	form = url.Values{"url": {"ftp://example.com/file"}}
+	req = httptest.NewRequest(http.MethodPost, "/submit", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = serve(f, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "absolute http or https URL")
+}
+
+func TestPages(t *testing.T) {
+	f, _, _ := newTestFrontend(t)
+
+	specs := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{method: http.MethodGet, path: "/", status: http.StatusOK},
+		{method: http.MethodGet, path: "/submit", status: http.StatusOK},
+		{method: http.MethodGet, path: "/healthz", status: http.StatusOK},
+		{method: http.MethodGet, path: "/unknown", status: http.StatusNotFound},
+		{method: http.MethodDelete, path: "/submit", status: http.StatusMethodNotAllowed},
+	}
+	for _, spec := range specs {
+		rec := serve(f, httptest.NewRequest(spec.method, spec.path, nil))
+		assert.Equal(t, spec.status, rec.Code, "%s %s", spec.method, spec.path)
+	}
+}
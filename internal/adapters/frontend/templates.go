@@ -0,0 +1,64 @@
+package frontend
+
+import "html/template"
+
+// layout is shared by every page; each page defines its own content block.
+const layout = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>Links 'R' Us</title>
+</head>
+<body>
+	<header>
+		<a href="/">Links 'R' Us</a> | <a href="/submit">Submit a link</a>
+	</header>
+	<main>{{template "content" .}}</main>
+</body>
+</html>`
+
+var (
+	indexTemplate = page("index", `{{define "content"}}
+<form action="/search" method="get">
+	<input type="search" name="q" autofocus required>
+	<button type="submit">Search</button>
+</form>
+{{end}}`)
+
+	searchTemplate = page("search", `{{define "content"}}
+<form action="/search" method="get">
+	<input type="search" name="q" value="{{.Query}}" required>
+	<button type="submit">Search</button>
+</form>
+{{if .Results}}
+<p>Results {{.From}}-{{.To}} of {{.TotalCount}}</p>
+<ol start="{{.From}}">
+	{{range .Results}}
+	<li>
+		<a href="{{.URL}}">{{if .Title}}{{.Title}}{{else}}{{.URL}}{{end}}</a>
+		<br><small>{{.URL}}</small>
+	</li>
+	{{end}}
+</ol>
+<nav>
+	{{if ge .PrevOffset 0}}<a href="/search?q={{.Query}}&amp;offset={{.PrevOffset}}">Previous</a>{{end}}
+	{{if ge .NextOffset 0}}<a href="/search?q={{.Query}}&amp;offset={{.NextOffset}}">Next</a>{{end}}
+</nav>
+{{else}}
+<p>No results found for <strong>{{.Query}}</strong>.</p>
+{{end}}
+{{end}}`)
+
+	submitTemplate = page("submit", `{{define "content"}}
+{{if .Message}}<p{{if .Failed}} role="alert"{{end}}>{{.Message}}</p>{{end}}
+<form action="/submit" method="post">
+	<input type="url" name="url" value="{{.URL}}" placeholder="https://" required>
+	<button type="submit">Submit</button>
+</form>
+{{end}}`)
+)
+
+// page parses content into a copy of the layout.
+func page(name, content string) *template.Template {
+	return template.Must(template.Must(template.New(name).Parse(layout)).Parse(content))
+}
@@ -1,12 +1,13 @@
 package graphtest
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/partition"
 	"github.com/bruceneco/links-r-us/internal/ports/repository"
 	"github.com/stretchr/testify/assert"
-	"math/big"
 	"reflect"
 	"sort"
 	"sync"
@@ -35,7 +36,7 @@ func (s *SuiteBase) TestUpsertLink(t *testing.T) {
 		RetrievedAt: time.Now().Add(-10 * time.Hour),
 	}
 
-	err := s.g.UpsertLink(original)
+	err := s.g.UpsertLink(context.TODO(), original)
 	assert.Nil(t, err)
 	assert.NotEqual(t, uuid.Nil, original.ID, "expected a linkID to be assigned to the new link")
 
@@ -46,11 +47,11 @@ func (s *SuiteBase) TestUpsertLink(t *testing.T) {
 		URL:         "https://example.com",
 		RetrievedAt: accessedAt,
 	}
-	err = s.g.UpsertLink(existing)
+	err = s.g.UpsertLink(context.TODO(), existing)
 	assert.Nil(t, err)
 	assert.Equal(t, original.ID, existing.ID, "link ID changed while upserting")
 
-	stored, err := s.g.FindLink(existing.ID)
+	stored, err := s.g.FindLink(context.TODO(), existing.ID)
 	assert.Nil(t, err)
 	assert.Equal(t, accessedAt, stored.RetrievedAt, "last accessed timestamp was not updated")
 
@@ -60,11 +61,11 @@ func (s *SuiteBase) TestUpsertLink(t *testing.T) {
 		URL:         existing.URL,
 		RetrievedAt: time.Now().Add(-10 * time.Hour).UTC(),
 	}
-	err = s.g.UpsertLink(sameURL)
+	err = s.g.UpsertLink(context.TODO(), sameURL)
 	assert.Nil(t, err)
 	assert.Equal(t, existing.ID, sameURL.ID, "link ID changed while upserting")
 
-	stored, err = s.g.FindLink(existing.ID)
+	stored, err = s.g.FindLink(context.TODO(), existing.ID)
 	assert.Nil(t, err)
 	assert.Equal(t, accessedAt, stored.RetrievedAt, "last accessed timestamp was overwritten with an older value")
 
@@ -73,11 +74,234 @@ func (s *SuiteBase) TestUpsertLink(t *testing.T) {
 	dup := &domain.Link{
 		URL: "foo",
 	}
-	err = s.g.UpsertLink(dup)
+	err = s.g.UpsertLink(context.TODO(), dup)
 	assert.Nil(t, err)
 	assert.NotEqual(t, uuid.Nil, dup.ID, "expected a linkID to be assigned to the new link")
 }
 
+// TestUpsertLinks verifies the batch link upsert logic.
+func (s *SuiteBase) TestUpsertLinks(t *testing.T) {
+	accessedAt := time.Now().Truncate(time.Second).UTC()
+	existing := &domain.Link{URL: "https://example.com/existing", RetrievedAt: accessedAt}
+	assert.Nil(t, s.g.UpsertLink(context.TODO(), existing))
+
+	batch := []*domain.Link{
+		{URL: "https://example.com/0", RetrievedAt: accessedAt.Add(-time.Hour)},
+		{URL: "https://example.com/1", RetrievedAt: accessedAt.Add(-time.Hour)},
+		// Older timestamp for an existing link must not overwrite it.
+		{URL: existing.URL, RetrievedAt: accessedAt.Add(-10 * time.Hour)},
+		// Duplicate URL within the same batch with a newer timestamp.
+		{URL: "https://example.com/0", RetrievedAt: accessedAt},
+	}
+	assert.Nil(t, s.g.UpsertLinks(context.TODO(), batch))
+
+	for i, link := range batch {
+		assert.NotEqual(t, uuid.Nil, link.ID, "expected a linkID to be assigned to link %d", i)
+	}
+	assert.Equal(t, existing.ID, batch[2].ID, "link ID changed while upserting")
+	assert.Equal(t, batch[0].ID, batch[3].ID, "links with the same URL got different IDs")
+	assert.NotEqual(t, batch[0].ID, batch[1].ID, "links with different URLs got the same ID")
+
+	stored, err := s.g.FindLink(context.TODO(), existing.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, accessedAt, stored.RetrievedAt, "last accessed timestamp was overwritten with an older value")
+
+	stored, err = s.g.FindLink(context.TODO(), batch[0].ID)
+	assert.Nil(t, err)
+	assert.Equal(t, accessedAt, stored.RetrievedAt, "last accessed timestamp was not updated")
+
+	// Upserting an empty batch is a no-op.
+	assert.Nil(t, s.g.UpsertLinks(context.TODO(), nil))
+}
+
+// TestLinkFetchMetadata verifies that the fetch metadata of a link is
+// persisted and only replaced by a more recent retrieval.
+func (s *SuiteBase) TestLinkFetchMetadata(t *testing.T) {
+	fetchedAt := time.Now().Truncate(time.Second).UTC()
+	link := &domain.Link{
+		URL:           "https://example.com",
+		RetrievedAt:   fetchedAt,
+		StatusCode:    200,
+		ContentType:   "text/html; charset=utf-8",
+		ContentLength: 1024,
+		ETag:          `"abc"`,
+		LastModified:  "Wed, 21 Oct 2015 07:28:00 GMT",
+	}
+	assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
+
+	stored, err := s.g.FindLink(context.TODO(), link.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, link, stored, "fetch metadata was not persisted")
+
+	// Discovering the link again must not discard its fetch metadata.
+	rediscovered := &domain.Link{URL: link.URL}
+	assert.Nil(t, s.g.UpsertLink(context.TODO(), rediscovered))
+	assert.Equal(t, link, rediscovered, "upsert did not return the stored link")
+	stored, err = s.g.FindLinkByURL(context.TODO(), link.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, link, stored, "fetch metadata was overwritten by an older retrieval")
+
+	// A failed fetch attempt replaces the metadata of the previous one.
+	failed := &domain.Link{
+		URL:          link.URL,
+		RetrievedAt:  fetchedAt.Add(time.Hour),
+		FailureCount: 1,
+		LastError:    "connection refused",
+	}
+	assert.Nil(t, s.g.UpsertLinks(context.TODO(), []*domain.Link{failed}))
+	assert.Equal(t, link.ID, failed.ID, "link ID changed while upserting")
+
+	got, err := s.g.FindLinks(context.TODO(), []uuid.UUID{link.ID})
+	assert.Nil(t, err)
+	assert.Equal(t, []*domain.Link{failed}, got, "fetch metadata was not updated")
+
+	it, err := s.g.Links(context.TODO(), repository.FullIDRange(), fetchedAt.Add(2*time.Hour))
+	assert.Nil(t, err)
+	assert.True(t, it.Next(), "expected the link to be returned by the iterator")
+	assert.Equal(t, failed, it.Link(), "iterator returned the wrong fetch metadata")
+	assert.False(t, it.Next())
+	assert.Nil(t, it.Error())
+	assert.Nil(t, it.Close())
+}
+
+// TestTouchLink verifies that touching a link records an unchanged fetch and
+// keeps its outgoing edges from going stale.
+func (s *SuiteBase) TestTouchLink(t *testing.T) {
+	fetchedAt := time.Now().Truncate(time.Second).UTC()
+	link := &domain.Link{
+		URL:          "https://example.com",
+		RetrievedAt:  fetchedAt,
+		StatusCode:   200,
+		ETag:         `"abc"`,
+		FailureCount: 1,
+		LastError:    "connection reset by peer",
+	}
+	about := &domain.Link{URL: "https://example.com/about"}
+	contact := &domain.Link{URL: "https://example.com/contact"}
+	assert.Nil(t, s.g.UpsertLinks(context.TODO(), []*domain.Link{link, about, contact}))
+	assert.Nil(t, s.g.UpsertEdges(context.TODO(), []*domain.Edge{
+		{Src: link.ID, Dst: about.ID},
+		{Src: link.ID, Dst: contact.ID},
+		{Src: about.ID, Dst: link.ID},
+	}))
+
+	staleBefore := time.Now().Add(100 * time.Millisecond)
+	time.Sleep(250 * time.Millisecond)
+
+	touchedAt := fetchedAt.Add(time.Hour)
+	assert.Nil(t, s.g.TouchLink(context.TODO(), link.ID, touchedAt))
+	stored, err := s.g.FindLink(context.TODO(), link.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, link.NotModified(touchedAt), stored, "touch did not record the fetch")
+
+	// An older fetch leaves the link alone.
+	assert.Nil(t, s.g.TouchLink(context.TODO(), link.ID, fetchedAt))
+	stored, err = s.g.FindLink(context.TODO(), link.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, touchedAt, stored.RetrievedAt, "touch replaced RetrievedAt with an older value")
+
+	// Only the outgoing edges of the touched link are refreshed.
+	assert.Nil(t, s.g.RemoveStaleEdges(context.TODO(), link.ID, staleBefore))
+	assert.Nil(t, s.g.RemoveStaleEdges(context.TODO(), about.ID, staleBefore))
+	in, out, err := s.g.Degree(context.TODO(), link.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, [2]int{0, 2}, [2]int{in, out})
+
+	err = s.g.TouchLink(context.TODO(), uuid.New(), touchedAt)
+	assert.True(t, errors.Is(err, repository.GraphErrNotFound), "expected GraphErrNotFound, got %v", err)
+}
+
+// TestMergeLink verifies that duplicate links are associated with their
+// canonical link and that their edges are moved over to it.
+func (s *SuiteBase) TestMergeLink(t *testing.T) {
+	links := make(map[string]*domain.Link)
+	for _, name := range []string{"dup", "canonical", "x", "y", "z", "older-dup"} {
+		link := &domain.Link{URL: "https://example.com/" + name}
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
+		links[name] = link
+	}
+	id := func(name string) uuid.UUID { return links[name].ID }
+
+	for _, e := range [][2]string{
+		{"dup", "x"}, {"dup", "canonical"}, {"x", "dup"}, {"canonical", "y"}, {"dup", "y"},
+		{"y", "dup"}, {"y", "canonical"}, {"dup", "dup"}, {"older-dup", "z"},
+	} {
+		assert.Nil(t, s.g.UpsertEdge(context.TODO(), &domain.Edge{Src: id(e[0]), Dst: id(e[1])}))
+	}
+
+	// Merge a first duplicate and then the link it duplicates.
+	assert.Nil(t, s.g.MergeLink(context.TODO(), id("older-dup"), id("dup")))
+	assert.Nil(t, s.g.MergeLink(context.TODO(), id("dup"), id("canonical")))
+	s.assertCanonical(t, id("dup"), id("canonical"))
+	s.assertCanonical(t, id("older-dup"), id("canonical"))
+	s.assertCanonical(t, id("canonical"), uuid.Nil)
+
+	assert.ElementsMatch(t, []uuid.UUID{id("x"), id("y"), id("z")}, s.edgeEnds(t, id("canonical"), false), "unexpected outgoing edges")
+	assert.ElementsMatch(t, []uuid.UUID{id("x"), id("y")}, s.edgeEnds(t, id("canonical"), true), "unexpected incoming edges")
+	for _, name := range []string{"dup", "older-dup"} {
+		in, out, err := s.g.Degree(context.TODO(), id(name))
+		assert.Nil(t, err)
+		assert.Equal(t, [2]int{0, 0}, [2]int{in, out}, "edges of %s were not moved", name)
+	}
+
+	// Upserting a duplicate must not forget its canonical link.
+	dup := &domain.Link{URL: links["dup"].URL, RetrievedAt: time.Now()}
+	assert.Nil(t, s.g.UpsertLink(context.TODO(), dup))
+	assert.Equal(t, id("canonical"), dup.CanonicalLinkID())
+
+	// Reversing the merge makes the former duplicate canonical.
+	assert.Nil(t, s.g.MergeLink(context.TODO(), id("canonical"), id("dup")))
+	s.assertCanonical(t, id("dup"), uuid.Nil)
+	s.assertCanonical(t, id("canonical"), id("dup"))
+	s.assertCanonical(t, id("older-dup"), id("dup"))
+
+	// Merging a link with itself makes it canonical again.
+	assert.Nil(t, s.g.MergeLink(context.TODO(), id("canonical"), id("canonical")))
+	s.assertCanonical(t, id("canonical"), uuid.Nil)
+
+	// Removing a canonical link makes its duplicates canonical.
+	assert.Nil(t, s.g.RemoveLink(context.TODO(), id("dup")))
+	s.assertCanonical(t, id("older-dup"), uuid.Nil)
+
+	err := s.g.MergeLink(context.TODO(), uuid.New(), id("canonical"))
+	assert.True(t, errors.Is(err, repository.GraphErrNotFound))
+	err = s.g.MergeLink(context.TODO(), id("canonical"), uuid.New())
+	assert.True(t, errors.Is(err, repository.GraphErrNotFound))
+}
+
+func (s *SuiteBase) assertCanonical(t *testing.T, id, canonicalID uuid.UUID) {
+	link, err := s.g.FindLink(context.TODO(), id)
+	assert.Nil(t, err)
+	assert.Equal(t, canonicalID, link.CanonicalID, "unexpected canonical link for %s", link.URL)
+}
+
+// edgeEnds returns the other end of every edge pointing to linkID if in is
+// set or originating from it otherwise.
+func (s *SuiteBase) edgeEnds(t *testing.T, linkID uuid.UUID, in bool) []uuid.UUID {
+	var (
+		it  repository.EdgeIterator
+		err error
+	)
+	if in {
+		it, err = s.g.InEdges(context.TODO(), linkID)
+	} else {
+		it, err = s.g.OutEdges(context.TODO(), linkID)
+	}
+	assert.Nil(t, err)
+
+	var ends []uuid.UUID
+	for it.Next() {
+		if in {
+			ends = append(ends, it.Edge().Src)
+		} else {
+			ends = append(ends, it.Edge().Dst)
+		}
+	}
+	assert.Nil(t, it.Error())
+	assert.Nil(t, it.Close())
+	return ends
+}
+
 // TestFindLink verifies the link lookup logic.
 func (s *SuiteBase) TestFindLink(t *testing.T) {
 	// Create a new link
@@ -86,21 +310,108 @@ func (s *SuiteBase) TestFindLink(t *testing.T) {
 		RetrievedAt: time.Now().Truncate(time.Second).UTC(),
 	}
 
-	err := s.g.UpsertLink(link)
+	err := s.g.UpsertLink(context.TODO(), link)
 	assert.Nil(t, err)
 	assert.NotEqual(t, uuid.Nil, link.ID, "expected a linkID to be assigned to the new link")
 
 	// Lookup link by ID
-	other, err := s.g.FindLink(link.ID)
+	other, err := s.g.FindLink(context.TODO(), link.ID)
 	assert.Nil(t, err)
 
 	assert.True(t, reflect.DeepEqual(link, other), "lookup by ID returned the wrong link")
 
 	// Lookup link by unknown ID
-	_, err = s.g.FindLink(uuid.Nil)
+	_, err = s.g.FindLink(context.TODO(), uuid.Nil)
 	assert.True(t, errors.Is(err, repository.GraphErrNotFound))
 }
 
+// TestFindLinkByURL verifies the link lookup by URL logic.
+func (s *SuiteBase) TestFindLinkByURL(t *testing.T) {
+	link := &domain.Link{
+		URL:         "https://example.com",
+		RetrievedAt: time.Now().Truncate(time.Second).UTC(),
+	}
+	assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
+
+	// Lookup link by URL
+	other, err := s.g.FindLinkByURL(context.TODO(), link.URL)
+	assert.Nil(t, err)
+	assert.True(t, reflect.DeepEqual(link, other), "lookup by URL returned the wrong link")
+
+	// Lookup link by unknown URL
+	_, err = s.g.FindLinkByURL(context.TODO(), "https://example.com/unknown")
+	assert.True(t, errors.Is(err, repository.GraphErrNotFound))
+}
+
+// TestFindLinks verifies the batch link lookup logic.
+func (s *SuiteBase) TestFindLinks(t *testing.T) {
+	var links []*domain.Link
+	for i := 0; i < 5; i++ {
+		link := &domain.Link{
+			URL:         fmt.Sprintf("https://example.com/%d", i),
+			RetrievedAt: time.Now().Truncate(time.Second).UTC(),
+		}
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
+		links = append(links, link)
+	}
+
+	// Request links in reverse order mixed with an unknown ID.
+	ids := []uuid.UUID{links[4].ID, uuid.New(), links[2].ID, links[0].ID}
+	got, err := s.g.FindLinks(context.TODO(), ids)
+	assert.Nil(t, err)
+	assert.True(t, reflect.DeepEqual([]*domain.Link{links[4], links[2], links[0]}, got), "batch lookup returned the wrong links")
+
+	got, err = s.g.FindLinks(context.TODO(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, got, 0)
+}
+
+// TestRemoveLink verifies that removing a link also removes its incoming
+// and outgoing edges.
+func (s *SuiteBase) TestRemoveLink(t *testing.T) {
+	linkUUIDs := make([]uuid.UUID, 3)
+	for i := 0; i < len(linkUUIDs); i++ {
+		link := &domain.Link{URL: fmt.Sprint(i)}
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
+		linkUUIDs[i] = link.ID
+	}
+
+	keep := make(map[uuid.UUID]struct{})
+	for _, pair := range [][2]int{{0, 1}, {1, 2}, {2, 0}, {0, 2}, {1, 1}} {
+		edge := &domain.Edge{Src: linkUUIDs[pair[0]], Dst: linkUUIDs[pair[1]]}
+		assert.Nil(t, s.g.UpsertEdge(context.TODO(), edge))
+		if pair[0] != 1 && pair[1] != 1 {
+			keep[edge.ID] = struct{}{}
+		}
+	}
+
+	assert.Nil(t, s.g.RemoveLink(context.TODO(), linkUUIDs[1]))
+
+	_, err := s.g.FindLink(context.TODO(), linkUUIDs[1])
+	assert.True(t, errors.Is(err, repository.GraphErrNotFound))
+	_, err = s.g.FindLinkByURL(context.TODO(), "1")
+	assert.True(t, errors.Is(err, repository.GraphErrNotFound))
+
+	it, err := s.partitionedEdgeIterator(t, 0, 1, time.Now())
+	assert.Nil(t, err)
+	seen := make(map[uuid.UUID]struct{})
+	for it.Next() {
+		seen[it.Edge().ID] = struct{}{}
+	}
+	assert.Nil(t, it.Error())
+	assert.Nil(t, it.Close())
+	assert.Equal(t, keep, seen, "expected only edges unrelated to the removed link to remain")
+
+	// Removing an unknown link fails.
+	err = s.g.RemoveLink(context.TODO(), linkUUIDs[1])
+	assert.True(t, errors.Is(err, repository.GraphErrNotFound))
+
+	// The URL of a removed link can be inserted again as a new link.
+	link := &domain.Link{URL: "1"}
+	assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
+	assert.NotEqual(t, linkUUIDs[1], link.ID, "expected a new linkID to be assigned")
+}
+
 // TestConcurrentLinkIterators verifies that multiple clients can concurrently
 // access the store.
 func (s *SuiteBase) TestConcurrentLinkIterators(t *testing.T) {
@@ -112,7 +423,7 @@ func (s *SuiteBase) TestConcurrentLinkIterators(t *testing.T) {
 
 	for i := 0; i < numLinks; i++ {
 		link := &domain.Link{URL: fmt.Sprint(i)}
-		assert.Nil(t, s.g.UpsertLink(link))
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
 	}
 
 	wg.Add(numIterators)
@@ -161,7 +472,7 @@ func (s *SuiteBase) TestLinkIteratorTimeFilter(t *testing.T) {
 	linkInsertTimes := make([]time.Time, len(linkUUIDs))
 	for i := 0; i < len(linkUUIDs); i++ {
 		link := &domain.Link{URL: fmt.Sprint(i), RetrievedAt: time.Now()}
-		assert.Nil(t, s.g.UpsertLink(link))
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
 		linkUUIDs[i] = link.ID
 		linkInsertTimes[i] = time.Now()
 	}
@@ -194,7 +505,7 @@ func (s *SuiteBase) TestPartitionedLinkIterators(t *testing.T) {
 	numLinks := 100
 	numPartitions := 10
 	for i := 0; i < numLinks; i++ {
-		assert.Nil(t, s.g.UpsertLink(&domain.Link{URL: fmt.Sprint(i)}))
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), &domain.Link{URL: fmt.Sprint(i)}))
 	}
 
 	// Check with both odd and even partition counts to check for rounding-related bugs.
@@ -224,13 +535,60 @@ func (s *SuiteBase) iteratePartitionedLinks(t *testing.T, numPartitions int) int
 	return len(seen)
 }
 
+// TestLinkRangeBoundaries verifies that link ranges are half-open, so that
+// a link whose ID lies on the boundary of two adjacent ranges is returned by
+// exactly one of them, and that MaxID makes a range unbounded.
+func (s *SuiteBase) TestLinkRangeBoundaries(t *testing.T) {
+	ids := make([]uuid.UUID, 20)
+	for i := range ids {
+		link := &domain.Link{URL: fmt.Sprint(i)}
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
+		ids[i] = link.ID
+	}
+	sortIDs(ids)
+
+	collect := func(r repository.IDRange) []uuid.UUID {
+		it, err := s.g.Links(context.TODO(), r, time.Now())
+		assert.Nil(t, err)
+		var got []uuid.UUID
+		for it.Next() {
+			got = append(got, it.Link().ID)
+		}
+		assert.Nil(t, it.Error())
+		assert.Nil(t, it.Close())
+		sortIDs(got)
+		return got
+	}
+	assertRangeBoundaries(t, ids, collect)
+}
+
+// TestLinkIteratorContextCancellation verifies that link iteration stops
+// with the context error once its context is cancelled.
+func (s *SuiteBase) TestLinkIteratorContextCancellation(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), &domain.Link{URL: fmt.Sprint(i)}))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	it, err := s.g.Links(ctx, s.partitionRange(t, 0, 1), time.Now())
+	if err != nil {
+		assert.True(t, errors.Is(err, context.Canceled))
+		return
+	}
+	assert.False(t, it.Next(), "expected iterator to stop after context cancellation")
+	assert.True(t, errors.Is(it.Error(), context.Canceled))
+	assert.Nil(t, it.Close())
+}
+
 // TestUpsertEdge verifies the edge upsert logic.
 func (s *SuiteBase) TestUpsertEdge(t *testing.T) {
 	// Create links
 	linkUUIDs := make([]uuid.UUID, 3)
 	for i := 0; i < 3; i++ {
 		link := &domain.Link{URL: fmt.Sprint(i)}
-		assert.Nil(t, s.g.UpsertLink(link))
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
 		linkUUIDs[i] = link.ID
 	}
 
@@ -240,7 +598,7 @@ func (s *SuiteBase) TestUpsertEdge(t *testing.T) {
 		Dst: linkUUIDs[1],
 	}
 
-	err := s.g.UpsertEdge(edge)
+	err := s.g.UpsertEdge(context.TODO(), edge)
 	assert.Nil(t, err)
 	assert.NotEqual(t, uuid.Nil, edge.ID, "expected an edgeID to be assigned to the new edge")
 	assert.False(t, edge.UpdatedAt.IsZero(), "UpdatedAt field not set")
@@ -251,7 +609,7 @@ func (s *SuiteBase) TestUpsertEdge(t *testing.T) {
 		Src: linkUUIDs[0],
 		Dst: linkUUIDs[1],
 	}
-	err = s.g.UpsertEdge(other)
+	err = s.g.UpsertEdge(context.TODO(), other)
 	assert.Nil(t, err)
 	assert.Equal(t, edge.ID, other.ID, "edge ID changed while upserting")
 	assert.NotEqual(t, edge.UpdatedAt, other.UpdatedAt, "UpdatedAt field not modified")
@@ -261,10 +619,310 @@ func (s *SuiteBase) TestUpsertEdge(t *testing.T) {
 		Src: linkUUIDs[0],
 		Dst: uuid.New(),
 	}
-	err = s.g.UpsertEdge(bogus)
+	err = s.g.UpsertEdge(context.TODO(), bogus)
 	assert.True(t, errors.Is(err, repository.GraphErrUnknownEdgeLinks))
 }
 
+// TestUpsertEdges verifies the batch edge upsert logic.
+func (s *SuiteBase) TestUpsertEdges(t *testing.T) {
+	linkUUIDs := make([]uuid.UUID, 4)
+	for i := 0; i < len(linkUUIDs); i++ {
+		link := &domain.Link{URL: fmt.Sprint(i)}
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
+		linkUUIDs[i] = link.ID
+	}
+
+	existing := &domain.Edge{Src: linkUUIDs[0], Dst: linkUUIDs[1]}
+	assert.Nil(t, s.g.UpsertEdge(context.TODO(), existing))
+
+	batch := []*domain.Edge{
+		{Src: linkUUIDs[0], Dst: linkUUIDs[1]},
+		{Src: linkUUIDs[0], Dst: linkUUIDs[2]},
+		{Src: linkUUIDs[1], Dst: linkUUIDs[3]},
+		{Src: linkUUIDs[0], Dst: linkUUIDs[2]},
+	}
+	assert.Nil(t, s.g.UpsertEdges(context.TODO(), batch))
+
+	for i, edge := range batch {
+		assert.NotEqual(t, uuid.Nil, edge.ID, "expected an edgeID to be assigned to edge %d", i)
+		assert.False(t, edge.UpdatedAt.IsZero(), "UpdatedAt field not set for edge %d", i)
+	}
+	assert.Equal(t, existing.ID, batch[0].ID, "edge ID changed while upserting")
+	assert.NotEqual(t, existing.UpdatedAt, batch[0].UpdatedAt, "UpdatedAt field not modified")
+	assert.Equal(t, batch[1].ID, batch[3].ID, "edges with the same links got different IDs")
+
+	// A batch referencing an unknown link must be rejected as a whole.
+	bogus := []*domain.Edge{
+		{Src: linkUUIDs[2], Dst: linkUUIDs[3]},
+		{Src: linkUUIDs[0], Dst: uuid.New()},
+	}
+	err := s.g.UpsertEdges(context.TODO(), bogus)
+	assert.True(t, errors.Is(err, repository.GraphErrUnknownEdgeLinks))
+
+	it, err := s.partitionedEdgeIterator(t, 0, 1, time.Now())
+	assert.Nil(t, err)
+	var seen int
+	for it.Next() {
+		assert.False(t, it.Edge().Src == linkUUIDs[2], "edge from a rejected batch was stored")
+		seen++
+	}
+	assert.Nil(t, it.Error())
+	assert.Nil(t, it.Close())
+	assert.Equal(t, 3, seen)
+}
+
+// TestEdgeAnchor verifies that the anchor text and rel flags of an edge are
+// persisted and refreshed when the edge is discovered again.
+func (s *SuiteBase) TestEdgeAnchor(t *testing.T) {
+	linkUUIDs := make([]uuid.UUID, 2)
+	for i := 0; i < len(linkUUIDs); i++ {
+		link := &domain.Link{URL: fmt.Sprint(i)}
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
+		linkUUIDs[i] = link.ID
+	}
+
+	edge := &domain.Edge{
+		Src:        linkUUIDs[0],
+		Dst:        linkUUIDs[1],
+		AnchorText: "an example page",
+		Rel:        domain.ParseEdgeRel("nofollow ugc"),
+	}
+	assert.Nil(t, s.g.UpsertEdge(context.TODO(), edge))
+	assert.Equal(t, "an example page", edge.AnchorText)
+	assert.True(t, edge.Rel.Has(domain.EdgeRelNoFollow|domain.EdgeRelUGC))
+	assert.False(t, edge.Rel.Has(domain.EdgeRelSponsored))
+	s.assertEdgeAnchor(t, edge)
+
+	// Discovering the edge again replaces its anchor with the latest one.
+	batch := []*domain.Edge{
+		{Src: linkUUIDs[0], Dst: linkUUIDs[1], AnchorText: "stale", Rel: domain.EdgeRelNoFollow},
+		{Src: linkUUIDs[0], Dst: linkUUIDs[1], AnchorText: "sponsored example", Rel: domain.EdgeRelSponsored},
+	}
+	assert.Nil(t, s.g.UpsertEdges(context.TODO(), batch))
+	assert.Equal(t, edge.ID, batch[1].ID, "edge ID changed while upserting")
+	s.assertEdgeAnchor(t, batch[1])
+}
+
+// TestRedirectEdge verifies that redirects are stored as edges from the
+// redirecting link to the final link of the redirect chain.
+func (s *SuiteBase) TestRedirectEdge(t *testing.T) {
+	src := &domain.Link{URL: "http://example.com", RetrievedAt: time.Now().Truncate(time.Second).UTC(), StatusCode: 301}
+	final := &domain.Link{URL: "https://www.example.com"}
+	assert.Nil(t, s.g.UpsertLinks(context.TODO(), []*domain.Link{src, final}))
+
+	redirect := &domain.Edge{Src: src.ID, Dst: final.ID, Kind: domain.EdgeKindRedirect}
+	assert.Nil(t, s.g.UpsertEdge(context.TODO(), redirect))
+	assert.Equal(t, domain.EdgeKindRedirect, redirect.Kind)
+
+	it, err := s.g.OutEdges(context.TODO(), src.ID)
+	assert.Nil(t, err)
+	assert.True(t, it.Next(), "expected the redirect edge")
+	assert.Equal(t, final.ID, it.Edge().Dst)
+	assert.Equal(t, domain.EdgeKindRedirect, it.Edge().Kind, "edge kind was not persisted")
+	assert.False(t, it.Next())
+	assert.Nil(t, it.Close())
+
+	// The kind is part of the edge identity: a page that also links to the
+	// target of its redirect gets a second edge and upserting either one
+	// only refreshes that edge.
+	link := &domain.Edge{Src: src.ID, Dst: final.ID, AnchorText: "home"}
+	assert.Nil(t, s.g.UpsertEdges(context.TODO(), []*domain.Edge{link}))
+	assert.NotEqual(t, redirect.ID, link.ID, "expected a separate link edge")
+	assert.Equal(t, domain.EdgeKindLink, link.Kind)
+
+	again := &domain.Edge{Src: src.ID, Dst: final.ID, Kind: domain.EdgeKindRedirect}
+	assert.Nil(t, s.g.UpsertEdge(context.TODO(), again))
+	assert.Equal(t, redirect.ID, again.ID, "edge ID changed while upserting")
+
+	// Merging a duplicate only drops its edges of a kind the canonical link
+	// already has.
+	dup := &domain.Link{URL: "http://www.example.com", StatusCode: 301}
+	assert.Nil(t, s.g.UpsertLink(context.TODO(), dup))
+	dupEdges := []*domain.Edge{
+		{Src: dup.ID, Dst: final.ID, Kind: domain.EdgeKindRedirect},
+		{Src: dup.ID, Dst: final.ID, AnchorText: "home"},
+	}
+	assert.Nil(t, s.g.UpsertEdges(context.TODO(), dupEdges))
+	assert.Nil(t, s.g.MergeLink(context.TODO(), dup.ID, src.ID))
+
+	byKind := make(map[domain.EdgeKind]*domain.Edge)
+	it, err = s.g.InEdges(context.TODO(), final.ID)
+	assert.Nil(t, err)
+	for it.Next() {
+		byKind[it.Edge().Kind] = it.Edge()
+	}
+	assert.Nil(t, it.Error())
+	assert.Nil(t, it.Close())
+	if assert.Len(t, byKind, 2) {
+		assert.Equal(t, redirect.ID, byKind[domain.EdgeKindRedirect].ID)
+		assert.Equal(t, link.ID, byKind[domain.EdgeKindLink].ID)
+		assert.Equal(t, "home", byKind[domain.EdgeKindLink].AnchorText)
+	}
+
+	in, out, err := s.g.Degree(context.TODO(), src.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, [2]int{0, 2}, [2]int{in, out})
+}
+
+// assertEdgeAnchor checks that the in, out and partition edge iterators
+// return the anchor of edge.
+func (s *SuiteBase) assertEdgeAnchor(t *testing.T, edge *domain.Edge) {
+	iterators := map[string]func() (repository.EdgeIterator, error){
+		"in edges":  func() (repository.EdgeIterator, error) { return s.g.InEdges(context.TODO(), edge.Dst) },
+		"out edges": func() (repository.EdgeIterator, error) { return s.g.OutEdges(context.TODO(), edge.Src) },
+		"edges": func() (repository.EdgeIterator, error) {
+			return s.g.Edges(context.TODO(), repository.FullIDRange(), time.Now().Add(time.Minute))
+		},
+	}
+	for name, open := range iterators {
+		it, err := open()
+		assert.Nil(t, err)
+		assert.True(t, it.Next(), "%s: expected an edge", name)
+		assert.Equal(t, edge.AnchorText, it.Edge().AnchorText, "%s: wrong anchor text", name)
+		assert.Equal(t, edge.Rel, it.Edge().Rel, "%s: wrong rel flags", name)
+		assert.False(t, it.Next(), "%s: expected a single edge", name)
+		assert.Nil(t, it.Error())
+		assert.Nil(t, it.Close())
+	}
+}
+
+// TestRemoveEdge verifies the edge deletion logic.
+func (s *SuiteBase) TestRemoveEdge(t *testing.T) {
+	linkUUIDs := make([]uuid.UUID, 3)
+	for i := 0; i < len(linkUUIDs); i++ {
+		link := &domain.Link{URL: fmt.Sprint(i)}
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
+		linkUUIDs[i] = link.ID
+	}
+
+	gone := &domain.Edge{Src: linkUUIDs[0], Dst: linkUUIDs[1]}
+	assert.Nil(t, s.g.UpsertEdge(context.TODO(), gone))
+	kept := &domain.Edge{Src: linkUUIDs[0], Dst: linkUUIDs[2]}
+	assert.Nil(t, s.g.UpsertEdge(context.TODO(), kept))
+
+	assert.Nil(t, s.g.RemoveEdge(context.TODO(), gone.ID))
+	err := s.g.RemoveEdge(context.TODO(), gone.ID)
+	assert.True(t, errors.Is(err, repository.GraphErrNotFound))
+
+	s.assertIteratedEdgeIDsMatch(t, time.Now(), []uuid.UUID{kept.ID})
+
+	// Re-creating a removed edge assigns it a new ID.
+	recreated := &domain.Edge{Src: linkUUIDs[0], Dst: linkUUIDs[1]}
+	assert.Nil(t, s.g.UpsertEdge(context.TODO(), recreated))
+	assert.NotEqual(t, gone.ID, recreated.ID, "expected a new edgeID to be assigned")
+}
+
+// TestInOutEdges verifies the incoming and outgoing edge lookups of a link
+// and its degree counts.
+func (s *SuiteBase) TestInOutEdges(t *testing.T) {
+	linkUUIDs := make([]uuid.UUID, 4)
+	for i := 0; i < len(linkUUIDs); i++ {
+		link := &domain.Link{URL: fmt.Sprint(i)}
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
+		linkUUIDs[i] = link.ID
+	}
+
+	edgeUUIDs := make(map[[2]int]uuid.UUID)
+	for _, pair := range [][2]int{{0, 1}, {0, 2}, {2, 1}, {3, 1}, {1, 3}} {
+		edge := &domain.Edge{Src: linkUUIDs[pair[0]], Dst: linkUUIDs[pair[1]]}
+		assert.Nil(t, s.g.UpsertEdge(context.TODO(), edge))
+		edgeUUIDs[pair] = edge.ID
+	}
+
+	it, err := s.g.InEdges(context.TODO(), linkUUIDs[1])
+	assert.Nil(t, err)
+	assertEdgeIDsMatch(t, it, []uuid.UUID{edgeUUIDs[[2]int{0, 1}], edgeUUIDs[[2]int{2, 1}], edgeUUIDs[[2]int{3, 1}]})
+
+	it, err = s.g.OutEdges(context.TODO(), linkUUIDs[1])
+	assert.Nil(t, err)
+	assertEdgeIDsMatch(t, it, []uuid.UUID{edgeUUIDs[[2]int{1, 3}]})
+
+	it, err = s.g.OutEdges(context.TODO(), linkUUIDs[0])
+	assert.Nil(t, err)
+	assertEdgeIDsMatch(t, it, []uuid.UUID{edgeUUIDs[[2]int{0, 1}], edgeUUIDs[[2]int{0, 2}]})
+
+	it, err = s.g.InEdges(context.TODO(), uuid.New())
+	assert.Nil(t, err)
+	assertEdgeIDsMatch(t, it, nil)
+
+	inDegree, outDegree, err := s.g.Degree(context.TODO(), linkUUIDs[1])
+	assert.Nil(t, err)
+	assert.Equal(t, 3, inDegree)
+	assert.Equal(t, 1, outDegree)
+
+	// Removing edges is reflected in the incoming edges and degree counts.
+	assert.Nil(t, s.g.RemoveEdge(context.TODO(), edgeUUIDs[[2]int{2, 1}]))
+	assert.Nil(t, s.g.RemoveLink(context.TODO(), linkUUIDs[3]))
+
+	it, err = s.g.InEdges(context.TODO(), linkUUIDs[1])
+	assert.Nil(t, err)
+	assertEdgeIDsMatch(t, it, []uuid.UUID{edgeUUIDs[[2]int{0, 1}]})
+
+	inDegree, outDegree, err = s.g.Degree(context.TODO(), linkUUIDs[1])
+	assert.Nil(t, err)
+	assert.Equal(t, 1, inDegree)
+	assert.Equal(t, 0, outDegree)
+
+	_, _, err = s.g.Degree(context.TODO(), linkUUIDs[3])
+	assert.True(t, errors.Is(err, repository.GraphErrNotFound))
+}
+
+func assertEdgeIDsMatch(t *testing.T, it repository.EdgeIterator, exp []uuid.UUID) {
+	var got []uuid.UUID
+	for it.Next() {
+		got = append(got, it.Edge().ID)
+	}
+	assert.Nil(t, it.Error())
+	assert.Nil(t, it.Close())
+
+	sort.Slice(got, func(l, r int) bool { return got[l].String() < got[r].String() })
+	sort.Slice(exp, func(l, r int) bool { return exp[l].String() < exp[r].String() })
+	assert.Equal(t, exp, got)
+}
+
+// TestStats verifies the graph statistics.
+func (s *SuiteBase) TestStats(t *testing.T) {
+	now := time.Now()
+	retrievedAt := []time.Time{{}, {}, now.Add(-time.Hour), now.Add(-48 * time.Hour)}
+	linkUUIDs := make([]uuid.UUID, len(retrievedAt))
+	for i := 0; i < len(linkUUIDs); i++ {
+		link := &domain.Link{URL: fmt.Sprint(i), RetrievedAt: retrievedAt[i]}
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
+		linkUUIDs[i] = link.ID
+	}
+
+	numPartitions := 4
+	r, err := partition.NewFullRange(numPartitions)
+	assert.Nil(t, err)
+	expEdgesPerPartition := make([]int, numPartitions)
+	for _, pair := range [][2]int{{0, 1}, {0, 2}, {1, 2}} {
+		edge := &domain.Edge{Src: linkUUIDs[pair[0]], Dst: linkUUIDs[pair[1]]}
+		assert.Nil(t, s.g.UpsertEdge(context.TODO(), edge))
+
+		p, err := r.PartitionForID(edge.Src)
+		assert.Nil(t, err)
+		expEdgesPerPartition[p]++
+	}
+
+	stats, err := s.g.Stats(context.TODO(), repository.GraphStatsOptions{
+		RetrievedWithin: 24 * time.Hour,
+		NumPartitions:   numPartitions,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, stats.Links)
+	assert.Equal(t, 3, stats.Edges)
+	assert.Equal(t, 2, stats.UnretrievedLinks)
+	assert.Equal(t, 1, stats.RecentlyRetrievedLinks)
+	assert.Equal(t, expEdgesPerPartition, stats.EdgesPerPartition)
+	assert.Equal(t, map[int]int{0: 2, 1: 1, 2: 1}, stats.InDegreeHistogram)
+	assert.Equal(t, map[int]int{0: 2, 1: 1, 2: 1}, stats.OutDegreeHistogram)
+
+	// Without options all edges are reported in a single partition.
+	stats, err = s.g.Stats(context.TODO(), repository.GraphStatsOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3}, stats.EdgesPerPartition)
+}
+
 // TestConcurrentEdgeIterators verifies that multiple clients can concurrently
 // access the store.
 func (s *SuiteBase) TestConcurrentEdgeIterators(t *testing.T) {
@@ -277,11 +935,11 @@ func (s *SuiteBase) TestConcurrentEdgeIterators(t *testing.T) {
 
 	for i := 0; i < numEdges*2; i++ {
 		link := &domain.Link{URL: fmt.Sprint(i)}
-		assert.Nil(t, s.g.UpsertLink(link))
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
 		linkUUIDs[i] = link.ID
 	}
 	for i := 0; i < numEdges; i++ {
-		assert.Nil(t, s.g.UpsertEdge(&domain.Edge{
+		assert.Nil(t, s.g.UpsertEdge(context.TODO(), &domain.Edge{
 			Src: linkUUIDs[0],
 			Dst: linkUUIDs[i],
 		}))
@@ -333,7 +991,7 @@ func (s *SuiteBase) TestEdgeIteratorTimeFilter(t *testing.T) {
 	linkInsertTimes := make([]time.Time, len(linkUUIDs))
 	for i := 0; i < len(linkUUIDs); i++ {
 		link := &domain.Link{URL: fmt.Sprint(i)}
-		assert.Nil(t, s.g.UpsertLink(link))
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
 		linkUUIDs[i] = link.ID
 		linkInsertTimes[i] = time.Now()
 	}
@@ -342,7 +1000,7 @@ func (s *SuiteBase) TestEdgeIteratorTimeFilter(t *testing.T) {
 	edgeInsertTimes := make([]time.Time, len(linkUUIDs))
 	for i := 0; i < len(linkUUIDs); i++ {
 		edge := &domain.Edge{Src: linkUUIDs[0], Dst: linkUUIDs[i]}
-		assert.Nil(t, s.g.UpsertEdge(edge))
+		assert.Nil(t, s.g.UpsertEdge(context.TODO(), edge))
 		edgeUUIDs[i] = edge.ID
 		edgeInsertTimes[i] = time.Now()
 	}
@@ -377,11 +1035,11 @@ func (s *SuiteBase) TestPartitionedEdgeIterators(t *testing.T) {
 	linkUUIDs := make([]uuid.UUID, numEdges*2)
 	for i := 0; i < numEdges*2; i++ {
 		link := &domain.Link{URL: fmt.Sprint(i)}
-		assert.Nil(t, s.g.UpsertLink(link))
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
 		linkUUIDs[i] = link.ID
 	}
 	for i := 0; i < numEdges; i++ {
-		assert.Nil(t, s.g.UpsertEdge(&domain.Edge{
+		assert.Nil(t, s.g.UpsertEdge(context.TODO(), &domain.Edge{
 			Src: linkUUIDs[0],
 			Dst: linkUUIDs[i],
 		}))
@@ -427,6 +1085,81 @@ func (s *SuiteBase) iteratePartitionedEdges(t *testing.T, numPartitions int) int
 	return len(seen)
 }
 
+// TestEdgeRangeBoundaries verifies that edge ranges are half-open, so that
+// an edge whose source ID lies on the boundary of two adjacent ranges is
+// returned by exactly one of them, and that MaxID makes a range unbounded.
+func (s *SuiteBase) TestEdgeRangeBoundaries(t *testing.T) {
+	ids := make([]uuid.UUID, 20)
+	for i := range ids {
+		link := &domain.Link{URL: fmt.Sprint(i)}
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
+		ids[i] = link.ID
+	}
+	for _, id := range ids {
+		assert.Nil(t, s.g.UpsertEdge(context.TODO(), &domain.Edge{Src: id, Dst: ids[0]}))
+	}
+	sortIDs(ids)
+
+	collect := func(r repository.IDRange) []uuid.UUID {
+		it, err := s.g.Edges(context.TODO(), r, time.Now())
+		assert.Nil(t, err)
+		var got []uuid.UUID
+		for it.Next() {
+			got = append(got, it.Edge().Src)
+		}
+		assert.Nil(t, it.Error())
+		assert.Nil(t, it.Close())
+		sortIDs(got)
+		return got
+	}
+	assertRangeBoundaries(t, ids, collect)
+}
+
+// assertRangeBoundaries checks the IDs returned by collect for ranges whose
+// boundaries fall on the sorted IDs in ids.
+func assertRangeBoundaries(t *testing.T, ids []uuid.UUID, collect func(repository.IDRange) []uuid.UUID) {
+	pivot := len(ids) / 2
+	assert.Equal(t, ids[:pivot], collect(repository.NewIDRange(uuid.Nil, ids[pivot])),
+		"expected the upper bound to be excluded")
+	assert.Equal(t, ids[pivot:], collect(repository.NewIDRange(ids[pivot], repository.MaxID)),
+		"expected the lower bound to be included")
+	assert.Equal(t, ids[1:len(ids)-1], collect(repository.NewIDRange(ids[1], ids[len(ids)-1])))
+	assert.Empty(t, collect(repository.NewIDRange(ids[pivot], ids[pivot])), "expected an empty range")
+	assert.Equal(t, ids, collect(repository.FullIDRange()))
+}
+
+func sortIDs(ids []uuid.UUID) {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+}
+
+// TestEdgeIteratorContextCancellation verifies that edge iteration stops
+// with the context error once its context is cancelled.
+func (s *SuiteBase) TestEdgeIteratorContextCancellation(t *testing.T) {
+	linkUUIDs := make([]uuid.UUID, 10)
+	for i := 0; i < len(linkUUIDs); i++ {
+		link := &domain.Link{URL: fmt.Sprint(i)}
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
+		linkUUIDs[i] = link.ID
+	}
+	for i := 0; i < len(linkUUIDs); i++ {
+		assert.Nil(t, s.g.UpsertEdge(context.TODO(), &domain.Edge{Src: linkUUIDs[0], Dst: linkUUIDs[i]}))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	it, err := s.g.Edges(ctx, s.partitionRange(t, 0, 1), time.Now())
+	if err != nil {
+		assert.True(t, errors.Is(err, context.Canceled))
+		return
+	}
+	assert.False(t, it.Next(), "expected iterator to stop after context cancellation")
+	assert.True(t, errors.Is(it.Error(), context.Canceled))
+	assert.Nil(t, it.Close())
+}
+
 // TestRemoveStaleEdges verifies that the edge deletion logic works as expected.
 func (s *SuiteBase) TestRemoveStaleEdges(t *testing.T) {
 	numEdges := 100
@@ -434,7 +1167,7 @@ func (s *SuiteBase) TestRemoveStaleEdges(t *testing.T) {
 	goneUUIDs := make(map[uuid.UUID]struct{})
 	for i := 0; i < numEdges*4; i++ {
 		link := &domain.Link{URL: fmt.Sprint(i)}
-		assert.Nil(t, s.g.UpsertLink(link))
+		assert.Nil(t, s.g.UpsertLink(context.TODO(), link))
 		linkUUIDs[i] = link.ID
 	}
 
@@ -444,7 +1177,7 @@ func (s *SuiteBase) TestRemoveStaleEdges(t *testing.T) {
 			Src: linkUUIDs[0],
 			Dst: linkUUIDs[i],
 		}
-		assert.Nil(t, s.g.UpsertEdge(e1))
+		assert.Nil(t, s.g.UpsertEdge(context.TODO(), e1))
 		goneUUIDs[e1.ID] = struct{}{}
 		lastTs = e1.UpdatedAt
 	}
@@ -458,9 +1191,9 @@ func (s *SuiteBase) TestRemoveStaleEdges(t *testing.T) {
 			Src: linkUUIDs[0],
 			Dst: linkUUIDs[numEdges+i+1],
 		}
-		assert.Nil(t, s.g.UpsertEdge(e2))
+		assert.Nil(t, s.g.UpsertEdge(context.TODO(), e2))
 	}
-	assert.Nil(t, s.g.RemoveStaleEdges(linkUUIDs[0], deleteBefore))
+	assert.Nil(t, s.g.RemoveStaleEdges(context.TODO(), linkUUIDs[0], deleteBefore))
 
 	it, err := s.partitionedEdgeIterator(t, 0, 1, time.Now())
 	assert.Nil(t, err)
@@ -478,50 +1211,20 @@ func (s *SuiteBase) TestRemoveStaleEdges(t *testing.T) {
 }
 
 func (s *SuiteBase) partitionedLinkIterator(t *testing.T, partition, numPartitions int, accessedBefore time.Time) (repository.LinkIterator, error) {
-	from, to := s.partitionRange(t, partition, numPartitions)
-	return s.g.Links(from, to, accessedBefore)
+	return s.g.Links(context.TODO(), s.partitionRange(t, partition, numPartitions), accessedBefore)
 }
 
 func (s *SuiteBase) partitionedEdgeIterator(t *testing.T, partition, numPartitions int, updatedBefore time.Time) (repository.EdgeIterator, error) {
-	from, to := s.partitionRange(t, partition, numPartitions)
-	return s.g.Edges(from, to, updatedBefore)
+	return s.g.Edges(context.TODO(), s.partitionRange(t, partition, numPartitions), updatedBefore)
 }
 
-func (s *SuiteBase) partitionRange(t *testing.T, partition, numPartitions int) (from, to uuid.UUID) {
-	if partition < 0 || partition >= numPartitions {
-		t.Fatal("invalid partition")
-	}
-
-	var minUUID = uuid.Nil
-	var maxUUID = uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff")
-	var err error
-
-	// Calculate the size of each partition as: (2^128 / numPartitions)
-	tokenRange := big.NewInt(0)
-	partSize := big.NewInt(0)
-	partSize.SetBytes(maxUUID[:])
-	partSize = partSize.Div(partSize, big.NewInt(int64(numPartitions)))
-
-	// We model the partitions as a segment that begins at minUUID (all
-	// bits set to zero) and ends at maxUUID (all bits set to 1). By
-	// setting the end range for the *last* partition to maxUUID we ensure
-	// that we always cover the full range of UUIDs even if the range
-	// itself is not evenly divisible by numPartitions.
-	if partition == 0 {
-		from = minUUID
-	} else {
-		tokenRange.Mul(partSize, big.NewInt(int64(partition)))
-		from, err = uuid.FromBytes(tokenRange.Bytes())
-		assert.Nil(t, err)
-	}
+func (s *SuiteBase) partitionRange(t *testing.T, part, numPartitions int) repository.IDRange {
+	r, err := partition.NewFullRange(numPartitions)
+	assert.Nil(t, err)
 
-	if partition == numPartitions-1 {
-		to = maxUUID
-	} else {
-		tokenRange.Mul(partSize, big.NewInt(int64(partition+1)))
-		to, err = uuid.FromBytes(tokenRange.Bytes())
-		assert.Nil(t, err)
+	from, to, err := r.PartitionExtents(part)
+	if err != nil {
+		t.Fatal("invalid partition")
 	}
-
-	return from, to
+	return repository.NewIDRange(from, to)
 }
@@ -0,0 +1,703 @@
+// Package bolt implements a graph repository on top of an embedded bbolt
+// key-value store, giving single-node deployments a durable graph without a
+// database process.
+//
+// The store uses the following buckets:
+//
+//	links    link ID         -> link
+//	linkURLs URL             -> link ID
+//	edges    edge ID         -> edge
+//	outEdges src ID + dst ID + kind -> edge ID (per-source edge lists)
+//	inEdges  dst ID + src ID + kind -> edge ID (per-destination edge lists)
+//	dups     canonical ID + link ID -> empty (links duplicating a canonical link)
+//	meta     "layout" -> layout version of the buckets above
+//
+// UUIDs are stored as their 16 raw bytes so cursor scans visit them in UUID
+// order.
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+	"go.etcd.io/bbolt"
+	"time"
+)
+
+var (
+	linksBucket    = []byte("links")
+	linkURLsBucket = []byte("linkURLs")
+	edgesBucket    = []byte("edges")
+	outEdgesBucket = []byte("outEdges")
+	inEdgesBucket  = []byte("inEdges")
+	dupsBucket     = []byte("dups")
+	metaBucket     = []byte("meta")
+
+	allBuckets = [][]byte{linksBucket, linkURLsBucket, edgesBucket, outEdgesBucket, inEdgesBucket, dupsBucket, metaBucket}
+
+	layoutKey = []byte("layout")
+)
+
+// layoutVersion is the version of the bucket layout written by this
+// package. Version 1 keyed the edge lists by the link IDs only.
+const layoutVersion = 2
+
+type GraphBoltRepository struct {
+	db *bbolt.DB
+}
+
+// NewGraphBoltRepository creates a graph backed by db. The database must
+// have been opened with Open so that the required buckets exist.
+func NewGraphBoltRepository(db *bbolt.DB) repository.GraphRepository {
+	return &GraphBoltRepository{
+		db: db,
+	}
+}
+
+// Open opens the bbolt database at path, creating it and the buckets used by
+// the graph if needed.
+func Open(path string) (*bbolt.DB, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open bolt: %w", err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range allBuckets {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return upgradeLayout(tx)
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("open bolt: %w", err)
+	}
+	return db, nil
+}
+
+// upgradeLayout rewrites the buckets of a database created by an older
+// version of this package to the current layout.
+func upgradeLayout(tx *bbolt.Tx) error {
+	meta := tx.Bucket(metaBucket)
+	if v := meta.Get(layoutKey); len(v) == 1 && v[0] == layoutVersion {
+		return nil
+	}
+
+	// Version 1 edge list keys lack the edge kind.
+	edges := tx.Bucket(edgesBucket)
+	for _, name := range [][]byte{outEdgesBucket, inEdgesBucket} {
+		b := tx.Bucket(name)
+		var legacy [][2][]byte
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			if len(k) == 32 {
+				legacy = append(legacy, [2][]byte{append([]byte(nil), k...), append([]byte(nil), v...)})
+			}
+		}
+		for _, kv := range legacy {
+			edge, err := decodeEdge(edges.Get(kv[1]))
+			if err != nil {
+				return err
+			}
+			if err = b.Delete(kv[0]); err != nil {
+				return err
+			}
+			if err = b.Put(append(kv[0], byte(edge.Kind)), kv[1]); err != nil {
+				return err
+			}
+		}
+	}
+	return meta.Put(layoutKey, []byte{layoutVersion})
+}
+
+func (r *GraphBoltRepository) UpsertLink(_ context.Context, link *domain.Link) error {
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		return upsertLink(tx, link)
+	})
+	if err != nil {
+		return fmt.Errorf("upsert link: %w", err)
+	}
+	return nil
+}
+
+func (r *GraphBoltRepository) UpsertLinks(_ context.Context, links []*domain.Link) error {
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		for _, link := range links {
+			if err := upsertLink(tx, link); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("upsert links: %w", err)
+	}
+	return nil
+}
+
+func upsertLink(tx *bbolt.Tx, link *domain.Link) error {
+	links := tx.Bucket(linksBucket)
+
+	// Check if a link with the same URL already exists. If so, convert
+	// this into an update. The stored link is only replaced by a more
+	// recent retrieval.
+	if id := tx.Bucket(linkURLsBucket).Get([]byte(link.URL)); id != nil {
+		existing, err := decodeLink(links.Get(id))
+		if err != nil {
+			return err
+		}
+		if !link.RetrievedAt.After(existing.RetrievedAt) {
+			*link = *existing
+			return nil
+		}
+		link.ID = existing.ID
+		link.CanonicalID = existing.CanonicalID
+		link.RetrievedAt = link.RetrievedAt.UTC()
+		return putJSON(links, link.ID[:], link)
+	}
+
+	// Assign new ID and insert link
+	for {
+		link.ID = uuid.New()
+		if links.Get(link.ID[:]) == nil {
+			break
+		}
+	}
+	link.CanonicalID = uuid.Nil
+	link.RetrievedAt = link.RetrievedAt.UTC()
+	if err := putJSON(links, link.ID[:], link); err != nil {
+		return err
+	}
+	return tx.Bucket(linkURLsBucket).Put([]byte(link.URL), link.ID[:])
+}
+
+func (r *GraphBoltRepository) FindLink(_ context.Context, id uuid.UUID) (*domain.Link, error) {
+	var link *domain.Link
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		link, err = findLink(tx, id[:])
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find link: %w", err)
+	}
+	return link, nil
+}
+
+func (r *GraphBoltRepository) FindLinkByURL(_ context.Context, url string) (*domain.Link, error) {
+	var link *domain.Link
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		id := tx.Bucket(linkURLsBucket).Get([]byte(url))
+		if id == nil {
+			return repository.GraphErrNotFound
+		}
+
+		var err error
+		link, err = findLink(tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find link by URL: %w", err)
+	}
+	return link, nil
+}
+
+func (r *GraphBoltRepository) FindLinks(_ context.Context, ids []uuid.UUID) ([]*domain.Link, error) {
+	list := make([]*domain.Link, 0, len(ids))
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		links := tx.Bucket(linksBucket)
+		for _, id := range ids {
+			data := links.Get(id[:])
+			if data == nil {
+				continue
+			}
+			link, err := decodeLink(data)
+			if err != nil {
+				return err
+			}
+			list = append(list, link)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find links: %w", err)
+	}
+	return list, nil
+}
+
+func findLink(tx *bbolt.Tx, id []byte) (*domain.Link, error) {
+	data := tx.Bucket(linksBucket).Get(id)
+	if data == nil {
+		return nil, repository.GraphErrNotFound
+	}
+	return decodeLink(data)
+}
+
+func (r *GraphBoltRepository) RemoveLink(_ context.Context, id uuid.UUID) error {
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		link, err := findLink(tx, id[:])
+		if err != nil {
+			return err
+		}
+
+		// Drop outgoing and incoming edges. Self-loops appear in both edge
+		// lists so the IDs are deduplicated before removal.
+		edgeIDs := make(map[string]struct{})
+		for _, bucket := range [][]byte{outEdgesBucket, inEdgesBucket} {
+			c := tx.Bucket(bucket).Cursor()
+			for k, v := c.Seek(id[:]); k != nil && bytes.HasPrefix(k, id[:]); k, v = c.Next() {
+				edgeIDs[string(v)] = struct{}{}
+			}
+		}
+		for edgeID := range edgeIDs {
+			if err = removeEdge(tx, []byte(edgeID)); err != nil {
+				return err
+			}
+		}
+
+		// Links that duplicate the removed link become canonical again.
+		for _, dupID := range duplicates(tx, id) {
+			dup, err := findLink(tx, dupID[:])
+			if err != nil {
+				return err
+			}
+			if err = setCanonical(tx, dup, uuid.Nil); err != nil {
+				return err
+			}
+		}
+		if err = setCanonical(tx, link, uuid.Nil); err != nil {
+			return err
+		}
+
+		if err = tx.Bucket(linkURLsBucket).Delete([]byte(link.URL)); err != nil {
+			return err
+		}
+		return tx.Bucket(linksBucket).Delete(id[:])
+	})
+	if err != nil {
+		return fmt.Errorf("remove link: %w", err)
+	}
+	return nil
+}
+
+func (r *GraphBoltRepository) TouchLink(_ context.Context, id uuid.UUID, retrievedAt time.Time) error {
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		link, err := findLink(tx, id[:])
+		if err != nil {
+			return err
+		}
+		if retrievedAt.After(link.RetrievedAt) {
+			if err = putJSON(tx.Bucket(linksBucket), id[:], link.NotModified(retrievedAt)); err != nil {
+				return err
+			}
+		}
+
+		edges := tx.Bucket(edgesBucket)
+		now := time.Now().UTC()
+		for _, entry := range edgeEntries(tx.Bucket(outEdgesBucket), id) {
+			edge, err := decodeEdge(edges.Get(entry.edgeID))
+			if err != nil {
+				return err
+			}
+			edge.UpdatedAt = now
+			if err = putJSON(edges, entry.edgeID, edge); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("touch link: %w", err)
+	}
+	return nil
+}
+
+func (r *GraphBoltRepository) MergeLink(_ context.Context, id, canonicalID uuid.UUID) error {
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		link, err := findLink(tx, id[:])
+		if err != nil {
+			return err
+		}
+		canonical, err := findLink(tx, canonicalID[:])
+		if err != nil {
+			return err
+		}
+		if id == canonicalID {
+			return setCanonical(tx, link, uuid.Nil)
+		}
+
+		target := canonicalID
+		if c := canonical.CanonicalID; c != uuid.Nil && c != id {
+			target = c
+		}
+
+		// Links that duplicated id now duplicate the target, which may
+		// itself have been one of them.
+		for _, dupID := range duplicates(tx, id) {
+			dup, err := findLink(tx, dupID[:])
+			if err != nil {
+				return err
+			}
+			newCanonical := target
+			if dupID == target {
+				newCanonical = uuid.Nil
+			}
+			if err = setCanonical(tx, dup, newCanonical); err != nil {
+				return err
+			}
+		}
+		if err = setCanonical(tx, link, target); err != nil {
+			return err
+		}
+		return moveEdges(tx, id, target)
+	})
+	if err != nil {
+		return fmt.Errorf("merge link: %w", err)
+	}
+	return nil
+}
+
+// duplicates returns the IDs of the links that duplicate canonicalID.
+func duplicates(tx *bbolt.Tx, canonicalID uuid.UUID) []uuid.UUID {
+	var ids []uuid.UUID
+	c := tx.Bucket(dupsBucket).Cursor()
+	for k, _ := c.Seek(canonicalID[:]); k != nil && bytes.HasPrefix(k, canonicalID[:]); k, _ = c.Next() {
+		ids = append(ids, uuid.UUID(k[len(canonicalID):]))
+	}
+	return ids
+}
+
+// setCanonical stores link as a duplicate of canonicalID, or as a canonical
+// link if canonicalID is uuid.Nil, and keeps the dups bucket in sync.
+func setCanonical(tx *bbolt.Tx, link *domain.Link, canonicalID uuid.UUID) error {
+	dups := tx.Bucket(dupsBucket)
+	if link.CanonicalID != uuid.Nil {
+		if err := dups.Delete(pairKey(link.CanonicalID, link.ID)); err != nil {
+			return err
+		}
+	}
+	if canonicalID != uuid.Nil {
+		if err := dups.Put(pairKey(canonicalID, link.ID), []byte{}); err != nil {
+			return err
+		}
+	}
+
+	link.CanonicalID = canonicalID
+	return putJSON(tx.Bucket(linksBucket), link.ID[:], link)
+}
+
+// moveEdges moves the edges of link id to link target, keeping their IDs.
+// Edges between the two links and edges that target already has are
+// dropped.
+func moveEdges(tx *bbolt.Tx, id, target uuid.UUID) error {
+	edges := tx.Bucket(edgesBucket)
+	outEdges := tx.Bucket(outEdgesBucket)
+	inEdges := tx.Bucket(inEdgesBucket)
+
+	move := func(edgeID []byte, update func(edge *domain.Edge)) error {
+		edge, err := decodeEdge(edges.Get(edgeID))
+		if err != nil {
+			return err
+		}
+		if err = outEdges.Delete(edgeKey(edge.Src, edge.Dst, edge.Kind)); err != nil {
+			return err
+		}
+		if err = inEdges.Delete(edgeKey(edge.Dst, edge.Src, edge.Kind)); err != nil {
+			return err
+		}
+		update(edge)
+		if err = outEdges.Put(edgeKey(edge.Src, edge.Dst, edge.Kind), edgeID); err != nil {
+			return err
+		}
+		if err = inEdges.Put(edgeKey(edge.Dst, edge.Src, edge.Kind), edgeID); err != nil {
+			return err
+		}
+		return putJSON(edges, edgeID, edge)
+	}
+
+	for _, entry := range edgeEntries(outEdges, id) {
+		dst := entry.other
+		if dst == id || dst == target || outEdges.Get(edgeKey(target, dst, entry.kind)) != nil {
+			if err := removeEdge(tx, entry.edgeID); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := move(entry.edgeID, func(edge *domain.Edge) { edge.Src = target }); err != nil {
+			return err
+		}
+	}
+
+	// Self-loops were dropped along with the outgoing edges.
+	for _, entry := range edgeEntries(inEdges, id) {
+		src := entry.other
+		if src == target || inEdges.Get(edgeKey(target, src, entry.kind)) != nil {
+			if err := removeEdge(tx, entry.edgeID); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := move(entry.edgeID, func(edge *domain.Edge) { edge.Dst = target }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// edgeListEntry is an entry of a per-link edge list.
+type edgeListEntry struct {
+	other  uuid.UUID
+	kind   domain.EdgeKind
+	edgeID []byte
+}
+
+// edgeEntries returns a copy of the entries of the edge list of owner held
+// in bucket b.
+func edgeEntries(b *bbolt.Bucket, owner uuid.UUID) []edgeListEntry {
+	var entries []edgeListEntry
+	c := b.Cursor()
+	for k, v := c.Seek(owner[:]); k != nil && bytes.HasPrefix(k, owner[:]); k, v = c.Next() {
+		entries = append(entries, edgeListEntry{
+			other:  uuid.UUID(k[len(owner) : 2*len(owner)]),
+			kind:   domain.EdgeKind(k[2*len(owner)]),
+			edgeID: append([]byte(nil), v...),
+		})
+	}
+	return entries
+}
+
+func (r *GraphBoltRepository) Links(ctx context.Context, ids repository.IDRange, retrievedBefore time.Time) (repository.LinkIterator, error) {
+	from, to := rangeKeys(ids)
+	return newLinkIterator(ctx, r.db, from, to, retrievedBefore), nil
+}
+
+func (r *GraphBoltRepository) UpsertEdge(_ context.Context, edge *domain.Edge) error {
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		return upsertEdge(tx, edge)
+	})
+	if err != nil {
+		return fmt.Errorf("upsert edge: %w", err)
+	}
+	return nil
+}
+
+func (r *GraphBoltRepository) UpsertEdges(_ context.Context, edges []*domain.Edge) error {
+	// Returning an error from the update function rolls back the whole
+	// batch.
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		for _, edge := range edges {
+			if err := upsertEdge(tx, edge); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("upsert edges: %w", err)
+	}
+	return nil
+}
+
+func upsertEdge(tx *bbolt.Tx, edge *domain.Edge) error {
+	links := tx.Bucket(linksBucket)
+	if links.Get(edge.Src[:]) == nil || links.Get(edge.Dst[:]) == nil {
+		return repository.GraphErrUnknownEdgeLinks
+	}
+
+	edges := tx.Bucket(edgesBucket)
+	outKey := edgeKey(edge.Src, edge.Dst, edge.Kind)
+	if edgeID := tx.Bucket(outEdgesBucket).Get(outKey); edgeID != nil {
+		existing, err := decodeEdge(edges.Get(edgeID))
+		if err != nil {
+			return err
+		}
+		existing.UpdatedAt = time.Now().UTC()
+		existing.AnchorText = edge.AnchorText
+		existing.Rel = edge.Rel
+		*edge = *existing
+		return putJSON(edges, edge.ID[:], edge)
+	}
+
+	// Insert new edge
+	for {
+		edge.ID = uuid.New()
+		if edges.Get(edge.ID[:]) == nil {
+			break
+		}
+	}
+	edge.UpdatedAt = time.Now().UTC()
+	if err := putJSON(edges, edge.ID[:], edge); err != nil {
+		return err
+	}
+	if err := tx.Bucket(outEdgesBucket).Put(outKey, edge.ID[:]); err != nil {
+		return err
+	}
+	return tx.Bucket(inEdgesBucket).Put(edgeKey(edge.Dst, edge.Src, edge.Kind), edge.ID[:])
+}
+
+func (r *GraphBoltRepository) RemoveEdge(_ context.Context, id uuid.UUID) error {
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		return removeEdge(tx, id[:])
+	})
+	if err != nil {
+		return fmt.Errorf("remove edge: %w", err)
+	}
+	return nil
+}
+
+// removeEdge deletes an edge along with its entries in the per-source and
+// per-destination edge lists.
+func removeEdge(tx *bbolt.Tx, id []byte) error {
+	edges := tx.Bucket(edgesBucket)
+	data := edges.Get(id)
+	if data == nil {
+		return repository.GraphErrNotFound
+	}
+	edge, err := decodeEdge(data)
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Bucket(outEdgesBucket).Delete(edgeKey(edge.Src, edge.Dst, edge.Kind)); err != nil {
+		return err
+	}
+	if err = tx.Bucket(inEdgesBucket).Delete(edgeKey(edge.Dst, edge.Src, edge.Kind)); err != nil {
+		return err
+	}
+	return edges.Delete(id)
+}
+
+func (r *GraphBoltRepository) RemoveStaleEdges(_ context.Context, fromID uuid.UUID, updatedBefore time.Time) error {
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		edges := tx.Bucket(edgesBucket)
+
+		var stale [][]byte
+		c := tx.Bucket(outEdgesBucket).Cursor()
+		for k, v := c.Seek(fromID[:]); k != nil && bytes.HasPrefix(k, fromID[:]); k, v = c.Next() {
+			edge, err := decodeEdge(edges.Get(v))
+			if err != nil {
+				return err
+			}
+			if edge.UpdatedAt.Before(updatedBefore) {
+				stale = append(stale, append([]byte(nil), v...))
+			}
+		}
+
+		for _, edgeID := range stale {
+			if err := removeEdge(tx, edgeID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("remove stale edges: %w", err)
+	}
+	return nil
+}
+
+func (r *GraphBoltRepository) Edges(ctx context.Context, srcIDs repository.IDRange, updatedBefore time.Time) (repository.EdgeIterator, error) {
+	from, to := rangeKeys(srcIDs)
+	return newEdgeIterator(ctx, r.db, outEdgesBucket, from, to, func(edge *domain.Edge) bool {
+		return edge.UpdatedAt.Before(updatedBefore)
+	}), nil
+}
+
+func (r *GraphBoltRepository) InEdges(ctx context.Context, linkID uuid.UUID) (repository.EdgeIterator, error) {
+	return newEdgeIterator(ctx, r.db, inEdgesBucket, linkID[:], prefixEnd(linkID[:]), nil), nil
+}
+
+func (r *GraphBoltRepository) OutEdges(ctx context.Context, linkID uuid.UUID) (repository.EdgeIterator, error) {
+	return newEdgeIterator(ctx, r.db, outEdgesBucket, linkID[:], prefixEnd(linkID[:]), nil), nil
+}
+
+func (r *GraphBoltRepository) Degree(_ context.Context, linkID uuid.UUID) (int, int, error) {
+	var inDegree, outDegree int
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		if tx.Bucket(linksBucket).Get(linkID[:]) == nil {
+			return repository.GraphErrNotFound
+		}
+		inDegree = countPrefix(tx.Bucket(inEdgesBucket), linkID[:])
+		outDegree = countPrefix(tx.Bucket(outEdgesBucket), linkID[:])
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("degree: %w", err)
+	}
+	return inDegree, outDegree, nil
+}
+
+// rangeKeys returns the cursor bounds for ids. Unbounded ranges yield a nil
+// upper bound.
+func rangeKeys(ids repository.IDRange) (from, to []byte) {
+	if ids.Unbounded() {
+		return ids.From[:], nil
+	}
+	return ids.From[:], ids.To[:]
+}
+
+// pairKey builds a key from the IDs of two links, ordering it by owner.
+func pairKey(owner, other uuid.UUID) []byte {
+	key := make([]byte, 0, 33)
+	key = append(key, owner[:]...)
+	return append(key, other[:]...)
+}
+
+// edgeKey builds the key of an edge list entry from the IDs of the link that
+// owns the list and the link at the other end of the edge, followed by the
+// edge kind.
+func edgeKey(owner, other uuid.UUID, kind domain.EdgeKind) []byte {
+	return append(pairKey(owner, other), byte(kind))
+}
+
+func countPrefix(b *bbolt.Bucket, prefix []byte) int {
+	var count int
+	c := b.Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		count++
+	}
+	return count
+}
+
+// prefixEnd returns the smallest key that is greater than every key starting
+// with prefix, or nil if no such key exists.
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte(nil), prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+func putJSON(b *bbolt.Bucket, key []byte, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.Put(key, data)
+}
+
+func decodeLink(data []byte) (*domain.Link, error) {
+	link := new(domain.Link)
+	if err := json.Unmarshal(data, link); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+func decodeEdge(data []byte) (*domain.Edge, error) {
+	edge := new(domain.Edge)
+	if err := json.Unmarshal(data, edge); err != nil {
+		return nil, err
+	}
+	return edge, nil
+}
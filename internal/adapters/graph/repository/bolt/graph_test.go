@@ -0,0 +1,181 @@
+package bolt
+
+import (
+	"context"
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/graphtest"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.etcd.io/bbolt"
+	"path/filepath"
+	"testing"
+)
+
+func Test(t *testing.T) {
+	suite.Run(t, new(GraphBoltRepositoryTestSuite))
+}
+
+type GraphBoltRepositoryTestSuite struct {
+	suite.Suite
+	base graphtest.SuiteBase
+	db   *bbolt.DB
+}
+
+func (s *GraphBoltRepositoryTestSuite) SetupSuite() {
+	db, err := Open(filepath.Join(s.T().TempDir(), "graph.db"))
+	if err != nil {
+		s.FailNowf("", "failed to open database %v", err)
+	}
+	s.db = db
+	s.base.SetGraph(NewGraphBoltRepository(db))
+}
+func (s *GraphBoltRepositoryTestSuite) SetupTest() {
+	s.flushDB()
+}
+
+func (s *GraphBoltRepositoryTestSuite) flushDB() {
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range allBuckets {
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+			if _, err := tx.CreateBucket(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	s.Nil(err)
+}
+func (s *GraphBoltRepositoryTestSuite) TearDownSuite() {
+	if s.db != nil {
+		s.Nil(s.db.Close())
+	}
+}
+func (s *GraphBoltRepositoryTestSuite) TestUpsertLink() {
+	s.base.TestUpsertLink(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestUpsertLinks() {
+	s.base.TestUpsertLinks(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestLinkFetchMetadata() {
+	s.base.TestLinkFetchMetadata(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestTouchLink() {
+	s.base.TestTouchLink(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestMergeLink() {
+	s.base.TestMergeLink(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestFindLink() {
+	s.base.TestFindLink(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestFindLinkByURL() {
+	s.base.TestFindLinkByURL(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestFindLinks() {
+	s.base.TestFindLinks(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestRemoveLink() {
+	s.base.TestRemoveLink(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestConcurrentLinkIterators() {
+	s.base.TestConcurrentLinkIterators(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestLinkIteratorTimeFilter() {
+	s.base.TestLinkIteratorTimeFilter(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestPartitionedLinkIterators() {
+	s.base.TestPartitionedLinkIterators(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestLinkIteratorContextCancellation() {
+	s.base.TestLinkIteratorContextCancellation(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestLinkRangeBoundaries() {
+	s.base.TestLinkRangeBoundaries(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestUpsertEdge() {
+	s.base.TestUpsertEdge(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestUpsertEdges() {
+	s.base.TestUpsertEdges(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestEdgeAnchor() {
+	s.base.TestEdgeAnchor(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestRedirectEdge() {
+	s.base.TestRedirectEdge(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestRemoveEdge() {
+	s.base.TestRemoveEdge(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestInOutEdges() {
+	s.base.TestInOutEdges(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestStats() {
+	s.base.TestStats(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestConcurrentEdgeIterators() {
+	s.base.TestConcurrentEdgeIterators(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestEdgeIteratorTimeFilter() {
+	s.base.TestEdgeIteratorTimeFilter(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestPartitionedEdgeIterators() {
+	s.base.TestPartitionedEdgeIterators(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestRemoveStaleEdges() {
+	s.base.TestRemoveStaleEdges(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestEdgeIteratorContextCancellation() {
+	s.base.TestEdgeIteratorContextCancellation(s.T())
+}
+func (s *GraphBoltRepositoryTestSuite) TestEdgeRangeBoundaries() {
+	s.base.TestEdgeRangeBoundaries(s.T())
+}
+
+func TestUpgradeLegacyEdgeKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.db")
+	db, err := Open(path)
+	require.NoError(t, err)
+
+	g := NewGraphBoltRepository(db)
+	src := &domain.Link{URL: "https://example.com"}
+	dst := &domain.Link{URL: "https://example.com/about"}
+	require.NoError(t, g.UpsertLinks(context.TODO(), []*domain.Link{src, dst}))
+	edge := &domain.Edge{Src: src.ID, Dst: dst.ID, Kind: domain.EdgeKindRedirect}
+	require.NoError(t, g.UpsertEdge(context.TODO(), edge))
+
+	// Rewrite the edge lists the way version 1 of the layout stored them.
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for name, key := range map[string][]byte{
+			string(outEdgesBucket): pairKey(src.ID, dst.ID),
+			string(inEdgesBucket):  pairKey(dst.ID, src.ID),
+		} {
+			b := tx.Bucket([]byte(name))
+			if err := b.Delete(append(key, byte(edge.Kind))); err != nil {
+				return err
+			}
+			if err := b.Put(key, edge.ID[:]); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(metaBucket).Delete(layoutKey)
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	db, err = Open(path)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	g = NewGraphBoltRepository(db)
+
+	// Upserting the edge again must find the upgraded entry.
+	again := &domain.Edge{Src: src.ID, Dst: dst.ID, Kind: domain.EdgeKindRedirect}
+	require.NoError(t, g.UpsertEdge(context.TODO(), again))
+	assert.Equal(t, edge.ID, again.ID)
+	in, out, err := g.Degree(context.TODO(), dst.ID)
+	require.NoError(t, err)
+	assert.Equal(t, [2]int{1, 0}, [2]int{in, out})
+}
@@ -0,0 +1,176 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"go.etcd.io/bbolt"
+	"time"
+)
+
+// batchSize is the number of entries fetched by an iterator within a single
+// read transaction. Paging keeps read transactions short so that they do not
+// hold back writers for the duration of a long scan.
+const batchSize = 100
+
+// cursorRange pages through the keys of a bucket that fall in [next, to).
+// A nil to denotes an unbounded range.
+type cursorRange struct {
+	db     *bbolt.DB
+	bucket []byte
+	next   []byte
+	to     []byte
+	done   bool
+}
+
+// fetch visits up to batchSize entries of the range, invoking fn for each of
+// them, and advances the range past the visited entries.
+func (r *cursorRange) fetch(fn func(tx *bbolt.Tx, k, v []byte) error) error {
+	return r.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(r.bucket).Cursor()
+		k, v := c.Seek(r.next)
+		for n := 0; ; n++ {
+			if k == nil || (r.to != nil && bytes.Compare(k, r.to) >= 0) {
+				r.done = true
+				return nil
+			}
+			if n == batchSize {
+				r.next = append([]byte(nil), k...)
+				return nil
+			}
+			if err := fn(tx, k, v); err != nil {
+				return err
+			}
+			k, v = c.Next()
+		}
+	})
+}
+
+// linkIterator is a repository.LinkIterator implementation for the bolt graph.
+type linkIterator struct {
+	ctx             context.Context
+	rng             cursorRange
+	retrievedBefore time.Time
+
+	buf         []*domain.Link
+	lastErr     error
+	latchedLink *domain.Link
+}
+
+func newLinkIterator(ctx context.Context, db *bbolt.DB, from, to []byte, retrievedBefore time.Time) *linkIterator {
+	return &linkIterator{
+		ctx:             ctx,
+		rng:             cursorRange{db: db, bucket: linksBucket, next: from, to: to},
+		retrievedBefore: retrievedBefore,
+	}
+}
+
+func (i *linkIterator) Next() bool {
+	if i.lastErr != nil {
+		return false
+	}
+	if i.lastErr = i.ctx.Err(); i.lastErr != nil {
+		return false
+	}
+
+	for len(i.buf) == 0 {
+		if i.rng.done {
+			return false
+		}
+		i.lastErr = i.rng.fetch(func(_ *bbolt.Tx, _, v []byte) error {
+			link, err := decodeLink(v)
+			if err != nil {
+				return err
+			}
+			if link.RetrievedAt.Before(i.retrievedBefore) {
+				i.buf = append(i.buf, link)
+			}
+			return nil
+		})
+		if i.lastErr != nil {
+			return false
+		}
+	}
+
+	i.latchedLink, i.buf = i.buf[0], i.buf[1:]
+	return true
+}
+
+func (i *linkIterator) Error() error {
+	return i.lastErr
+}
+
+func (i *linkIterator) Close() error {
+	i.buf = nil
+	i.rng.done = true
+	return nil
+}
+
+func (i *linkIterator) Link() *domain.Link {
+	return i.latchedLink
+}
+
+// edgeIterator is a repository.EdgeIterator implementation for the bolt
+// graph. It scans an edge list bucket and resolves each entry to its edge.
+type edgeIterator struct {
+	ctx    context.Context
+	rng    cursorRange
+	filter func(*domain.Edge) bool
+
+	buf         []*domain.Edge
+	lastErr     error
+	latchedEdge *domain.Edge
+}
+
+func newEdgeIterator(ctx context.Context, db *bbolt.DB, bucket, from, to []byte, filter func(*domain.Edge) bool) *edgeIterator {
+	return &edgeIterator{
+		ctx:    ctx,
+		rng:    cursorRange{db: db, bucket: bucket, next: from, to: to},
+		filter: filter,
+	}
+}
+
+func (i *edgeIterator) Next() bool {
+	if i.lastErr != nil {
+		return false
+	}
+	if i.lastErr = i.ctx.Err(); i.lastErr != nil {
+		return false
+	}
+
+	for len(i.buf) == 0 {
+		if i.rng.done {
+			return false
+		}
+		i.lastErr = i.rng.fetch(func(tx *bbolt.Tx, _, v []byte) error {
+			edge, err := decodeEdge(tx.Bucket(edgesBucket).Get(v))
+			if err != nil {
+				return err
+			}
+			if i.filter == nil || i.filter(edge) {
+				i.buf = append(i.buf, edge)
+			}
+			return nil
+		})
+		if i.lastErr != nil {
+			return false
+		}
+	}
+
+	i.latchedEdge, i.buf = i.buf[0], i.buf[1:]
+	return true
+}
+
+func (i *edgeIterator) Error() error {
+	return i.lastErr
+}
+
+func (i *edgeIterator) Close() error {
+	i.buf = nil
+	i.rng.done = true
+	return nil
+}
+
+func (i *edgeIterator) Edge() *domain.Edge {
+	return i.latchedEdge
+}
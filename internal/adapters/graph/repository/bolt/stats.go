@@ -0,0 +1,81 @@
+package bolt
+
+import (
+	"context"
+	"fmt"
+	"github.com/bruceneco/links-r-us/internal/partition"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+	"go.etcd.io/bbolt"
+	"time"
+)
+
+func (r *GraphBoltRepository) Stats(_ context.Context, opts repository.GraphStatsOptions) (*repository.GraphStats, error) {
+	numPartitions := max(opts.NumPartitions, 1)
+	pr, err := partition.NewFullRange(numPartitions)
+	if err != nil {
+		return nil, fmt.Errorf("stats: %w", err)
+	}
+	retrievedSince := time.Now().Add(-opts.RetrievedWithin)
+
+	stats := &repository.GraphStats{
+		EdgesPerPartition:  make([]int, numPartitions),
+		InDegreeHistogram:  make(map[int]int),
+		OutDegreeHistogram: make(map[int]int),
+	}
+	err = r.db.View(func(tx *bbolt.Tx) error {
+		// Count the edges of each link using the edge list buckets.
+		inDegree := make(map[uuid.UUID]int)
+		outDegree := make(map[uuid.UUID]int)
+		err := tx.Bucket(outEdgesBucket).ForEach(func(k, _ []byte) error {
+			src, err := uuid.FromBytes(k[:16])
+			if err != nil {
+				return err
+			}
+			p, err := pr.PartitionForID(src)
+			if err != nil {
+				return err
+			}
+			stats.Edges++
+			stats.EdgesPerPartition[p]++
+			outDegree[src]++
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		err = tx.Bucket(inEdgesBucket).ForEach(func(k, _ []byte) error {
+			dst, err := uuid.FromBytes(k[:16])
+			if err != nil {
+				return err
+			}
+			inDegree[dst]++
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.Bucket(linksBucket).ForEach(func(_, v []byte) error {
+			link, err := decodeLink(v)
+			if err != nil {
+				return err
+			}
+
+			stats.Links++
+			switch {
+			case link.RetrievedAt.IsZero():
+				stats.UnretrievedLinks++
+			case !link.RetrievedAt.Before(retrievedSince):
+				stats.RecentlyRetrievedLinks++
+			}
+			stats.InDegreeHistogram[inDegree[link.ID]]++
+			stats.OutDegreeHistogram[outDegree[link.ID]]++
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("stats: %w", err)
+	}
+	return stats, nil
+}
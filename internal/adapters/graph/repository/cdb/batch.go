@@ -0,0 +1,196 @@
+package cdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+	"strings"
+)
+
+// maxBatchRows caps the number of rows sent in a single multi-row statement
+// so that large batches stay well below the protocol's parameter limit.
+const maxBatchRows = 1000
+
+var upsertLinksQueryPrefix = `INSERT INTO links (` + upsertLinkColumns + `) VALUES `
+
+func (r *GraphCDBRepository) UpsertLinks(ctx context.Context, links []*domain.Link) error {
+	if len(links) == 0 {
+		return nil
+	}
+
+	// A statement cannot touch the same row twice, so collapse links that
+	// share a URL and keep the most recently retrieved one.
+	var urls []string
+	latest := make(map[string]*domain.Link, len(links))
+	for _, link := range links {
+		prev, seen := latest[link.URL]
+		if !seen {
+			urls = append(urls, link.URL)
+		}
+		if !seen || link.RetrievedAt.After(prev.RetrievedAt) {
+			latest[link.URL] = link
+		}
+	}
+
+	stored := make(map[string]*domain.Link, len(urls))
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		clear(stored)
+		for start := 0; start < len(urls); start += maxBatchRows {
+			chunk := urls[start:min(start+maxBatchRows, len(urls))]
+			args := make([]any, 0, len(chunk)*upsertLinkNumArgs)
+			for _, url := range chunk {
+				args = append(args, upsertLinkArgs(latest[url])...)
+			}
+
+			query := upsertLinksQueryPrefix + valuePlaceholders(len(chunk), upsertLinkNumArgs, "") + upsertLinkConflictClause
+			rows, err := tx.QueryContext(ctx, query, args...)
+			if err != nil {
+				return err
+			}
+			for rows.Next() {
+				link, err := scanLink(rows)
+				if err != nil {
+					_ = rows.Close()
+					return err
+				}
+				stored[link.URL] = link
+			}
+			if err = rows.Err(); err != nil {
+				return err
+			}
+			if err = rows.Close(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("upsert links: %w", err)
+	}
+
+	for _, link := range links {
+		*link = *stored[link.URL]
+	}
+	return nil
+}
+
+var upsertEdgesQueryPrefix = `INSERT INTO edges (src, dst, anchor_text, rel, kind, updated_at) VALUES `
+
+// edgeKey identifies an edge by its endpoints and kind.
+type edgeKey struct {
+	src, dst uuid.UUID
+	kind     domain.EdgeKind
+}
+
+func (r *GraphCDBRepository) UpsertEdges(ctx context.Context, edges []*domain.Edge) error {
+	if len(edges) == 0 {
+		return nil
+	}
+
+	// A statement cannot touch the same row twice, so collapse duplicate
+	// edges before building the query, keeping the last one seen.
+	var keys []edgeKey
+	latest := make(map[edgeKey]*domain.Edge, len(edges))
+	for _, edge := range edges {
+		key := edgeKey{src: edge.Src, dst: edge.Dst, kind: edge.Kind}
+		if _, dup := latest[key]; !dup {
+			keys = append(keys, key)
+		}
+		latest[key] = edge
+	}
+
+	stored := make(map[edgeKey]*domain.Edge, len(keys))
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		clear(stored)
+		for start := 0; start < len(keys); start += maxBatchRows {
+			chunk := keys[start:min(start+maxBatchRows, len(keys))]
+			args := make([]any, 0, len(chunk)*5)
+			for _, key := range chunk {
+				edge := latest[key]
+				args = append(args, key.src, key.dst, edge.AnchorText, edge.Rel, key.kind)
+			}
+
+			query := upsertEdgesQueryPrefix + valuePlaceholders(len(chunk), 5, "NOW()") + upsertEdgeConflictClause
+			rows, err := tx.QueryContext(ctx, query, args...)
+			if err != nil {
+				return err
+			}
+			for rows.Next() {
+				edge, err := scanEdge(rows)
+				if err != nil {
+					_ = rows.Close()
+					return err
+				}
+				stored[edgeKey{src: edge.Src, dst: edge.Dst, kind: edge.Kind}] = edge
+			}
+			if err = rows.Err(); err != nil {
+				return err
+			}
+			if err = rows.Close(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if isForeignKeyViolationError(err) {
+			err = repository.GraphErrUnknownEdgeLinks
+		}
+		return fmt.Errorf("upsert edges: %w", err)
+	}
+
+	for _, edge := range edges {
+		*edge = *stored[edgeKey{src: edge.Src, dst: edge.Dst, kind: edge.Kind}]
+	}
+	return nil
+}
+
+// inTx runs fn inside a transaction which is committed if fn succeeds and
+// rolled back otherwise. The whole transaction is restarted if it fails with
+// a retryable error, so fn must reset any state it accumulates.
+func (r *GraphCDBRepository) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return r.inTxWithOptions(ctx, nil, fn)
+}
+
+// inTxWithOptions behaves like inTx but starts the transaction with opts.
+func (r *GraphCDBRepository) inTxWithOptions(ctx context.Context, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	return r.retrier.do(ctx, func() error {
+		tx, err := r.db.BeginTx(ctx, opts)
+		if err != nil {
+			return err
+		}
+		if err = fn(tx); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// valuePlaceholders builds the VALUES list of a multi-row insert with
+// numRows rows of numArgs positional parameters each, optionally followed
+// by a literal SQL expression.
+func valuePlaceholders(numRows, numArgs int, extra string) string {
+	var sb strings.Builder
+	for row := 0; row < numRows; row++ {
+		if row > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteByte('(')
+		for arg := 0; arg < numArgs; arg++ {
+			if arg > 0 {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(&sb, "$%d", row*numArgs+arg+1)
+		}
+		if extra != "" {
+			sb.WriteString(", ")
+			sb.WriteString(extra)
+		}
+		sb.WriteByte(')')
+	}
+	return sb.String()
+}
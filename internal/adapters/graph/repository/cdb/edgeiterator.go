@@ -1,36 +1,24 @@
 package cdb
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
 	"github.com/bruceneco/links-r-us/internal/application/core/domain"
 	"github.com/bruceneco/links-r-us/internal/ports/repository"
 )
 
+// edgeIterator is a repository.EdgeIterator that fetches edges one page at
+// a time.
 type edgeIterator struct {
-	rows        *sql.Rows
-	lastErr     error
-	latchedEdge *domain.Edge
+	pager[*domain.Edge]
 }
 
-func newEdgeIterator(rows *sql.Rows) repository.EdgeIterator {
-	return &edgeIterator{rows: rows}
+func newEdgeIterator(ctx context.Context, pageSize int, retrier *retrier, read pageReader[*domain.Edge]) repository.EdgeIterator {
+	return &edgeIterator{pager: newPager(ctx, pageSize, retrier, read)}
 }
 
 func (i *edgeIterator) Next() bool {
-	if i.lastErr != nil || !i.rows.Next() {
-		return false
-	}
-
-	edge := new(domain.Edge)
-	i.lastErr = i.rows.Scan(&edge.ID, &edge.Src, &edge.Dst, &edge.UpdatedAt)
-	if i.lastErr != nil {
-		return false
-	}
-	edge.UpdatedAt = edge.UpdatedAt.UTC()
-
-	i.latchedEdge = edge
-	return true
+	return i.next()
 }
 
 func (i *edgeIterator) Error() error {
@@ -38,13 +26,35 @@ func (i *edgeIterator) Error() error {
 }
 
 func (i *edgeIterator) Close() error {
-	err := i.rows.Close()
-	if err != nil {
-		return fmt.Errorf("edge iterator: %w", err)
-	}
+	i.close()
 	return nil
 }
 
 func (i *edgeIterator) Edge() *domain.Edge {
-	return i.latchedEdge
+	return i.latched
+}
+
+// scanEdges reads every edge returned by rows and closes them.
+func scanEdges(rows *sql.Rows) ([]*domain.Edge, error) {
+	defer func() { _ = rows.Close() }()
+
+	var edges []*domain.Edge
+	for rows.Next() {
+		edge, err := scanEdge(rows)
+		if err != nil {
+			return nil, err
+		}
+		edges = append(edges, edge)
+	}
+	return edges, rows.Err()
+}
+
+// scanEdge reads an edge from a row holding the edgeColumns.
+func scanEdge(row rowScanner) (*domain.Edge, error) {
+	edge := new(domain.Edge)
+	if err := row.Scan(&edge.ID, &edge.Src, &edge.Dst, &edge.UpdatedAt, &edge.AnchorText, &edge.Rel, &edge.Kind); err != nil {
+		return nil, err
+	}
+	edge.UpdatedAt = edge.UpdatedAt.UTC()
+	return edge, nil
 }
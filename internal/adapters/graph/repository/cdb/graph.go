@@ -1,6 +1,11 @@
+// Package cdb implements the graph repository on top of CockroachDB. Its
+// queries stick to the SQL subset CockroachDB shares with PostgreSQL, so the
+// repository also runs against PostgreSQL once the schema has been created
+// with the PostgreSQL variant of the migrations.
 package cdb
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -8,34 +13,86 @@ import (
 	"github.com/bruceneco/links-r-us/internal/ports/repository"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"os"
 	"time"
 )
 
 type GraphCDBRepository struct {
-	db *sql.DB
+	db       *sql.DB
+	pageSize int
+	retrier  *retrier
 }
 
-func NewGraphCDBRepository(db *sql.DB) repository.GraphRepository {
-	return &GraphCDBRepository{
-		db: db,
+// Option customizes a GraphCDBRepository.
+type Option func(*GraphCDBRepository)
+
+// WithPageSize sets the number of rows fetched by each query issued by the
+// link and edge iterators. Non-positive values are ignored.
+func WithPageSize(n int) Option {
+	return func(r *GraphCDBRepository) {
+		if n > 0 {
+			r.pageSize = n
+		}
+	}
+}
+
+// WithRetryPolicy configures how idempotent operations that fail with a
+// retryable error, such as a CockroachDB transaction restart, are retried.
+// Each operation is attempted at most maxAttempts times, with a random delay
+// between retries bounded by baseDelay doubled after every attempt and
+// capped at maxDelay. A maxAttempts of 1 disables retries.
+func WithRetryPolicy(maxAttempts int, baseDelay, maxDelay time.Duration) Option {
+	return func(r *GraphCDBRepository) {
+		r.retrier.maxAttempts = max(maxAttempts, 1)
+		r.retrier.baseDelay = baseDelay
+		r.retrier.maxDelay = maxDelay
+	}
+}
+
+func NewGraphCDBRepository(db *sql.DB, opts ...Option) *GraphCDBRepository {
+	r := &GraphCDBRepository{
+		db:       db,
+		pageSize: defaultPageSize,
+		retrier:  newRetrier(),
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	return r
 }
 
+// RetryStats returns the number of retries performed so far.
+func (r *GraphCDBRepository) RetryStats() RetryStats {
+	return r.retrier.stats()
+}
+
+// edgeColumns lists the edges columns read by scanEdge, in order.
+const edgeColumns = `id, src, dst, updated_at, anchor_text, rel, kind`
+
+// upsertEdgeConflictClause refreshes an existing edge with the way it was
+// discovered most recently.
+const upsertEdgeConflictClause = `
+	ON CONFLICT (src, dst, kind) DO UPDATE SET updated_at = NOW(), anchor_text = excluded.anchor_text, rel = excluded.rel
+	RETURNING ` + edgeColumns
+
 var upsertEdgeQuery = `
-	INSERT INTO edges (src, dst, updated_at) VALUES ($1, $2, NOW())
-	ON CONFLICT (src, dst) DO UPDATE SET updated_at = NOW()
-	RETURNING id, updated_at
-`
+	INSERT INTO edges (src, dst, anchor_text, rel, kind, updated_at) VALUES ($1, $2, $3, $4, $5, NOW())
+` + upsertEdgeConflictClause
 
-func (r *GraphCDBRepository) UpsertEdge(edge *domain.Edge) error {
-	row := r.db.QueryRow(upsertEdgeQuery, edge.Src, edge.Dst)
-	if err := row.Scan(&edge.ID, &edge.UpdatedAt); err != nil {
+func (r *GraphCDBRepository) UpsertEdge(ctx context.Context, edge *domain.Edge) error {
+	var stored *domain.Edge
+	err := r.retrier.do(ctx, func() error {
+		var err error
+		stored, err = scanEdge(r.db.QueryRowContext(ctx, upsertEdgeQuery, edge.Src, edge.Dst, edge.AnchorText, edge.Rel, edge.Kind))
+		return err
+	})
+	if err != nil {
 		if isForeignKeyViolationError(err) {
 			err = repository.GraphErrUnknownEdgeLinks
 		}
 		return fmt.Errorf("upsert edge: %w", err)
 	}
-	edge.UpdatedAt = edge.UpdatedAt.UTC()
+	*edge = *stored
 	return nil
 }
 
@@ -48,75 +105,457 @@ func isForeignKeyViolationError(err error) bool {
 	return pqErr.Code.Name() == "foreign_key_violation"
 }
 
+// Edge pages are ordered by the unique (src, dst, kind) tuple rather than the
+// edge ID so that each page can be served by the index on the edge
+// endpoints.
 var edgesInPartitionQuery = `
-	SELECT id, src, dst, updated_at 
+	SELECT ` + edgeColumns + `
 	FROM edges 
 	WHERE 
 	    src >= $1 AND 
-	    src < $2 AND 
-	    updated_at < $3
+	    ($2 OR src < $3) AND 
+	    updated_at < $4 AND
+	    ($5::UUID IS NULL OR (src, dst, kind) > ($5, $6, $7))
+	ORDER BY src, dst, kind
+	LIMIT $8
 `
 
-func (r *GraphCDBRepository) Edges(fromID, toID uuid.UUID, updatedBefore time.Time) (repository.EdgeIterator, error) {
-	rows, err := r.db.Query(edgesInPartitionQuery, fromID, toID, updatedBefore)
+func (r *GraphCDBRepository) Edges(ctx context.Context, srcIDs repository.IDRange, updatedBefore time.Time) (repository.EdgeIterator, error) {
+	var (
+		lastSrc, lastDst uuid.NullUUID
+		lastKind         domain.EdgeKind
+	)
+	return newEdgeIterator(ctx, r.pageSize, r.retrier, func(ctx context.Context, limit int) ([]*domain.Edge, error) {
+		rows, err := r.db.QueryContext(ctx, edgesInPartitionQuery, srcIDs.From, srcIDs.Unbounded(), srcIDs.To, updatedBefore, lastSrc, lastDst, lastKind, limit)
+		if err != nil {
+			return nil, fmt.Errorf("edges: %w", err)
+		}
+		edges, err := scanEdges(rows)
+		if err != nil {
+			return nil, fmt.Errorf("edges: %w", err)
+		}
+		if n := len(edges); n != 0 {
+			lastSrc = uuid.NullUUID{UUID: edges[n-1].Src, Valid: true}
+			lastDst = uuid.NullUUID{UUID: edges[n-1].Dst, Valid: true}
+			lastKind = edges[n-1].Kind
+		}
+		return edges, nil
+	}), nil
+}
+
+var inEdgesQuery = `
+	SELECT ` + edgeColumns + ` FROM edges
+	WHERE dst=$1 AND ($2::UUID IS NULL OR (src, kind) > ($2, $3))
+	ORDER BY src, kind
+	LIMIT $4
+`
+
+func (r *GraphCDBRepository) InEdges(ctx context.Context, linkID uuid.UUID) (repository.EdgeIterator, error) {
+	var (
+		lastSrc  uuid.NullUUID
+		lastKind domain.EdgeKind
+	)
+	return newEdgeIterator(ctx, r.pageSize, r.retrier, func(ctx context.Context, limit int) ([]*domain.Edge, error) {
+		rows, err := r.db.QueryContext(ctx, inEdgesQuery, linkID, lastSrc, lastKind, limit)
+		if err != nil {
+			return nil, fmt.Errorf("in edges: %w", err)
+		}
+		edges, err := scanEdges(rows)
+		if err != nil {
+			return nil, fmt.Errorf("in edges: %w", err)
+		}
+		if n := len(edges); n != 0 {
+			lastSrc = uuid.NullUUID{UUID: edges[n-1].Src, Valid: true}
+			lastKind = edges[n-1].Kind
+		}
+		return edges, nil
+	}), nil
+}
+
+var outEdgesQuery = `
+	SELECT ` + edgeColumns + ` FROM edges
+	WHERE src=$1 AND ($2::UUID IS NULL OR (dst, kind) > ($2, $3))
+	ORDER BY dst, kind
+	LIMIT $4
+`
+
+func (r *GraphCDBRepository) OutEdges(ctx context.Context, linkID uuid.UUID) (repository.EdgeIterator, error) {
+	var (
+		lastDst  uuid.NullUUID
+		lastKind domain.EdgeKind
+	)
+	return newEdgeIterator(ctx, r.pageSize, r.retrier, func(ctx context.Context, limit int) ([]*domain.Edge, error) {
+		rows, err := r.db.QueryContext(ctx, outEdgesQuery, linkID, lastDst, lastKind, limit)
+		if err != nil {
+			return nil, fmt.Errorf("out edges: %w", err)
+		}
+		edges, err := scanEdges(rows)
+		if err != nil {
+			return nil, fmt.Errorf("out edges: %w", err)
+		}
+		if n := len(edges); n != 0 {
+			lastDst = uuid.NullUUID{UUID: edges[n-1].Dst, Valid: true}
+			lastKind = edges[n-1].Kind
+		}
+		return edges, nil
+	}), nil
+}
+
+var degreeQuery = `
+	SELECT
+	    (SELECT count(*) FROM edges WHERE dst=$1),
+	    (SELECT count(*) FROM edges WHERE src=$1)
+	FROM links WHERE id=$1
+`
+
+func (r *GraphCDBRepository) Degree(ctx context.Context, linkID uuid.UUID) (int, int, error) {
+	var inDegree, outDegree int
+	err := r.retrier.do(ctx, func() error {
+		return r.db.QueryRowContext(ctx, degreeQuery, linkID).Scan(&inDegree, &outDegree)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, 0, fmt.Errorf("degree: %w", repository.GraphErrNotFound)
+		}
+		return 0, 0, fmt.Errorf("degree: %w", err)
+	}
+	return inDegree, outDegree, nil
+}
+
+var removeEdgeQuery = `
+	DELETE FROM edges WHERE id=$1
+`
+
+// RemoveEdge is not retried: if an attempt that appeared to fail did delete
+// the edge, retrying it would report GraphErrNotFound.
+func (r *GraphCDBRepository) RemoveEdge(ctx context.Context, id uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx, removeEdgeQuery, id)
 	if err != nil {
-		return nil, fmt.Errorf("edges: %w", err)
+		return fmt.Errorf("remove edge: %w", err)
 	}
-	return newEdgeIterator(rows), nil
+	if err = ensureAffected(res); err != nil {
+		return fmt.Errorf("remove edge: %w", err)
+	}
+	return nil
+}
+
+// ensureAffected returns repository.GraphErrNotFound if res reports that no
+// rows were affected.
+func ensureAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return repository.GraphErrNotFound
+	}
+	return nil
 }
 
 var removeStaleEdgesQuery = `
 	DELETE FROM edges WHERE src=$1 AND updated_at < $2
 `
 
-func (r *GraphCDBRepository) RemoveStaleEdges(fromID uuid.UUID, updatedBefore time.Time) error {
-	_, err := r.db.Exec(removeStaleEdgesQuery, fromID, updatedBefore.UTC())
+func (r *GraphCDBRepository) RemoveStaleEdges(ctx context.Context, fromID uuid.UUID, updatedBefore time.Time) error {
+	err := r.retrier.do(ctx, func() error {
+		_, err := r.db.ExecContext(ctx, removeStaleEdgesQuery, fromID, updatedBefore.UTC())
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("remove stale edges: %w", err)
 	}
 	return nil
 }
 
+// linkColumns lists the links columns read by scanLink, in order.
+const linkColumns = `id, url, retrieved_at, status_code, content_type, content_length, etag, last_modified, failure_count, last_error, canonical_id`
+
+// upsertLinkColumns lists the links columns written by upserts, in the order
+// of the arguments returned by upsertLinkArgs.
+const upsertLinkColumns = `url, retrieved_at, status_code, content_type, content_length, etag, last_modified, failure_count, last_error`
+
+// upsertLinkConflictClause keeps the most recent retrieval timestamp of a
+// link and only replaces its fetch metadata with that of a newer retrieval.
+const upsertLinkConflictClause = `
+	ON CONFLICT (url) DO UPDATE SET
+	    retrieved_at=GREATEST(links.retrieved_at, excluded.retrieved_at),
+	    status_code=CASE WHEN excluded.retrieved_at > links.retrieved_at THEN excluded.status_code ELSE links.status_code END,
+	    content_type=CASE WHEN excluded.retrieved_at > links.retrieved_at THEN excluded.content_type ELSE links.content_type END,
+	    content_length=CASE WHEN excluded.retrieved_at > links.retrieved_at THEN excluded.content_length ELSE links.content_length END,
+	    etag=CASE WHEN excluded.retrieved_at > links.retrieved_at THEN excluded.etag ELSE links.etag END,
+	    last_modified=CASE WHEN excluded.retrieved_at > links.retrieved_at THEN excluded.last_modified ELSE links.last_modified END,
+	    failure_count=CASE WHEN excluded.retrieved_at > links.retrieved_at THEN excluded.failure_count ELSE links.failure_count END,
+	    last_error=CASE WHEN excluded.retrieved_at > links.retrieved_at THEN excluded.last_error ELSE links.last_error END
+	RETURNING ` + linkColumns
+
 var upsertLinkQuery = `
-	INSERT INTO links (url, retrieved_at) VALUES ($1, $2)
-	ON CONFLICT (url) DO UPDATE SET retrieved_at=GREATEST(links.retrieved_at, $2)
-	RETURNING id, retrieved_at
-`
+	INSERT INTO links (` + upsertLinkColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+` + upsertLinkConflictClause
+
+// upsertLinkNumArgs is the number of values returned by upsertLinkArgs.
+const upsertLinkNumArgs = 9
 
-func (r *GraphCDBRepository) UpsertLink(link *domain.Link) error {
-	row := r.db.QueryRow(upsertLinkQuery, link.URL, link.RetrievedAt.UTC())
-	if err := row.Scan(&link.ID, &link.RetrievedAt); err != nil {
+// upsertLinkArgs returns the values of upsertLinkColumns for link.
+func upsertLinkArgs(link *domain.Link) []any {
+	return []any{
+		link.URL, link.RetrievedAt.UTC(), link.StatusCode, link.ContentType, link.ContentLength,
+		link.ETag, link.LastModified, link.FailureCount, link.LastError,
+	}
+}
+
+func (r *GraphCDBRepository) UpsertLink(ctx context.Context, link *domain.Link) error {
+	var stored *domain.Link
+	err := r.retrier.do(ctx, func() error {
+		var err error
+		stored, err = scanLink(r.db.QueryRowContext(ctx, upsertLinkQuery, upsertLinkArgs(link)...))
+		return err
+	})
+	if err != nil {
 		return fmt.Errorf("upsert link: %w", err)
 	}
-	link.RetrievedAt = link.RetrievedAt.UTC()
+	*link = *stored
+	return nil
+}
+
+// Edges pointing to or from the link are removed by the ON DELETE CASCADE
+// constraints of the edges table.
+var removeLinkQuery = `
+	DELETE FROM links WHERE id=$1
+`
+
+// RemoveLink is not retried for the same reason as RemoveEdge.
+func (r *GraphCDBRepository) RemoveLink(ctx context.Context, id uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx, removeLinkQuery, id)
+	if err != nil {
+		return fmt.Errorf("remove link: %w", err)
+	}
+	if err = ensureAffected(res); err != nil {
+		return fmt.Errorf("remove link: %w", err)
+	}
+	return nil
+}
+
+// touchLinkQuery only replaces the fetch outcome of a link with a newer one,
+// like upsertLinkConflictClause.
+var touchLinkQuery = `
+	UPDATE links SET
+	    retrieved_at=GREATEST(retrieved_at, $2),
+	    failure_count=CASE WHEN $2 > retrieved_at THEN 0 ELSE failure_count END,
+	    last_error=CASE WHEN $2 > retrieved_at THEN '' ELSE last_error END
+	WHERE id=$1
+`
+
+var touchOutEdgesQuery = `
+	UPDATE edges SET updated_at=NOW() WHERE src=$1
+`
+
+func (r *GraphCDBRepository) TouchLink(ctx context.Context, id uuid.UUID, retrievedAt time.Time) error {
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, touchLinkQuery, id, retrievedAt.UTC())
+		if err != nil {
+			return err
+		}
+		if err = ensureAffected(res); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, touchOutEdgesQuery, id)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("touch link: %w", err)
+	}
+	return nil
+}
+
+var canonicalIDQuery = `
+	SELECT canonical_id FROM links WHERE id=$1
+`
+
+var clearCanonicalIDQuery = `
+	UPDATE links SET canonical_id=NULL WHERE id=$1
+`
+
+var setCanonicalIDQuery = `
+	UPDATE links SET canonical_id=$2 WHERE id=$1
+`
+
+// Duplicates of the merged link follow it to its canonical link, except the
+// canonical link itself if it used to duplicate the merged link.
+var moveDuplicatesQuery = `
+	UPDATE links SET canonical_id=CASE WHEN id=$2 THEN NULL ELSE $2::UUID END WHERE canonical_id=$1
+`
+
+var moveOutEdgesQuery = `
+	UPDATE edges SET src=$2
+	WHERE src=$1 AND dst <> $1 AND dst <> $2 AND
+	    NOT EXISTS (SELECT 1 FROM edges AS e WHERE e.src=$2 AND e.dst=edges.dst AND e.kind=edges.kind)
+`
+
+var moveInEdgesQuery = `
+	UPDATE edges SET dst=$2
+	WHERE dst=$1 AND src <> $1 AND src <> $2 AND
+	    NOT EXISTS (SELECT 1 FROM edges AS e WHERE e.dst=$2 AND e.src=edges.src AND e.kind=edges.kind)
+`
+
+// Edges that could not be moved are dropped.
+var removeLinkEdgesQuery = `
+	DELETE FROM edges WHERE src=$1 OR dst=$1
+`
+
+func (r *GraphCDBRepository) MergeLink(ctx context.Context, id, canonicalID uuid.UUID) error {
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		// Both links must exist; sql.ErrNoRows is reported as not found.
+		var linkCanonicalID, canonicalCanonicalID uuid.NullUUID
+		if err := tx.QueryRowContext(ctx, canonicalIDQuery, id).Scan(&linkCanonicalID); err != nil {
+			return err
+		}
+		if err := tx.QueryRowContext(ctx, canonicalIDQuery, canonicalID).Scan(&canonicalCanonicalID); err != nil {
+			return err
+		}
+		if id == canonicalID {
+			_, err := tx.ExecContext(ctx, clearCanonicalIDQuery, id)
+			return err
+		}
+
+		target := canonicalID
+		if c := canonicalCanonicalID; c.Valid && c.UUID != id {
+			target = c.UUID
+		}
+		for _, query := range []string{moveDuplicatesQuery, setCanonicalIDQuery, moveOutEdgesQuery, moveInEdgesQuery} {
+			if _, err := tx.ExecContext(ctx, query, id, target); err != nil {
+				return err
+			}
+		}
+		_, err := tx.ExecContext(ctx, removeLinkEdgesQuery, id)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.GraphErrNotFound
+		}
+		return fmt.Errorf("merge link: %w", err)
+	}
 	return nil
 }
 
 var findLinkQuery = `
-	SELECT url, retrieved_at FROM links WHERE id=$1
+	SELECT ` + linkColumns + ` FROM links WHERE id=$1
 `
 
-func (r *GraphCDBRepository) FindLink(id uuid.UUID) (*domain.Link, error) {
-	row := r.db.QueryRow(findLinkQuery, id)
-	link := &domain.Link{ID: id}
-	if err := row.Scan(&link.URL, &link.RetrievedAt); err != nil {
+func (r *GraphCDBRepository) FindLink(ctx context.Context, id uuid.UUID) (*domain.Link, error) {
+	var link *domain.Link
+	err := r.retrier.do(ctx, func() error {
+		var err error
+		link, err = scanLink(r.db.QueryRowContext(ctx, findLinkQuery, id))
+		return err
+	})
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("find link: %w", repository.GraphErrNotFound)
 		}
 		return nil, fmt.Errorf("find link: %w", err)
 	}
-	link.RetrievedAt = link.RetrievedAt.UTC()
 	return link, nil
 }
 
-var linksInPartitionQuery = `
-	SELECT id, url, retrieved_at FROM links WHERE id >= $1 AND id <= $2 AND retrieved_at < $3
+var findLinkByURLQuery = `
+	SELECT ` + linkColumns + ` FROM links WHERE url=$1
 `
 
-func (r *GraphCDBRepository) Links(fromId uuid.UUID, toId uuid.UUID, accessedBefore time.Time) (repository.LinkIterator, error) {
-	rows, err := r.db.Query(linksInPartitionQuery, fromId, toId, accessedBefore.UTC())
+func (r *GraphCDBRepository) FindLinkByURL(ctx context.Context, url string) (*domain.Link, error) {
+	var link *domain.Link
+	err := r.retrier.do(ctx, func() error {
+		var err error
+		link, err = scanLink(r.db.QueryRowContext(ctx, findLinkByURLQuery, url))
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("find link by URL: %w", repository.GraphErrNotFound)
+		}
+		return nil, fmt.Errorf("find link by URL: %w", err)
+	}
+	return link, nil
+}
+
+var findLinksQuery = `
+	SELECT ` + linkColumns + ` FROM links WHERE id = ANY($1::UUID[])
+`
+
+func (r *GraphCDBRepository) FindLinks(ctx context.Context, ids []uuid.UUID) ([]*domain.Link, error) {
+	idList := make([]string, len(ids))
+	for i, id := range ids {
+		idList[i] = id.String()
+	}
+
+	var links []*domain.Link
+	err := r.retrier.do(ctx, func() error {
+		rows, err := r.db.QueryContext(ctx, findLinksQuery, pq.Array(idList))
+		if err != nil {
+			return err
+		}
+		links, err = scanLinks(rows)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("links: %w", err)
+		return nil, fmt.Errorf("find links: %w", err)
 	}
-	return newLinkIterator(rows), nil
+
+	found := make(map[uuid.UUID]*domain.Link, len(links))
+	for _, link := range links {
+		found[link.ID] = link
+	}
+
+	// Return the links in the order they were requested.
+	list := make([]*domain.Link, 0, len(found))
+	for _, id := range ids {
+		if link := found[id]; link != nil {
+			lCopy := *link
+			list = append(list, &lCopy)
+		}
+	}
+	return list, nil
+}
+
+var linksInPartitionQuery = `
+	SELECT ` + linkColumns + ` FROM links
+	WHERE
+	    id >= $1 AND
+	    ($2 OR id < $3) AND
+	    retrieved_at < $4 AND
+	    ($5::UUID IS NULL OR id > $5)
+	ORDER BY id
+	LIMIT $6
+`
+
+func (r *GraphCDBRepository) Links(ctx context.Context, ids repository.IDRange, accessedBefore time.Time) (repository.LinkIterator, error) {
+	var lastID uuid.NullUUID
+	return newLinkIterator(ctx, r.pageSize, r.retrier, func(ctx context.Context, limit int) ([]*domain.Link, error) {
+		rows, err := r.db.QueryContext(ctx, linksInPartitionQuery, ids.From, ids.Unbounded(), ids.To, accessedBefore.UTC(), lastID, limit)
+		if err != nil {
+			return nil, fmt.Errorf("links: %w", err)
+		}
+		links, err := scanLinks(rows)
+		if err != nil {
+			return nil, fmt.Errorf("links: %w", err)
+		}
+		if n := len(links); n != 0 {
+			lastID = uuid.NullUUID{UUID: links[n-1].ID, Valid: true}
+		}
+		return links, nil
+	}), nil
+}
+
+// DSNFromEnv builds a connection string from the CDB_USER, CDB_HOST,
+// CDB_PORT and CDB_DATABASE environment variables.
+func DSNFromEnv() string {
+	return fmt.Sprintf("postgres://%s@%s:%s/%s?sslmode=disable",
+		os.Getenv("CDB_USER"), os.Getenv("CDB_HOST"), os.Getenv("CDB_PORT"), os.Getenv("CDB_DATABASE"))
+}
+
+// PostgresDSNFromEnv builds a PostgreSQL connection string from the PG_USER,
+// PG_PASSWORD, PG_HOST, PG_PORT and PG_DATABASE environment variables.
+func PostgresDSNFromEnv() string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
+		os.Getenv("PG_USER"), os.Getenv("PG_PASSWORD"), os.Getenv("PG_HOST"), os.Getenv("PG_PORT"), os.Getenv("PG_DATABASE"))
 }
@@ -1,9 +1,11 @@
 package cdb
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"github.com/bruceneco/links-r-us/internal/adapters/graph/graphtest"
+	"github.com/bruceneco/links-r-us/internal/adapters/repository/migrations"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/suite"
@@ -12,13 +14,23 @@ import (
 )
 
 func Test(t *testing.T) {
-	suite.Run(t, new(GraphCDBRepositoryTestSuite))
+	suite.Run(t, &GraphCDBRepositoryTestSuite{dsn: DSNFromEnv, dialect: migrations.CockroachDB})
+}
+
+// TestPostgres runs the suite against PostgreSQL. It is skipped unless
+// PG_HOST is set, e.g. by the test compose file.
+func TestPostgres(t *testing.T) {
+	suite.Run(t, &GraphCDBRepositoryTestSuite{dsn: PostgresDSNFromEnv, dialect: migrations.Postgres, requiredEnv: "PG_HOST"})
 }
 
 type GraphCDBRepositoryTestSuite struct {
 	suite.Suite
 	base graphtest.SuiteBase
 	db   *sql.DB
+
+	dsn         func() string
+	dialect     migrations.Dialect
+	requiredEnv string
 }
 
 func (s *GraphCDBRepositoryTestSuite) SetupSuite() {
@@ -26,11 +38,10 @@ func (s *GraphCDBRepositoryTestSuite) SetupSuite() {
 	if err != nil {
 		s.FailNow("cant load env")
 	}
-	host := os.Getenv("CDB_HOST")
-	port := os.Getenv("CDB_PORT")
-	user := os.Getenv("CDB_USER")
-	database := os.Getenv("CDB_DATABASE")
-	dsn := fmt.Sprintf("postgres://%s@%s:%s/%s?sslmode=disable", user, host, port, database)
+	if s.requiredEnv != "" && os.Getenv(s.requiredEnv) == "" {
+		s.T().Skipf("%s is not set", s.requiredEnv)
+	}
+	dsn := s.dsn()
 	fmt.Println(dsn)
 	db, err := sql.Open("postgres", dsn)
 	if err != nil {
@@ -40,7 +51,16 @@ func (s *GraphCDBRepositoryTestSuite) SetupSuite() {
 	if err != nil {
 		s.FailNowf("", "failed to ping database %w", err)
 	}
-	r := NewGraphCDBRepository(db)
+	m, err := migrations.New(db, s.dialect)
+	if err == nil {
+		err = m.Up(context.TODO())
+	}
+	if err != nil {
+		s.FailNowf("", "failed to migrate database %v", err)
+	}
+	// Use a small page size so that the suite exercises iterators spanning
+	// multiple pages.
+	r := NewGraphCDBRepository(db, WithPageSize(10))
 	s.db = db
 	s.base.SetGraph(r)
 }
@@ -63,9 +83,30 @@ func (s *GraphCDBRepositoryTestSuite) TearDownSuite() {
 func (s *GraphCDBRepositoryTestSuite) TestUpsertLink() {
 	s.base.TestUpsertLink(s.T())
 }
+func (s *GraphCDBRepositoryTestSuite) TestUpsertLinks() {
+	s.base.TestUpsertLinks(s.T())
+}
+func (s *GraphCDBRepositoryTestSuite) TestLinkFetchMetadata() {
+	s.base.TestLinkFetchMetadata(s.T())
+}
+func (s *GraphCDBRepositoryTestSuite) TestTouchLink() {
+	s.base.TestTouchLink(s.T())
+}
+func (s *GraphCDBRepositoryTestSuite) TestMergeLink() {
+	s.base.TestMergeLink(s.T())
+}
 func (s *GraphCDBRepositoryTestSuite) TestFindLink() {
 	s.base.TestFindLink(s.T())
 }
+func (s *GraphCDBRepositoryTestSuite) TestFindLinkByURL() {
+	s.base.TestFindLinkByURL(s.T())
+}
+func (s *GraphCDBRepositoryTestSuite) TestFindLinks() {
+	s.base.TestFindLinks(s.T())
+}
+func (s *GraphCDBRepositoryTestSuite) TestRemoveLink() {
+	s.base.TestRemoveLink(s.T())
+}
 func (s *GraphCDBRepositoryTestSuite) TestConcurrentLinkIterators() {
 	s.base.TestConcurrentLinkIterators(s.T())
 }
@@ -75,6 +116,36 @@ func (s *GraphCDBRepositoryTestSuite) TestLinkIteratorTimeFilter() {
 func (s *GraphCDBRepositoryTestSuite) TestPartitionedLinkIterators() {
 	s.base.TestPartitionedLinkIterators(s.T())
 }
+func (s *GraphCDBRepositoryTestSuite) TestLinkIteratorContextCancellation() {
+	s.base.TestLinkIteratorContextCancellation(s.T())
+}
+func (s *GraphCDBRepositoryTestSuite) TestLinkRangeBoundaries() {
+	s.base.TestLinkRangeBoundaries(s.T())
+}
 func (s *GraphCDBRepositoryTestSuite) TestUpsertEdge() {
 	s.base.TestUpsertEdge(s.T())
 }
+func (s *GraphCDBRepositoryTestSuite) TestUpsertEdges() {
+	s.base.TestUpsertEdges(s.T())
+}
+func (s *GraphCDBRepositoryTestSuite) TestEdgeAnchor() {
+	s.base.TestEdgeAnchor(s.T())
+}
+func (s *GraphCDBRepositoryTestSuite) TestRedirectEdge() {
+	s.base.TestRedirectEdge(s.T())
+}
+func (s *GraphCDBRepositoryTestSuite) TestRemoveEdge() {
+	s.base.TestRemoveEdge(s.T())
+}
+func (s *GraphCDBRepositoryTestSuite) TestInOutEdges() {
+	s.base.TestInOutEdges(s.T())
+}
+func (s *GraphCDBRepositoryTestSuite) TestStats() {
+	s.base.TestStats(s.T())
+}
+func (s *GraphCDBRepositoryTestSuite) TestEdgeIteratorContextCancellation() {
+	s.base.TestEdgeIteratorContextCancellation(s.T())
+}
+func (s *GraphCDBRepositoryTestSuite) TestEdgeRangeBoundaries() {
+	s.base.TestEdgeRangeBoundaries(s.T())
+}
@@ -1,36 +1,25 @@
 package cdb
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
 	"github.com/bruceneco/links-r-us/internal/application/core/domain"
 	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
 )
 
+// linkIterator is a repository.LinkIterator that fetches links one page at
+// a time.
 type linkIterator struct {
-	rows        *sql.Rows
-	lastErr     error
-	latchedLink *domain.Link
+	pager[*domain.Link]
 }
 
-func newLinkIterator(rows *sql.Rows) repository.LinkIterator {
-	return &linkIterator{rows: rows}
+func newLinkIterator(ctx context.Context, pageSize int, retrier *retrier, read pageReader[*domain.Link]) repository.LinkIterator {
+	return &linkIterator{pager: newPager(ctx, pageSize, retrier, read)}
 }
 
 func (i *linkIterator) Next() bool {
-	if i.lastErr != nil || !i.rows.Next() {
-		return false
-	}
-
-	link := new(domain.Link)
-	i.lastErr = i.rows.Scan(&link.ID, &link.URL, &link.RetrievedAt)
-	if i.lastErr != nil {
-		return false
-	}
-	link.RetrievedAt = link.RetrievedAt.UTC()
-
-	i.latchedLink = link
-	return true
+	return i.next()
 }
 
 func (i *linkIterator) Error() error {
@@ -38,13 +27,46 @@ func (i *linkIterator) Error() error {
 }
 
 func (i *linkIterator) Close() error {
-	err := i.rows.Close()
-	if err != nil {
-		return fmt.Errorf("link iterator: %w", err)
-	}
+	i.close()
 	return nil
 }
 
 func (i *linkIterator) Link() *domain.Link {
-	return i.latchedLink
+	return i.latched
+}
+
+// scanLinks reads every link returned by rows and closes them.
+func scanLinks(rows *sql.Rows) ([]*domain.Link, error) {
+	defer func() { _ = rows.Close() }()
+
+	var links []*domain.Link
+	for rows.Next() {
+		link, err := scanLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanLink reads a link from a row holding the linkColumns.
+func scanLink(row rowScanner) (*domain.Link, error) {
+	var canonicalID uuid.NullUUID
+	link := new(domain.Link)
+	err := row.Scan(
+		&link.ID, &link.URL, &link.RetrievedAt, &link.StatusCode, &link.ContentType, &link.ContentLength,
+		&link.ETag, &link.LastModified, &link.FailureCount, &link.LastError, &canonicalID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	link.RetrievedAt = link.RetrievedAt.UTC()
+	link.CanonicalID = canonicalID.UUID
+	return link, nil
 }
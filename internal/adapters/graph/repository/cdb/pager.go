@@ -0,0 +1,76 @@
+package cdb
+
+import "context"
+
+// defaultPageSize is the number of rows fetched by each iterator query
+// unless overridden with WithPageSize.
+const defaultPageSize = 1000
+
+// pageReader fetches up to limit rows following the last row returned by a
+// previous successful call. Implementations must only advance their keyset
+// cursor once a page has been read completely, so that a failed call can be
+// retried.
+type pageReader[T any] func(ctx context.Context, limit int) ([]T, error)
+
+// pager implements keyset pagination on top of a pageReader. Rows are
+// buffered one page at a time, so no database cursor is held open between
+// calls to next.
+type pager[T any] struct {
+	ctx      context.Context
+	read     pageReader[T]
+	pageSize int
+	retrier  *retrier
+
+	buf     []T
+	done    bool
+	lastErr error
+	latched T
+}
+
+func newPager[T any](ctx context.Context, pageSize int, retrier *retrier, read pageReader[T]) pager[T] {
+	return pager[T]{ctx: ctx, read: read, pageSize: pageSize, retrier: retrier}
+}
+
+// next latches the following row, fetching a new page if needed.
+func (p *pager[T]) next() bool {
+	if p.lastErr != nil {
+		return false
+	}
+	if p.lastErr = p.ctx.Err(); p.lastErr != nil {
+		return false
+	}
+
+	if len(p.buf) == 0 {
+		if p.done {
+			return false
+		}
+		if p.buf, p.lastErr = p.readPage(); p.lastErr != nil {
+			return false
+		}
+		// A short page means the keyset range has been exhausted.
+		p.done = len(p.buf) < p.pageSize
+		if len(p.buf) == 0 {
+			return false
+		}
+	}
+
+	p.latched, p.buf = p.buf[0], p.buf[1:]
+	return true
+}
+
+// readPage fetches the next page, resuming from the last row seen if the
+// query has to be retried.
+func (p *pager[T]) readPage() ([]T, error) {
+	var page []T
+	err := p.retrier.do(p.ctx, func() error {
+		var err error
+		page, err = p.read(p.ctx, p.pageSize)
+		return err
+	})
+	return page, err
+}
+
+func (p *pager[T]) close() {
+	p.buf = nil
+	p.done = true
+}
@@ -0,0 +1,106 @@
+package cdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePages serves the integers [0, n) as a keyset-paginated table.
+type fakePages struct {
+	n        int
+	last     int
+	calls    int
+	failures []error
+}
+
+func (f *fakePages) read(_ context.Context, limit int) ([]int, error) {
+	f.calls++
+	if len(f.failures) != 0 {
+		err := f.failures[0]
+		f.failures = f.failures[1:]
+		return nil, err
+	}
+
+	var page []int
+	for v := f.last; v < f.n && len(page) < limit; v++ {
+		page = append(page, v)
+	}
+	f.last += len(page)
+	return page, nil
+}
+
+func drain(p *pager[int]) []int {
+	var got []int
+	for p.next() {
+		got = append(got, p.latched)
+	}
+	return got
+}
+
+func TestPagerSpansPages(t *testing.T) {
+	for _, n := range []int{0, 1, 9, 10, 11, 25} {
+		src := &fakePages{n: n}
+		p := newPager(context.TODO(), 10, testRetrier(), src.read)
+
+		got := drain(&p)
+		assert.NoError(t, p.lastErr)
+		assert.Len(t, got, n)
+		for i, v := range got {
+			assert.Equal(t, i, v)
+		}
+		// Every full page requires a follow-up query to detect the end of
+		// the range.
+		assert.Equal(t, n/10+1, src.calls, "unexpected number of queries for %d rows", n)
+	}
+}
+
+func TestPagerRetriesTransientErrors(t *testing.T) {
+	src := &fakePages{
+		n:        15,
+		failures: []error{driver.ErrBadConn, &pq.Error{Code: "40001"}},
+	}
+	p := newPager(context.TODO(), 10, testRetrier(), src.read)
+
+	got := drain(&p)
+	assert.NoError(t, p.lastErr)
+	assert.Len(t, got, 15)
+}
+
+func TestPagerReportsPermanentErrors(t *testing.T) {
+	permanent := &pq.Error{Code: "42601"} // syntax error
+	src := &fakePages{n: 15, failures: []error{permanent}}
+	p := newPager(context.TODO(), 10, testRetrier(), src.read)
+
+	assert.Empty(t, drain(&p))
+	assert.True(t, errors.Is(p.lastErr, permanent))
+	assert.Equal(t, 1, src.calls, "expected permanent error not to be retried")
+}
+
+func TestPagerGivesUpAfterMaxAttempts(t *testing.T) {
+	rt := testRetrier()
+	src := &fakePages{n: 15}
+	for i := 0; i < rt.maxAttempts; i++ {
+		src.failures = append(src.failures, driver.ErrBadConn)
+	}
+	p := newPager(context.TODO(), 10, rt, src.read)
+
+	assert.Empty(t, drain(&p))
+	assert.True(t, errors.Is(p.lastErr, driver.ErrBadConn))
+	assert.Equal(t, rt.maxAttempts, src.calls)
+}
+
+func TestPagerContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	src := &fakePages{n: 15}
+	p := newPager(ctx, 10, testRetrier(), src.read)
+
+	assert.True(t, p.next())
+	cancel()
+	assert.False(t, p.next())
+	assert.True(t, errors.Is(p.lastErr, context.Canceled))
+}
@@ -0,0 +1,120 @@
+package cdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	defaultMaxAttempts = 5
+	defaultBaseDelay   = 25 * time.Millisecond
+	defaultMaxDelay    = time.Second
+)
+
+// RetryStats reports how often a GraphCDBRepository retried operations that
+// failed with a retryable error.
+type RetryStats struct {
+	// Retries is the number of times an operation was attempted again.
+	Retries uint64
+	// Exhausted is the number of operations that still failed after
+	// using up all of their attempts.
+	Exhausted uint64
+}
+
+// retrier re-runs idempotent operations that fail with a retryable error,
+// waiting for an exponentially growing, fully jittered delay between
+// attempts.
+type retrier struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+
+	retries   atomic.Uint64
+	exhausted atomic.Uint64
+}
+
+func newRetrier() *retrier {
+	return &retrier{
+		maxAttempts: defaultMaxAttempts,
+		baseDelay:   defaultBaseDelay,
+		maxDelay:    defaultMaxDelay,
+	}
+}
+
+// do invokes fn until it succeeds, fails with an error that is not
+// retryable, runs out of attempts or ctx is cancelled. fn must be safe to
+// run more than once.
+func (rt *retrier) do(ctx context.Context, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !isRetryableError(err) {
+			return err
+		}
+		if attempt >= rt.maxAttempts {
+			rt.exhausted.Add(1)
+			return err
+		}
+
+		rt.retries.Add(1)
+		timer := time.NewTimer(rt.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns a random delay between zero and the exponential backoff
+// ceiling for the specified attempt.
+func (rt *retrier) backoff(attempt int) time.Duration {
+	ceiling := rt.maxDelay
+	if shift := attempt - 1; shift < 32 && rt.baseDelay<<shift < ceiling {
+		ceiling = rt.baseDelay << shift
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling) + 1
+}
+
+func (rt *retrier) stats() RetryStats {
+	return RetryStats{
+		Retries:   rt.retries.Load(),
+		Exhausted: rt.exhausted.Load(),
+	}
+}
+
+// isRetryableError returns true if err was caused by a condition that may go
+// away when the operation is attempted again, such as a transaction
+// conflict reported by CockroachDB or a dropped connection. Cancelled or
+// expired contexts and other network errors, like timeouts, are never
+// retried.
+func isRetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code.Name() {
+		case "serialization_failure", "deadlock_detected", "admin_shutdown":
+			return true
+		}
+		return pqErr.Code.Class().Name() == "connection_exception"
+	}
+	return false
+}
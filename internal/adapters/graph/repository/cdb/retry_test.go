@@ -0,0 +1,103 @@
+package cdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRetrier returns a retrier with delays short enough for unit tests.
+func testRetrier() *retrier {
+	rt := newRetrier()
+	rt.maxAttempts = 3
+	rt.baseDelay = time.Millisecond
+	rt.maxDelay = time.Millisecond
+	return rt
+}
+
+func TestIsRetryableError(t *testing.T) {
+	specs := []struct {
+		err  error
+		want bool
+	}{
+		{err: &pq.Error{Code: "40001"}, want: true},
+		{err: fmt.Errorf("upsert link: %w", &pq.Error{Code: "40001"}), want: true},
+		{err: &pq.Error{Code: "40P01"}, want: true},
+		{err: &pq.Error{Code: "57P01"}, want: true},
+		{err: &pq.Error{Code: "08006"}, want: true},
+		{err: driver.ErrBadConn, want: true},
+		{err: &pq.Error{Code: "23503"}, want: false},
+		{err: &pq.Error{Code: "42601"}, want: false},
+		{err: sql.ErrNoRows, want: false},
+		{err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, want: true},
+		{err: fmt.Errorf("find link: %w", &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}), want: true},
+		{err: &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}, want: false},
+		{err: &net.DNSError{Err: "no such host", Name: "db", IsNotFound: true}, want: false},
+		{err: context.Canceled, want: false},
+		{err: fmt.Errorf("upsert link: %w", context.DeadlineExceeded), want: false},
+		{err: errors.Join(context.Canceled, driver.ErrBadConn), want: false},
+	}
+	for _, spec := range specs {
+		assert.Equal(t, spec.want, isRetryableError(spec.err), "unexpected result for %v", spec.err)
+	}
+}
+
+func TestRetrierRetriesUntilSuccess(t *testing.T) {
+	rt := testRetrier()
+	var calls int
+	err := rt.do(context.TODO(), func() error {
+		if calls++; calls < 3 {
+			return &pq.Error{Code: "40001"}
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, RetryStats{Retries: 2}, rt.stats())
+}
+
+func TestRetrierCountsExhaustedOperations(t *testing.T) {
+	rt := testRetrier()
+	serializationErr := &pq.Error{Code: "40001"}
+	err := rt.do(context.TODO(), func() error { return serializationErr })
+
+	assert.True(t, errors.Is(err, serializationErr))
+	assert.Equal(t, RetryStats{Retries: 2, Exhausted: 1}, rt.stats())
+}
+
+func TestRetrierStopsOnPermanentError(t *testing.T) {
+	rt := testRetrier()
+	var calls int
+	err := rt.do(context.TODO(), func() error {
+		calls++
+		return sql.ErrNoRows
+	})
+
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, RetryStats{}, rt.stats())
+}
+
+func TestRetrierBackoff(t *testing.T) {
+	rt := newRetrier()
+	for attempt := 1; attempt <= 10; attempt++ {
+		ceiling := min(rt.baseDelay<<(attempt-1), rt.maxDelay)
+		delay := rt.backoff(attempt)
+		assert.True(t, delay > 0 && delay <= ceiling, "delay %s for attempt %d exceeds %s", delay, attempt, ceiling)
+	}
+
+	// The delay stays capped once the shift would overflow.
+	delay := rt.backoff(1000)
+	assert.True(t, delay > 0 && delay <= rt.maxDelay)
+}
@@ -0,0 +1,110 @@
+package cdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/bruceneco/links-r-us/internal/partition"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"time"
+)
+
+var linkStatsQuery = `
+	SELECT
+	    count(*),
+	    count(*) FILTER (WHERE retrieved_at IS NULL OR retrieved_at = $1),
+	    count(*) FILTER (WHERE retrieved_at >= $2)
+	FROM links
+`
+
+var edgesInRangeCountQuery = `
+	SELECT count(*) FROM edges WHERE src >= $1 AND ($2 OR src < $3)
+`
+
+var inDegreeHistogramQuery = `
+	SELECT degree, count(*) FROM (
+	    SELECT links.id, count(edges.id) AS degree
+	    FROM links LEFT JOIN edges ON edges.dst = links.id
+	    GROUP BY links.id
+	) AS degrees GROUP BY degree
+`
+
+var outDegreeHistogramQuery = `
+	SELECT degree, count(*) FROM (
+	    SELECT links.id, count(edges.id) AS degree
+	    FROM links LEFT JOIN edges ON edges.src = links.id
+	    GROUP BY links.id
+	) AS degrees GROUP BY degree
+`
+
+func (r *GraphCDBRepository) Stats(ctx context.Context, opts repository.GraphStatsOptions) (*repository.GraphStats, error) {
+	numPartitions := max(opts.NumPartitions, 1)
+	pr, err := partition.NewFullRange(numPartitions)
+	if err != nil {
+		return nil, fmt.Errorf("stats: %w", err)
+	}
+
+	// All counts are read from the same snapshot so that they agree with
+	// each other. PostgreSQL only keeps a snapshot for the whole transaction
+	// from the repeatable read level up.
+	retrievedSince := time.Now().Add(-opts.RetrievedWithin).UTC()
+	txOpts := &sql.TxOptions{ReadOnly: true, Isolation: sql.LevelRepeatableRead}
+	var stats *repository.GraphStats
+	err = r.inTxWithOptions(ctx, txOpts, func(tx *sql.Tx) error {
+		var err error
+		stats, err = collectStats(ctx, tx, pr, retrievedSince)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("stats: %w", err)
+	}
+	return stats, nil
+}
+
+func collectStats(ctx context.Context, tx *sql.Tx, pr partition.Range, retrievedSince time.Time) (*repository.GraphStats, error) {
+	stats := &repository.GraphStats{EdgesPerPartition: make([]int, pr.NumPartitions())}
+	row := tx.QueryRowContext(ctx, linkStatsQuery, time.Time{}, retrievedSince)
+	if err := row.Scan(&stats.Links, &stats.UnretrievedLinks, &stats.RecentlyRetrievedLinks); err != nil {
+		return nil, err
+	}
+
+	for p := range stats.EdgesPerPartition {
+		from, to, err := pr.PartitionExtents(p)
+		if err != nil {
+			return nil, err
+		}
+		ids := repository.NewIDRange(from, to)
+		row = tx.QueryRowContext(ctx, edgesInRangeCountQuery, ids.From, ids.Unbounded(), ids.To)
+		if err = row.Scan(&stats.EdgesPerPartition[p]); err != nil {
+			return nil, err
+		}
+		stats.Edges += stats.EdgesPerPartition[p]
+	}
+
+	var err error
+	if stats.InDegreeHistogram, err = degreeHistogram(ctx, tx, inDegreeHistogramQuery); err != nil {
+		return nil, err
+	}
+	if stats.OutDegreeHistogram, err = degreeHistogram(ctx, tx, outDegreeHistogramQuery); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func degreeHistogram(ctx context.Context, tx *sql.Tx, query string) (map[int]int, error) {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	histogram := make(map[int]int)
+	for rows.Next() {
+		var degree, count int
+		if err = rows.Scan(&degree, &count); err != nil {
+			return nil, err
+		}
+		histogram[degree] = count
+	}
+	return histogram, rows.Err()
+}
@@ -1,18 +1,26 @@
 package memory
 
-import "github.com/bruceneco/links-r-us/internal/application/core/domain"
+import (
+	"context"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+)
 
 // linkIterator is a ports.LinkIterator implementation for the in-memory graph.
 type linkIterator struct {
-	s *InMemoryGraph
+	ctx context.Context
+	s   *InMemoryGraph
 
 	links    []*domain.Link
 	curIndex int
+	lastErr  error
 }
 
 // Next implements ports.LinkIterator.
 func (i *linkIterator) Next() bool {
-	if i.curIndex >= len(i.links) {
+	if i.lastErr != nil || i.curIndex >= len(i.links) {
+		return false
+	}
+	if i.lastErr = i.ctx.Err(); i.lastErr != nil {
 		return false
 	}
 	i.curIndex++
@@ -21,7 +29,7 @@ func (i *linkIterator) Next() bool {
 
 // Error implements ports.LinkIterator.
 func (i *linkIterator) Error() error {
-	return nil
+	return i.lastErr
 }
 
 // Close implements ports.LinkIterator.
@@ -41,15 +49,20 @@ func (i *linkIterator) Link() *domain.Link {
 
 // edgeIterator is a ports.EdgeIterator implementation for the in-memory graph.
 type edgeIterator struct {
-	s *InMemoryGraph
+	ctx context.Context
+	s   *InMemoryGraph
 
 	edges    []*domain.Edge
 	curIndex int
+	lastErr  error
 }
 
 // Next implements ports.EdgeIterator.
 func (i *edgeIterator) Next() bool {
-	if i.curIndex >= len(i.edges) {
+	if i.lastErr != nil || i.curIndex >= len(i.edges) {
+		return false
+	}
+	if i.lastErr = i.ctx.Err(); i.lastErr != nil {
 		return false
 	}
 	i.curIndex++
@@ -58,7 +71,7 @@ func (i *edgeIterator) Next() bool {
 
 // Error implements graph.EdgeIterator.
 func (i *edgeIterator) Error() error {
-	return nil
+	return i.lastErr
 }
 
 // Close implements graph.EdgeIterator.
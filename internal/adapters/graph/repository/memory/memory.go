@@ -1,6 +1,7 @@
 package memory
 
 import (
+	"context"
 	"fmt"
 	"github.com/bruceneco/links-r-us/internal/application/core/domain"
 	"github.com/bruceneco/links-r-us/internal/ports/repository"
@@ -10,9 +11,20 @@ import (
 	"github.com/google/uuid"
 )
 
-// edgeList contains the slice of edge UUIDs that originate from a link in the graph.
+// edgeList contains the slice of edge UUIDs that originate from or point to a link in the graph.
 type edgeList []uuid.UUID
 
+// without returns a copy of the list that excludes the specified edge ID.
+func (l edgeList) without(id uuid.UUID) edgeList {
+	var newEdgeList edgeList
+	for _, edgeID := range l {
+		if edgeID != id {
+			newEdgeList = append(newEdgeList, edgeID)
+		}
+	}
+	return newEdgeList
+}
+
 // InMemoryGraph implements an in-memory link graph that can be concurrently
 // accessed by multiple clients.
 type InMemoryGraph struct {
@@ -22,53 +34,130 @@ type InMemoryGraph struct {
 	edges map[uuid.UUID]*domain.Edge
 
 	linkURLIndex map[string]*domain.Link
-	linkEdgeMap  map[uuid.UUID]edgeList
+	// linkEdgeMap indexes the outgoing edges of each link.
+	linkEdgeMap map[uuid.UUID]edgeList
+	// linkInEdgeMap indexes the incoming edges of each link.
+	linkInEdgeMap map[uuid.UUID]edgeList
+	// duplicates indexes the links that duplicate each canonical link.
+	duplicates map[uuid.UUID]map[uuid.UUID]struct{}
+
+	// snapshotPath and wal are only set for graphs created with
+	// OpenInMemoryGraph. walSeq is the sequence number of the last WAL
+	// record reflected in the graph contents.
+	snapshotMu   sync.Mutex
+	snapshotPath string
+	wal          *wal
+	walSeq       uint64
 }
 
 // NewInMemoryGraph creates a new in-memory link graph.
 func NewInMemoryGraph() repository.GraphRepository {
-	return &InMemoryGraph{
-		links:        make(map[uuid.UUID]*domain.Link),
-		edges:        make(map[uuid.UUID]*domain.Edge),
-		linkURLIndex: make(map[string]*domain.Link),
-		linkEdgeMap:  make(map[uuid.UUID]edgeList),
-	}
+	return newInMemoryGraph()
+}
+
+func newInMemoryGraph() *InMemoryGraph {
+	s := new(InMemoryGraph)
+	s.reset()
+	return s
+}
+
+// reset discards the graph contents. The caller must hold the write lock.
+func (s *InMemoryGraph) reset() {
+	s.links = make(map[uuid.UUID]*domain.Link)
+	s.edges = make(map[uuid.UUID]*domain.Edge)
+	s.linkURLIndex = make(map[string]*domain.Link)
+	s.linkEdgeMap = make(map[uuid.UUID]edgeList)
+	s.linkInEdgeMap = make(map[uuid.UUID]edgeList)
+	s.duplicates = make(map[uuid.UUID]map[uuid.UUID]struct{})
 }
 
 // UpsertLink creates a new link or updates an existing link.
-func (s *InMemoryGraph) UpsertLink(link *domain.Link) error {
+func (s *InMemoryGraph) UpsertLink(_ context.Context, link *domain.Link) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	rec := s.upsertLinkRecord(link, nil)
+	if err := s.appendWAL(rec); err != nil {
+		return fmt.Errorf("upsert link: %w", err)
+	}
+	s.putLink(rec.Link)
+	*link = *rec.Link
+	return nil
+}
+
+// UpsertLinks creates or updates a batch of links while holding the write
+// lock only once.
+func (s *InMemoryGraph) UpsertLinks(_ context.Context, links []*domain.Link) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		recs    = make([]walRecord, 0, len(links))
+		pending = make(map[string]*domain.Link, len(links))
+	)
+	for _, link := range links {
+		rec := s.upsertLinkRecord(link, pending)
+		pending[rec.Link.URL] = rec.Link
+		recs = append(recs, rec)
+	}
+	if err := s.appendWAL(recs...); err != nil {
+		return fmt.Errorf("upsert links: %w", err)
+	}
+	for i, rec := range recs {
+		s.putLink(rec.Link)
+		*links[i] = *rec.Link
+	}
+	return nil
+}
+
+// upsertLinkRecord returns the write-ahead log record that creates or updates
+// link without changing the graph. Links in pending, keyed by URL, are
+// records of the same batch that have not been applied yet. The caller must
+// hold the write lock.
+func (s *InMemoryGraph) upsertLinkRecord(link *domain.Link, pending map[string]*domain.Link) walRecord {
 	// Check if a link with the same URL already exists. If so, convert
-	// this into an update and point the link ID to the existing link.
-	if existing := s.linkURLIndex[link.URL]; existing != nil {
-		link.ID = existing.ID
-		origTs := existing.RetrievedAt
-		*existing = *link
-		if origTs.After(existing.RetrievedAt) {
-			existing.RetrievedAt = origTs
+	// this into an update and point the link ID to the existing link. The
+	// stored link is only replaced by a more recent retrieval.
+	existing := pending[link.URL]
+	if existing == nil {
+		existing = s.linkURLIndex[link.URL]
+	}
+
+	lCopy := new(domain.Link)
+	if existing != nil {
+		*lCopy = *existing
+		if link.RetrievedAt.After(existing.RetrievedAt) {
+			*lCopy = *link
+			lCopy.ID = existing.ID
+			lCopy.CanonicalID = existing.CanonicalID
 		}
-		return nil
+		return walRecord{Op: walOpUpsertLink, Link: lCopy}
 	}
 
-	// Assign new ID and insert link
+	// Assign new ID
+	*lCopy = *link
 	for {
-		link.ID = uuid.New()
-		if s.links[link.ID] == nil {
+		lCopy.ID = uuid.New()
+		if s.links[lCopy.ID] == nil && !pendingLinkID(pending, lCopy.ID) {
 			break
 		}
 	}
+	lCopy.CanonicalID = uuid.Nil
+	return walRecord{Op: walOpUpsertLink, Link: lCopy}
+}
 
-	lCopy := new(domain.Link)
-	*lCopy = *link
-	s.linkURLIndex[lCopy.URL] = lCopy
-	s.links[lCopy.ID] = lCopy
-	return nil
+// pendingLinkID returns true if one of the pending links has the given ID.
+func pendingLinkID(pending map[string]*domain.Link, id uuid.UUID) bool {
+	for _, link := range pending {
+		if link.ID == id {
+			return true
+		}
+	}
+	return false
 }
 
 // FindLink looks up a link by its ID.
-func (s *InMemoryGraph) FindLink(id uuid.UUID) (*domain.Link, error) {
+func (s *InMemoryGraph) FindLink(_ context.Context, id uuid.UUID) (*domain.Link, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -82,76 +171,376 @@ func (s *InMemoryGraph) FindLink(id uuid.UUID) (*domain.Link, error) {
 	return lCopy, nil
 }
 
-// Links returns an iterator for the set of links whose IDs belong to the
-// [fromID, toID) range and were retrieved before the provided timestamp.
-func (s *InMemoryGraph) Links(fromID, toID uuid.UUID, retrievedBefore time.Time) (repository.LinkIterator, error) {
-	from, to := fromID.String(), toID.String()
+// FindLinkByURL looks up a link by its URL.
+func (s *InMemoryGraph) FindLinkByURL(_ context.Context, url string) (*domain.Link, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
+	link := s.linkURLIndex[url]
+	if link == nil {
+		return nil, fmt.Errorf("find link by URL: %w", repository.GraphErrNotFound)
+	}
+
+	lCopy := new(domain.Link)
+	*lCopy = *link
+	return lCopy, nil
+}
+
+// FindLinks looks up the links with the specified IDs. The returned links
+// follow the order of ids; unknown IDs are skipped.
+func (s *InMemoryGraph) FindLinks(_ context.Context, ids []uuid.UUID) ([]*domain.Link, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*domain.Link, 0, len(ids))
+	for _, id := range ids {
+		if link := s.links[id]; link != nil {
+			lCopy := new(domain.Link)
+			*lCopy = *link
+			list = append(list, lCopy)
+		}
+	}
+	return list, nil
+}
+
+// RemoveLink removes the link with the specified ID along with any edge
+// that originates from or points to it.
+func (s *InMemoryGraph) RemoveLink(_ context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.links[id] == nil {
+		return fmt.Errorf("remove link: %w", repository.GraphErrNotFound)
+	}
+
+	if err := s.appendWAL(walRecord{Op: walOpRemoveLink, ID: id}); err != nil {
+		return fmt.Errorf("remove link: %w", err)
+	}
+	s.removeLink(id)
+	return nil
+}
+
+// removeLink deletes an existing link and its edges. The caller must hold
+// the write lock.
+func (s *InMemoryGraph) removeLink(id uuid.UUID) {
+	link := s.links[id]
+	if link == nil {
+		return
+	}
+
+	// Drop outgoing and incoming edges together with the link's edge lists.
+	for _, edgeID := range s.linkEdgeMap[id] {
+		s.removeEdge(edgeID)
+	}
+	for _, edgeID := range s.linkInEdgeMap[id] {
+		s.removeEdge(edgeID)
+	}
+	delete(s.linkEdgeMap, id)
+	delete(s.linkInEdgeMap, id)
+
+	// Links that duplicate the removed link become canonical again.
+	for dupID := range s.duplicates[id] {
+		s.links[dupID].CanonicalID = uuid.Nil
+	}
+	delete(s.duplicates, id)
+	s.setCanonical(link, uuid.Nil)
+
+	delete(s.linkURLIndex, link.URL)
+	delete(s.links, id)
+}
+
+// TouchLink records that the link with the specified ID was fetched at
+// retrievedAt and found unchanged.
+func (s *InMemoryGraph) TouchLink(_ context.Context, id uuid.UUID, retrievedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.links[id] == nil {
+		return fmt.Errorf("touch link: %w", repository.GraphErrNotFound)
+	}
+
+	rec := walRecord{Op: walOpTouchLink, ID: id, At: retrievedAt, UpdatedAt: time.Now()}
+	if err := s.appendWAL(rec); err != nil {
+		return fmt.Errorf("touch link: %w", err)
+	}
+	s.touchLink(id, rec.At, rec.UpdatedAt)
+	return nil
+}
+
+// touchLink implements TouchLink for an existing link. The caller must hold
+// the write lock.
+func (s *InMemoryGraph) touchLink(id uuid.UUID, retrievedAt, updatedAt time.Time) {
+	if link := s.links[id]; retrievedAt.After(link.RetrievedAt) {
+		*link = *link.NotModified(retrievedAt)
+	}
+	for _, edgeID := range s.linkEdgeMap[id] {
+		s.edges[edgeID].UpdatedAt = updatedAt
+	}
+}
+
+// MergeLink marks the link with the specified ID as a duplicate of its
+// canonical link and moves its edges to the canonical link.
+func (s *InMemoryGraph) MergeLink(_ context.Context, id, canonicalID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.links[id] == nil || s.links[canonicalID] == nil {
+		return fmt.Errorf("merge link: %w", repository.GraphErrNotFound)
+	}
+
+	if err := s.appendWAL(walRecord{Op: walOpMergeLink, ID: id, Target: canonicalID}); err != nil {
+		return fmt.Errorf("merge link: %w", err)
+	}
+	s.mergeLink(id, canonicalID)
+	return nil
+}
+
+// mergeLink implements MergeLink for two existing links. Moved edges keep
+// their IDs so that replaying the operation from the write-ahead log yields
+// the same graph. The caller must hold the write lock.
+func (s *InMemoryGraph) mergeLink(id, canonicalID uuid.UUID) {
+	link := s.links[id]
+	if id == canonicalID {
+		s.setCanonical(link, uuid.Nil)
+		return
+	}
+
+	target := canonicalID
+	if c := s.links[canonicalID].CanonicalID; c != uuid.Nil && c != id {
+		target = c
+	}
+
+	// Links that duplicated id now duplicate the target, which may itself
+	// have been one of them.
+	for dupID := range s.duplicates[id] {
+		dup := s.links[dupID]
+		if dupID == target {
+			s.setCanonical(dup, uuid.Nil)
+		} else {
+			s.setCanonical(dup, target)
+		}
+	}
+	s.setCanonical(link, target)
+
+	targetOut := make(map[edgeKey]struct{})
+	for _, edgeID := range s.linkEdgeMap[target] {
+		targetOut[keyOf(s.edges[edgeID])] = struct{}{}
+	}
+	for _, edgeID := range append(edgeList(nil), s.linkEdgeMap[id]...) {
+		edge := s.edges[edgeID]
+		moved := edgeKey{src: target, dst: edge.Dst, kind: edge.Kind}
+		if _, exists := targetOut[moved]; exists || edge.Dst == id || edge.Dst == target {
+			s.removeEdge(edgeID)
+			continue
+		}
+		s.linkEdgeMap[id] = s.linkEdgeMap[id].without(edgeID)
+		edge.Src = target
+		s.linkEdgeMap[target] = append(s.linkEdgeMap[target], edgeID)
+	}
+
+	targetIn := make(map[edgeKey]struct{})
+	for _, edgeID := range s.linkInEdgeMap[target] {
+		targetIn[keyOf(s.edges[edgeID])] = struct{}{}
+	}
+	for _, edgeID := range append(edgeList(nil), s.linkInEdgeMap[id]...) {
+		edge := s.edges[edgeID]
+		moved := edgeKey{src: edge.Src, dst: target, kind: edge.Kind}
+		if _, exists := targetIn[moved]; exists || edge.Src == target {
+			s.removeEdge(edgeID)
+			continue
+		}
+		s.linkInEdgeMap[id] = s.linkInEdgeMap[id].without(edgeID)
+		edge.Dst = target
+		s.linkInEdgeMap[target] = append(s.linkInEdgeMap[target], edgeID)
+	}
+
+	delete(s.linkEdgeMap, id)
+	delete(s.linkInEdgeMap, id)
+}
+
+// setCanonical points link to canonicalID and keeps the duplicates index in
+// sync. The caller must hold the write lock.
+func (s *InMemoryGraph) setCanonical(link *domain.Link, canonicalID uuid.UUID) {
+	if prev := link.CanonicalID; prev != uuid.Nil {
+		delete(s.duplicates[prev], link.ID)
+		if len(s.duplicates[prev]) == 0 {
+			delete(s.duplicates, prev)
+		}
+	}
+
+	link.CanonicalID = canonicalID
+	if canonicalID != uuid.Nil {
+		if s.duplicates[canonicalID] == nil {
+			s.duplicates[canonicalID] = make(map[uuid.UUID]struct{})
+		}
+		s.duplicates[canonicalID][link.ID] = struct{}{}
+	}
+}
+
+// Links returns an iterator for the set of links whose IDs belong to the
+// ids range and were retrieved before the provided timestamp.
+func (s *InMemoryGraph) Links(ctx context.Context, ids repository.IDRange, retrievedBefore time.Time) (repository.LinkIterator, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	var list []*domain.Link
 	for linkID, link := range s.links {
-		if id := linkID.String(); id >= from && id < to && link.RetrievedAt.Before(retrievedBefore) {
+		if ids.Contains(linkID) && link.RetrievedAt.Before(retrievedBefore) {
 			linkCopy := new(domain.Link)
 			*linkCopy = *link
 			list = append(list, linkCopy)
 		}
 	}
 
-	return &linkIterator{s: s, links: list}, nil
+	return &linkIterator{ctx: ctx, s: s, links: list}, nil
 }
 
 // UpsertEdge creates a new edge or updates an existing edge.
-func (s *InMemoryGraph) UpsertEdge(edge *domain.Edge) error {
+func (s *InMemoryGraph) UpsertEdge(_ context.Context, edge *domain.Edge) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	_, srcExists := s.links[edge.Src]
-	_, dstExists := s.links[edge.Dst]
-	if !srcExists || !dstExists {
+	if !s.edgeLinksExist(edge) {
 		return fmt.Errorf("upsert edge: %w", repository.GraphErrUnknownEdgeLinks)
 	}
 
-	// Scan edge list from source
+	rec := s.upsertEdgeRecord(edge, nil)
+	if err := s.appendWAL(rec); err != nil {
+		return fmt.Errorf("upsert edge: %w", err)
+	}
+	s.putEdge(rec.Edge)
+	*edge = *rec.Edge
+	return nil
+}
+
+// UpsertEdges creates or updates a batch of edges while holding the write
+// lock only once. If any edge references an unknown link, no edges are
+// stored.
+func (s *InMemoryGraph) UpsertEdges(_ context.Context, edges []*domain.Edge) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, edge := range edges {
+		if !s.edgeLinksExist(edge) {
+			return fmt.Errorf("upsert edges: %w", repository.GraphErrUnknownEdgeLinks)
+		}
+	}
+
+	var (
+		recs    = make([]walRecord, 0, len(edges))
+		pending = make(map[edgeKey]*domain.Edge, len(edges))
+	)
+	for _, edge := range edges {
+		rec := s.upsertEdgeRecord(edge, pending)
+		pending[keyOf(rec.Edge)] = rec.Edge
+		recs = append(recs, rec)
+	}
+	if err := s.appendWAL(recs...); err != nil {
+		return fmt.Errorf("upsert edges: %w", err)
+	}
+	for i, rec := range recs {
+		s.putEdge(rec.Edge)
+		*edges[i] = *rec.Edge
+	}
+	return nil
+}
+
+// edgeLinksExist returns true if both the source and destination links of
+// edge are known. The caller must hold the lock.
+func (s *InMemoryGraph) edgeLinksExist(edge *domain.Edge) bool {
+	_, srcExists := s.links[edge.Src]
+	_, dstExists := s.links[edge.Dst]
+	return srcExists && dstExists
+}
+
+// upsertEdgeRecord returns the write-ahead log record that creates or
+// updates edge without changing the graph. Edges in pending, keyed by their
+// source, destination and kind, are records of the same batch that have not been
+// applied yet. The caller must hold the write lock and have verified that
+// the edge links exist.
+func (s *InMemoryGraph) upsertEdgeRecord(edge *domain.Edge, pending map[edgeKey]*domain.Edge) walRecord {
+	eCopy := new(domain.Edge)
+	*eCopy = *edge
+	eCopy.UpdatedAt = time.Now()
+
+	// Scan pending edges and the edge list from source
+	if existingEdge := pending[keyOf(edge)]; existingEdge != nil {
+		eCopy.ID = existingEdge.ID
+		return walRecord{Op: walOpUpsertEdge, Edge: eCopy}
+	}
 	for _, edgeID := range s.linkEdgeMap[edge.Src] {
-		existingEdge := s.edges[edgeID]
-		if existingEdge.Dst == edge.Dst {
-			existingEdge.UpdatedAt = time.Now()
-			*edge = *existingEdge
-			return nil
+		if keyOf(s.edges[edgeID]) == keyOf(edge) {
+			eCopy.ID = edgeID
+			return walRecord{Op: walOpUpsertEdge, Edge: eCopy}
 		}
 	}
 
-	// Insert new edge
+	// Assign new ID
 	for {
-		edge.ID = uuid.New()
-		if s.edges[edge.ID] == nil {
+		eCopy.ID = uuid.New()
+		if s.edges[eCopy.ID] == nil && !pendingEdgeID(pending, eCopy.ID) {
 			break
 		}
 	}
+	return walRecord{Op: walOpUpsertEdge, Edge: eCopy}
+}
 
-	edge.UpdatedAt = time.Now()
-	eCopy := new(domain.Edge)
-	*eCopy = *edge
-	s.edges[eCopy.ID] = eCopy
+// edgeKey identifies an edge by its endpoints and kind.
+type edgeKey struct {
+	src, dst uuid.UUID
+	kind     domain.EdgeKind
+}
+
+func keyOf(edge *domain.Edge) edgeKey {
+	return edgeKey{src: edge.Src, dst: edge.Dst, kind: edge.Kind}
+}
+
+// pendingEdgeID returns true if one of the pending edges has the given ID.
+func pendingEdgeID(pending map[edgeKey]*domain.Edge, id uuid.UUID) bool {
+	for _, edge := range pending {
+		if edge.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveEdge removes the edge with the specified ID.
+func (s *InMemoryGraph) RemoveEdge(_ context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	// Append the edge ID to the list of edges originating from the
-	// edge's source link.
-	s.linkEdgeMap[eCopy.Src] = append(s.linkEdgeMap[eCopy.Src], eCopy.ID)
+	if s.edges[id] == nil {
+		return fmt.Errorf("remove edge: %w", repository.GraphErrNotFound)
+	}
+
+	if err := s.appendWAL(walRecord{Op: walOpRemoveEdge, ID: id}); err != nil {
+		return fmt.Errorf("remove edge: %w", err)
+	}
+	s.removeEdge(id)
 	return nil
 }
 
-// Edges returns an iterator for the set of edges whose source vertex IDs
-// belong to the [fromID, toID) range and were updated before the provided
-// timestamp.
-func (s *InMemoryGraph) Edges(fromID, toID uuid.UUID, updatedBefore time.Time) (repository.EdgeIterator, error) {
-	from, to := fromID.String(), toID.String()
+// removeEdge deletes an existing edge and removes it from the edge lists of
+// its origin and destination links. The caller must hold the write lock.
+func (s *InMemoryGraph) removeEdge(id uuid.UUID) {
+	edge := s.edges[id]
+	if edge == nil {
+		return
+	}
+	delete(s.edges, id)
+
+	s.linkEdgeMap[edge.Src] = s.linkEdgeMap[edge.Src].without(id)
+	s.linkInEdgeMap[edge.Dst] = s.linkInEdgeMap[edge.Dst].without(id)
+}
 
+// Edges returns an iterator for the set of edges whose source vertex IDs
+// belong to the srcIDs range and were updated before the provided timestamp.
+func (s *InMemoryGraph) Edges(ctx context.Context, srcIDs repository.IDRange, updatedBefore time.Time) (repository.EdgeIterator, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	var list []*domain.Edge
 	for linkID := range s.links {
-		if id := linkID.String(); id < from || id >= to {
+		if !srcIDs.Contains(linkID) {
 			continue
 		}
 
@@ -163,20 +552,70 @@ func (s *InMemoryGraph) Edges(fromID, toID uuid.UUID, updatedBefore time.Time) (
 		}
 	}
 
-	return &edgeIterator{s: s, edges: list}, nil
+	return &edgeIterator{ctx: ctx, s: s, edges: list}, nil
+}
+
+// InEdges returns an iterator for the set of edges that point to the
+// specified link.
+func (s *InMemoryGraph) InEdges(ctx context.Context, linkID uuid.UUID) (repository.EdgeIterator, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &edgeIterator{ctx: ctx, s: s, edges: s.copyEdges(s.linkInEdgeMap[linkID])}, nil
+}
+
+// OutEdges returns an iterator for the set of edges that originate from the
+// specified link.
+func (s *InMemoryGraph) OutEdges(ctx context.Context, linkID uuid.UUID) (repository.EdgeIterator, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &edgeIterator{ctx: ctx, s: s, edges: s.copyEdges(s.linkEdgeMap[linkID])}, nil
+}
+
+// Degree returns the number of edges pointing to and originating from the
+// specified link.
+func (s *InMemoryGraph) Degree(_ context.Context, linkID uuid.UUID) (int, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.links[linkID] == nil {
+		return 0, 0, fmt.Errorf("degree: %w", repository.GraphErrNotFound)
+	}
+	return len(s.linkInEdgeMap[linkID]), len(s.linkEdgeMap[linkID]), nil
+}
+
+// copyEdges returns copies of the edges in list. The caller must hold the
+// lock.
+func (s *InMemoryGraph) copyEdges(list edgeList) []*domain.Edge {
+	edges := make([]*domain.Edge, 0, len(list))
+	for _, edgeID := range list {
+		edgeCopy := *s.edges[edgeID]
+		edges = append(edges, &edgeCopy)
+	}
+	return edges
 }
 
 // RemoveStaleEdges removes any edge that originates from the specified link ID
 // and was updated before the specified timestamp.
-func (s *InMemoryGraph) RemoveStaleEdges(fromID uuid.UUID, updatedBefore time.Time) error {
+func (s *InMemoryGraph) RemoveStaleEdges(_ context.Context, fromID uuid.UUID, updatedBefore time.Time) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if err := s.appendWAL(walRecord{Op: walOpRemoveStaleEdges, ID: fromID, Before: updatedBefore}); err != nil {
+		return fmt.Errorf("remove stale edges: %w", err)
+	}
+	s.removeStaleEdges(fromID, updatedBefore)
+	return nil
+}
+
+// removeStaleEdges deletes the edges originating from fromID that were
+// updated before updatedBefore. The caller must hold the write lock.
+func (s *InMemoryGraph) removeStaleEdges(fromID uuid.UUID, updatedBefore time.Time) {
 	var newEdgeList edgeList
 	for _, edgeID := range s.linkEdgeMap[fromID] {
 		edge := s.edges[edgeID]
 		if edge.UpdatedAt.Before(updatedBefore) {
 			delete(s.edges, edgeID)
+			s.linkInEdgeMap[edge.Dst] = s.linkInEdgeMap[edge.Dst].without(edgeID)
 			continue
 		}
 
@@ -185,5 +624,4 @@ func (s *InMemoryGraph) RemoveStaleEdges(fromID uuid.UUID, updatedBefore time.Ti
 
 	// Replace edge list or origin link with the filtered edge list
 	s.linkEdgeMap[fromID] = newEdgeList
-	return nil
 }
@@ -21,9 +21,30 @@ func (s *InMemoryGraphTestSuite) SetupTest() {
 func (s *InMemoryGraphTestSuite) TestUpsertLink() {
 	s.base.TestUpsertLink(s.T())
 }
+func (s *InMemoryGraphTestSuite) TestUpsertLinks() {
+	s.base.TestUpsertLinks(s.T())
+}
+func (s *InMemoryGraphTestSuite) TestLinkFetchMetadata() {
+	s.base.TestLinkFetchMetadata(s.T())
+}
+func (s *InMemoryGraphTestSuite) TestTouchLink() {
+	s.base.TestTouchLink(s.T())
+}
+func (s *InMemoryGraphTestSuite) TestMergeLink() {
+	s.base.TestMergeLink(s.T())
+}
 func (s *InMemoryGraphTestSuite) TestFindLink() {
 	s.base.TestFindLink(s.T())
 }
+func (s *InMemoryGraphTestSuite) TestFindLinkByURL() {
+	s.base.TestFindLinkByURL(s.T())
+}
+func (s *InMemoryGraphTestSuite) TestFindLinks() {
+	s.base.TestFindLinks(s.T())
+}
+func (s *InMemoryGraphTestSuite) TestRemoveLink() {
+	s.base.TestRemoveLink(s.T())
+}
 func (s *InMemoryGraphTestSuite) TestConcurrentLinkIterators() {
 	s.base.TestConcurrentLinkIterators(s.T())
 }
@@ -33,9 +54,33 @@ func (s *InMemoryGraphTestSuite) TestLinkIteratorTimeFilter() {
 func (s *InMemoryGraphTestSuite) TestPartitionedLinkIterators() {
 	s.base.TestPartitionedLinkIterators(s.T())
 }
+func (s *InMemoryGraphTestSuite) TestLinkIteratorContextCancellation() {
+	s.base.TestLinkIteratorContextCancellation(s.T())
+}
+func (s *InMemoryGraphTestSuite) TestLinkRangeBoundaries() {
+	s.base.TestLinkRangeBoundaries(s.T())
+}
 func (s *InMemoryGraphTestSuite) TestUpsertEdge() {
 	s.base.TestUpsertEdge(s.T())
 }
+func (s *InMemoryGraphTestSuite) TestUpsertEdges() {
+	s.base.TestUpsertEdges(s.T())
+}
+func (s *InMemoryGraphTestSuite) TestEdgeAnchor() {
+	s.base.TestEdgeAnchor(s.T())
+}
+func (s *InMemoryGraphTestSuite) TestRedirectEdge() {
+	s.base.TestRedirectEdge(s.T())
+}
+func (s *InMemoryGraphTestSuite) TestRemoveEdge() {
+	s.base.TestRemoveEdge(s.T())
+}
+func (s *InMemoryGraphTestSuite) TestInOutEdges() {
+	s.base.TestInOutEdges(s.T())
+}
+func (s *InMemoryGraphTestSuite) TestStats() {
+	s.base.TestStats(s.T())
+}
 func (s *InMemoryGraphTestSuite) TestConcurrentEdgeIterators() {
 	s.base.TestConcurrentEdgeIterators(s.T())
 }
@@ -48,3 +93,9 @@ func (s *InMemoryGraphTestSuite) TestPartitionedEdgeIterators() {
 func (s *InMemoryGraphTestSuite) TestRemoveStaleEdges() {
 	s.base.TestRemoveStaleEdges(s.T())
 }
+func (s *InMemoryGraphTestSuite) TestEdgeIteratorContextCancellation() {
+	s.base.TestEdgeIteratorContextCancellation(s.T())
+}
+func (s *InMemoryGraphTestSuite) TestEdgeRangeBoundaries() {
+	s.base.TestEdgeRangeBoundaries(s.T())
+}
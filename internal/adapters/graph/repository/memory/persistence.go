@@ -0,0 +1,317 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/google/uuid"
+)
+
+// snapshotVersion is bumped whenever the snapshot format changes in a
+// backwards incompatible way.
+const snapshotVersion = 1
+
+// snapshot is the on-disk representation of the graph contents.
+type snapshot struct {
+	Version int `json:"version"`
+	// WALSeq is the sequence number of the last write-ahead log record
+	// reflected in the snapshot.
+	WALSeq uint64         `json:"wal_seq"`
+	Links  []*domain.Link `json:"links"`
+	Edges  []*domain.Edge `json:"edges"`
+}
+
+// PersistenceConfig controls where an in-memory graph keeps its data.
+type PersistenceConfig struct {
+	// SnapshotPath is the file the graph is restored from on open and
+	// written to by SaveSnapshot.
+	SnapshotPath string
+
+	// WALPath is an optional append-only log recording every mutation
+	// applied since the last snapshot. If empty, mutations performed after
+	// the last snapshot are lost when the process exits.
+	WALPath string
+
+	// SyncWAL forces the log to be flushed to stable storage after every
+	// write, so mutations also survive operating system crashes.
+	SyncWAL bool
+}
+
+// OpenInMemoryGraph creates an in-memory graph restored from the snapshot
+// and write-ahead log specified by cfg. Missing files are treated as empty.
+// The caller should periodically invoke SaveSnapshot and must invoke Close
+// once the graph is no longer needed.
+func OpenInMemoryGraph(cfg PersistenceConfig) (*InMemoryGraph, error) {
+	s := newInMemoryGraph()
+	s.snapshotPath = cfg.SnapshotPath
+
+	f, err := os.Open(cfg.SnapshotPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("open in-memory graph: %w", err)
+	default:
+		err = s.ReadSnapshot(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("open in-memory graph: %w", err)
+		}
+	}
+
+	if cfg.WALPath != "" {
+		if s.wal, err = openWAL(cfg.WALPath, cfg.SyncWAL, s.applyWALRecord); err != nil {
+			return nil, fmt.Errorf("open in-memory graph: wal: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// WriteSnapshot writes a consistent copy of the graph contents to w. The
+// graph remains readable while the snapshot is written; writers are blocked
+// until it completes.
+func (s *InMemoryGraph) WriteSnapshot(w io.Writer) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.writeSnapshot(w)
+}
+
+// writeSnapshot encodes the graph contents to w. The caller must hold the
+// lock.
+func (s *InMemoryGraph) writeSnapshot(w io.Writer) error {
+	snap := snapshot{
+		Version: snapshotVersion,
+		WALSeq:  s.walSeq,
+		Links:   make([]*domain.Link, 0, len(s.links)),
+		Edges:   make([]*domain.Edge, 0, len(s.edges)),
+	}
+	for _, link := range s.links {
+		snap.Links = append(snap.Links, link)
+	}
+	for _, edge := range s.edges {
+		snap.Edges = append(snap.Edges, edge)
+	}
+
+	if err := json.NewEncoder(w).Encode(snap); err != nil {
+		return fmt.Errorf("write snapshot: %w", err)
+	}
+	return nil
+}
+
+// ReadSnapshot replaces the graph contents with the snapshot read from r.
+func (s *InMemoryGraph) ReadSnapshot(r io.Reader) error {
+	var snap snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("read snapshot: %w", err)
+	}
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("read snapshot: unsupported version %d", snap.Version)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reset()
+	s.walSeq = snap.WALSeq
+	for _, link := range snap.Links {
+		s.putLink(link)
+	}
+	for _, edge := range snap.Edges {
+		if !s.edgeLinksExist(edge) {
+			return fmt.Errorf("read snapshot: edge %s references unknown links", edge.ID)
+		}
+		s.putEdge(edge)
+	}
+	return nil
+}
+
+// SaveSnapshot atomically replaces the snapshot file with the current graph
+// contents and then discards the write-ahead log, whose records are all
+// reflected in the new snapshot.
+func (s *InMemoryGraph) SaveSnapshot() error {
+	if s.snapshotPath == "" {
+		return fmt.Errorf("save snapshot: no snapshot path configured")
+	}
+
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+
+	// Holding the read lock keeps writers, and therefore WAL appends, out
+	// until the log has been reset.
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if err := s.replaceSnapshot(); err != nil {
+		return fmt.Errorf("save snapshot: %w", err)
+	}
+
+	// If the process dies before the log is reset, the records it still
+	// holds are skipped on the next open as the snapshot already covers
+	// their sequence numbers.
+	if s.wal != nil {
+		if err := s.wal.reset(); err != nil {
+			return fmt.Errorf("save snapshot: reset wal: %w", err)
+		}
+	}
+	return nil
+}
+
+// replaceSnapshot writes the graph contents to a temporary file and renames
+// it over the snapshot file. The directory is synced as well so the rename
+// itself survives a crash. The caller must hold the lock.
+func (s *InMemoryGraph) replaceSnapshot() error {
+	dir := filepath.Dir(s.snapshotPath)
+	f, err := os.CreateTemp(dir, filepath.Base(s.snapshotPath)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	err = s.writeSnapshot(f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), s.snapshotPath)
+	}
+	if err == nil {
+		err = syncDir(dir)
+	}
+	return err
+}
+
+// syncDir flushes the directory entry changes of dir to stable storage.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = d.Sync()
+	if closeErr := d.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Close releases the write-ahead log. It does not save a snapshot.
+func (s *InMemoryGraph) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.wal == nil {
+		return nil
+	}
+	err := s.wal.close()
+	s.wal = nil
+	if err != nil {
+		return fmt.Errorf("close in-memory graph: %w", err)
+	}
+	return nil
+}
+
+// appendWAL assigns the next sequence numbers to recs and records them in
+// the write-ahead log, if one is configured. Mutations are only applied once
+// their records have been appended, so a failed append leaves the graph
+// unchanged. The caller must hold the write lock.
+func (s *InMemoryGraph) appendWAL(recs ...walRecord) error {
+	if s.wal == nil || len(recs) == 0 {
+		return nil
+	}
+	for i := range recs {
+		recs[i].Seq = s.walSeq + uint64(i) + 1
+	}
+	if err := s.wal.append(recs...); err != nil {
+		return err
+	}
+	s.walSeq += uint64(len(recs))
+	return nil
+}
+
+// applyWALRecord replays a mutation recorded in the write-ahead log. Records
+// already reflected in the snapshot are skipped. It is only invoked while
+// the graph is being opened.
+func (s *InMemoryGraph) applyWALRecord(rec walRecord) error {
+	if rec.Seq <= s.walSeq {
+		return nil
+	}
+	if err := s.replayWALRecord(rec); err != nil {
+		return err
+	}
+	s.walSeq = rec.Seq
+	return nil
+}
+
+// replayWALRecord applies the mutation described by rec.
+func (s *InMemoryGraph) replayWALRecord(rec walRecord) error {
+	switch rec.Op {
+	case walOpUpsertLink:
+		s.putLink(rec.Link)
+	case walOpRemoveLink:
+		s.removeLink(rec.ID)
+	case walOpUpsertEdge:
+		if !s.edgeLinksExist(rec.Edge) {
+			return fmt.Errorf("edge %s references unknown links", rec.Edge.ID)
+		}
+		s.putEdge(rec.Edge)
+	case walOpRemoveEdge:
+		s.removeEdge(rec.ID)
+	case walOpRemoveStaleEdges:
+		s.removeStaleEdges(rec.ID, rec.Before)
+	case walOpMergeLink:
+		if s.links[rec.ID] == nil || s.links[rec.Target] == nil {
+			return fmt.Errorf("merge of link %s references unknown links", rec.ID)
+		}
+		s.mergeLink(rec.ID, rec.Target)
+	case walOpTouchLink:
+		if s.links[rec.ID] == nil {
+			return fmt.Errorf("touch of unknown link %s", rec.ID)
+		}
+		s.touchLink(rec.ID, rec.At, rec.UpdatedAt)
+	default:
+		return fmt.Errorf("unknown operation %q", rec.Op)
+	}
+	return nil
+}
+
+// putLink stores link under its existing ID. The caller must hold the write
+// lock.
+func (s *InMemoryGraph) putLink(link *domain.Link) {
+	existing := s.links[link.ID]
+	if existing != nil && existing.URL != link.URL {
+		delete(s.linkURLIndex, existing.URL)
+	}
+
+	lCopy := new(domain.Link)
+	*lCopy = *link
+	if existing != nil {
+		lCopy.CanonicalID = existing.CanonicalID
+	} else {
+		lCopy.CanonicalID = uuid.Nil
+	}
+	s.links[lCopy.ID] = lCopy
+	s.linkURLIndex[lCopy.URL] = lCopy
+	s.setCanonical(lCopy, link.CanonicalID)
+}
+
+// putEdge stores edge under its existing ID. The caller must hold the write
+// lock and have verified that the edge links exist.
+func (s *InMemoryGraph) putEdge(edge *domain.Edge) {
+	if existing := s.edges[edge.ID]; existing != nil {
+		*existing = *edge
+		return
+	}
+
+	eCopy := new(domain.Edge)
+	*eCopy = *edge
+	s.edges[eCopy.ID] = eCopy
+	s.linkEdgeMap[eCopy.Src] = append(s.linkEdgeMap[eCopy.Src], eCopy.ID)
+	s.linkInEdgeMap[eCopy.Dst] = append(s.linkInEdgeMap[eCopy.Dst], eCopy.ID)
+}
@@ -0,0 +1,232 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	cfg := PersistenceConfig{SnapshotPath: filepath.Join(dir, "graph.snapshot")}
+
+	g, err := OpenInMemoryGraph(cfg)
+	require.NoError(t, err)
+	src, dst, edge := populate(t, g)
+	require.NoError(t, g.SaveSnapshot())
+	require.NoError(t, g.Close())
+
+	restored, err := OpenInMemoryGraph(cfg)
+	require.NoError(t, err)
+	defer func() { _ = restored.Close() }()
+	assertRestored(t, restored, src, dst, edge)
+}
+
+func TestWALReplay(t *testing.T) {
+	dir := t.TempDir()
+	cfg := PersistenceConfig{
+		SnapshotPath: filepath.Join(dir, "graph.snapshot"),
+		WALPath:      filepath.Join(dir, "graph.wal"),
+	}
+
+	g, err := OpenInMemoryGraph(cfg)
+	require.NoError(t, err)
+	src, dst, edge := populate(t, g)
+	require.NoError(t, g.SaveSnapshot())
+
+	// Mutations performed after the snapshot are only recorded in the WAL.
+	removed := &domain.Link{URL: "https://example.com/removed"}
+	require.NoError(t, g.UpsertLink(context.TODO(), removed))
+	require.NoError(t, g.UpsertEdge(context.TODO(), &domain.Edge{Src: src.ID, Dst: removed.ID}))
+	require.NoError(t, g.RemoveLink(context.TODO(), removed.ID))
+	other := &domain.Link{URL: "https://example.com/other"}
+	require.NoError(t, g.UpsertLink(context.TODO(), other))
+	stale := &domain.Edge{Src: dst.ID, Dst: other.ID}
+	require.NoError(t, g.UpsertEdge(context.TODO(), stale))
+	require.NoError(t, g.RemoveStaleEdges(context.TODO(), dst.ID, time.Now()))
+	touchedAt := time.Now().Truncate(time.Second)
+	require.NoError(t, g.TouchLink(context.TODO(), other.ID, touchedAt))
+	require.NoError(t, g.Close())
+
+	restored, err := OpenInMemoryGraph(cfg)
+	require.NoError(t, err)
+	defer func() { _ = restored.Close() }()
+	assertRestored(t, restored, src, dst, edge)
+
+	_, err = restored.FindLink(context.TODO(), removed.ID)
+	assert.True(t, errors.Is(err, repository.GraphErrNotFound), "expected removed link to stay removed")
+	got, err := restored.FindLinkByURL(context.TODO(), other.URL)
+	require.NoError(t, err)
+	assert.Equal(t, other.ID, got.ID)
+	assert.True(t, touchedAt.Equal(got.RetrievedAt), "expected touch to be replayed")
+	in, out, err := restored.Degree(context.TODO(), dst.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, in)
+	assert.Equal(t, 0, out, "expected stale edge removal to be replayed")
+}
+
+func TestWALTornRecord(t *testing.T) {
+	dir := t.TempDir()
+	cfg := PersistenceConfig{
+		SnapshotPath: filepath.Join(dir, "graph.snapshot"),
+		WALPath:      filepath.Join(dir, "graph.wal"),
+	}
+
+	g, err := OpenInMemoryGraph(cfg)
+	require.NoError(t, err)
+	src, dst, edge := populate(t, g)
+	require.NoError(t, g.Close())
+
+	// Simulate a crash in the middle of appending a record.
+	f, err := os.OpenFile(cfg.WALPath, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"op":"upsert_li`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	restored, err := OpenInMemoryGraph(cfg)
+	require.NoError(t, err)
+	assertRestored(t, restored, src, dst, edge)
+
+	// New records must be appended after the last complete record.
+	link := &domain.Link{URL: "https://example.com/after-crash"}
+	require.NoError(t, restored.UpsertLink(context.TODO(), link))
+	require.NoError(t, restored.Close())
+
+	restored, err = OpenInMemoryGraph(cfg)
+	require.NoError(t, err)
+	defer func() { _ = restored.Close() }()
+	_, err = restored.FindLink(context.TODO(), link.ID)
+	assert.NoError(t, err)
+}
+
+func TestWALCrashBeforeReset(t *testing.T) {
+	dir := t.TempDir()
+	cfg := PersistenceConfig{
+		SnapshotPath: filepath.Join(dir, "graph.snapshot"),
+		WALPath:      filepath.Join(dir, "graph.wal"),
+	}
+
+	g, err := OpenInMemoryGraph(cfg)
+	require.NoError(t, err)
+	src, dst, edge := populate(t, g)
+
+	// Merging moves the edge to dst while keeping its ID. Replaying the
+	// edge upsert on top of the merged graph would point it back at the
+	// duplicate while dst still lists it as an outgoing edge.
+	merged := &domain.Link{URL: "https://example.com/merged"}
+	other := &domain.Link{URL: "https://example.com/other"}
+	require.NoError(t, g.UpsertLinks(context.TODO(), []*domain.Link{merged, other}))
+	moved := &domain.Edge{Src: merged.ID, Dst: other.ID}
+	require.NoError(t, g.UpsertEdge(context.TODO(), moved))
+	require.NoError(t, g.MergeLink(context.TODO(), merged.ID, dst.ID))
+
+	// Simulate a crash after the new snapshot was renamed into place but
+	// before the log was reset.
+	g.mu.RLock()
+	require.NoError(t, g.replaceSnapshot())
+	g.mu.RUnlock()
+	require.NoError(t, g.Close())
+
+	restored, err := OpenInMemoryGraph(cfg)
+	require.NoError(t, err)
+	assertRestored(t, restored, src, dst, edge)
+	assertOutEdge(t, restored, dst.ID, moved.ID)
+
+	// Sequence numbers must continue past the ones in the snapshot, or
+	// records appended after the restart would be skipped.
+	link := &domain.Link{URL: "https://example.com/after-crash"}
+	require.NoError(t, restored.UpsertLink(context.TODO(), link))
+	require.NoError(t, restored.Close())
+
+	restored, err = OpenInMemoryGraph(cfg)
+	require.NoError(t, err)
+	defer func() { _ = restored.Close() }()
+	assertRestored(t, restored, src, dst, edge)
+	assertOutEdge(t, restored, dst.ID, moved.ID)
+	_, err = restored.FindLink(context.TODO(), link.ID)
+	assert.NoError(t, err)
+}
+
+func TestWALAppendFailure(t *testing.T) {
+	dir := t.TempDir()
+	cfg := PersistenceConfig{
+		SnapshotPath: filepath.Join(dir, "graph.snapshot"),
+		WALPath:      filepath.Join(dir, "graph.wal"),
+	}
+
+	g, err := OpenInMemoryGraph(cfg)
+	require.NoError(t, err)
+	src, dst, edge := populate(t, g)
+
+	// Make every further append fail.
+	require.NoError(t, g.wal.f.Close())
+
+	newLink := &domain.Link{URL: "https://example.com/new"}
+	updated := &domain.Link{URL: src.URL, RetrievedAt: time.Now()}
+	assert.Error(t, g.UpsertLink(context.TODO(), newLink))
+	assert.Error(t, g.UpsertLink(context.TODO(), updated))
+	assert.Error(t, g.UpsertLinks(context.TODO(), []*domain.Link{newLink, updated}))
+	assert.Error(t, g.UpsertEdge(context.TODO(), &domain.Edge{Src: dst.ID, Dst: src.ID}))
+	assert.Error(t, g.UpsertEdges(context.TODO(), []*domain.Edge{{Src: dst.ID, Dst: src.ID}}))
+	assert.Error(t, g.RemoveEdge(context.TODO(), edge.ID))
+	assert.Error(t, g.RemoveStaleEdges(context.TODO(), src.ID, time.Now()))
+	assert.Error(t, g.MergeLink(context.TODO(), dst.ID, src.ID))
+	assert.Error(t, g.RemoveLink(context.TODO(), dst.ID))
+
+	// None of the failed mutations may be visible.
+	assertRestored(t, g, src, dst, edge)
+	_, err = g.FindLinkByURL(context.TODO(), newLink.URL)
+	assert.True(t, errors.Is(err, repository.GraphErrNotFound))
+	got, err := g.FindLink(context.TODO(), dst.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, got.CanonicalID)
+	in, out, err := g.Degree(context.TODO(), dst.ID)
+	require.NoError(t, err)
+	assert.Equal(t, [2]int{1, 0}, [2]int{in, out})
+}
+
+func populate(t *testing.T, g *InMemoryGraph) (*domain.Link, *domain.Link, *domain.Edge) {
+	src := &domain.Link{URL: "https://example.com", RetrievedAt: time.Now().Add(-time.Hour)}
+	dst := &domain.Link{URL: "https://example.com/about"}
+	require.NoError(t, g.UpsertLinks(context.TODO(), []*domain.Link{src, dst}))
+	edge := &domain.Edge{Src: src.ID, Dst: dst.ID}
+	require.NoError(t, g.UpsertEdge(context.TODO(), edge))
+	return src, dst, edge
+}
+
+func assertRestored(t *testing.T, g *InMemoryGraph, src, dst *domain.Link, edge *domain.Edge) {
+	for _, link := range []*domain.Link{src, dst} {
+		got, err := g.FindLinkByURL(context.TODO(), link.URL)
+		require.NoError(t, err)
+		assert.Equal(t, link.ID, got.ID)
+		assert.True(t, link.RetrievedAt.Equal(got.RetrievedAt), "expected RetrievedAt to be restored")
+	}
+
+	it, err := g.OutEdges(context.TODO(), src.ID)
+	require.NoError(t, err)
+	require.True(t, it.Next(), "expected edge to be restored")
+	assert.Equal(t, edge.ID, it.Edge().ID)
+	assert.Equal(t, dst.ID, it.Edge().Dst)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Close())
+}
+
+func assertOutEdge(t *testing.T, g *InMemoryGraph, src, edgeID uuid.UUID) {
+	it, err := g.OutEdges(context.TODO(), src)
+	require.NoError(t, err)
+	require.True(t, it.Next(), "expected an outgoing edge")
+	assert.Equal(t, edgeID, it.Edge().ID)
+	assert.Equal(t, src, it.Edge().Src)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Close())
+}
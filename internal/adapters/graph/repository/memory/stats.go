@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/bruceneco/links-r-us/internal/partition"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"time"
+)
+
+// Stats computes summary figures about the graph contents.
+func (s *InMemoryGraph) Stats(_ context.Context, opts repository.GraphStatsOptions) (*repository.GraphStats, error) {
+	numPartitions := max(opts.NumPartitions, 1)
+	r, err := partition.NewFullRange(numPartitions)
+	if err != nil {
+		return nil, fmt.Errorf("stats: %w", err)
+	}
+	retrievedSince := time.Now().Add(-opts.RetrievedWithin)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := &repository.GraphStats{
+		Links:              len(s.links),
+		Edges:              len(s.edges),
+		EdgesPerPartition:  make([]int, numPartitions),
+		InDegreeHistogram:  make(map[int]int),
+		OutDegreeHistogram: make(map[int]int),
+	}
+	for linkID, link := range s.links {
+		switch {
+		case link.RetrievedAt.IsZero():
+			stats.UnretrievedLinks++
+		case !link.RetrievedAt.Before(retrievedSince):
+			stats.RecentlyRetrievedLinks++
+		}
+
+		stats.InDegreeHistogram[len(s.linkInEdgeMap[linkID])]++
+		stats.OutDegreeHistogram[len(s.linkEdgeMap[linkID])]++
+	}
+	for _, edge := range s.edges {
+		p, err := r.PartitionForID(edge.Src)
+		if err != nil {
+			return nil, fmt.Errorf("stats: %w", err)
+		}
+		stats.EdgesPerPartition[p]++
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/google/uuid"
+)
+
+// Operations recorded in the write-ahead log.
+const (
+	walOpUpsertLink       = "upsert_link"
+	walOpRemoveLink       = "remove_link"
+	walOpUpsertEdge       = "upsert_edge"
+	walOpRemoveEdge       = "remove_edge"
+	walOpRemoveStaleEdges = "remove_stale_edges"
+	walOpMergeLink        = "merge_link"
+	walOpTouchLink        = "touch_link"
+)
+
+// walRecord describes a single mutation of the graph. Upserts record the
+// stored link or edge, including the ID assigned by the graph, so replaying
+// them does not depend on the random ID generator.
+//
+// Seq increases with every record and keeps growing across snapshots. A
+// snapshot stores the Seq of the last record it reflects so records that
+// outlive it, e.g. because the process crashed before the log was reset,
+// are not applied twice.
+type walRecord struct {
+	Seq    uint64       `json:"seq"`
+	Op     string       `json:"op"`
+	Link   *domain.Link `json:"link,omitempty"`
+	Edge   *domain.Edge `json:"edge,omitempty"`
+	ID     uuid.UUID    `json:"id,omitempty"`
+	Target uuid.UUID    `json:"target,omitempty"`
+	Before time.Time    `json:"before,omitempty"`
+	// At and UpdatedAt are the retrieval time and the edge update time
+	// recorded by a touch.
+	At        time.Time `json:"at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// wal is an append-only log of graph mutations stored as one JSON record per
+// line.
+type wal struct {
+	f    *os.File
+	sync bool
+}
+
+// openWAL opens the log at path, creating it if needed. Each record that can
+// be read back is passed to apply. A torn record at the end of the log, as
+// left behind by a crash in the middle of a write, is discarded.
+func openWAL(path string, sync bool, apply func(walRecord) error) (*wal, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := replayWAL(f, apply)
+	if err == nil {
+		// Drop any torn record and position the file for appending.
+		if err = f.Truncate(valid); err == nil {
+			_, err = f.Seek(valid, io.SeekStart)
+		}
+	}
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return &wal{f: f, sync: sync}, nil
+}
+
+// replayWAL passes every complete record in r to apply and returns the
+// number of bytes they occupy.
+func replayWAL(r io.Reader, apply func(walRecord) error) (int64, error) {
+	var (
+		br    = bufio.NewReader(r)
+		valid int64
+	)
+	for {
+		line, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return valid, nil
+		} else if err != nil {
+			return 0, err
+		}
+
+		var rec walRecord
+		if err = json.Unmarshal(line, &rec); err != nil {
+			return 0, fmt.Errorf("corrupt record at offset %d: %w", valid, err)
+		}
+		if err = apply(rec); err != nil {
+			return 0, fmt.Errorf("replay record at offset %d: %w", valid, err)
+		}
+		valid += int64(len(line))
+	}
+}
+
+// append writes recs to the log with a single write call.
+func (w *wal) append(recs ...walRecord) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, rec := range recs {
+		if err := enc.Encode(rec); err != nil {
+			return err
+		}
+	}
+	if _, err := w.f.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	if w.sync {
+		return w.f.Sync()
+	}
+	return nil
+}
+
+// reset discards every record in the log.
+func (w *wal) reset() error {
+	if err := w.f.Truncate(0); err != nil {
+		return err
+	}
+	_, err := w.f.Seek(0, io.SeekStart)
+	return err
+}
+
+func (w *wal) close() error {
+	return w.f.Close()
+}
@@ -0,0 +1,504 @@
+// Package sqlite implements a graph repository on top of an embedded SQLite
+// database, providing a persistent graph without an external database
+// process.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+	"strings"
+	"time"
+)
+
+// timeLayout is a fixed-width UTC layout so that timestamps stored as TEXT
+// compare in chronological order.
+const timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// maxBatchParams caps the number of bound parameters used by a single IN
+// clause.
+const maxBatchParams = 500
+
+type GraphSQLiteRepository struct {
+	db *sql.DB
+}
+
+func NewGraphSQLiteRepository(db *sql.DB) repository.GraphRepository {
+	return &GraphSQLiteRepository{
+		db: db,
+	}
+}
+
+// Open opens the SQLite database at path, creating it if needed, with the
+// pragmas required by the graph and applies any pending migration.
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", path)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite: %w", err)
+	}
+	if err = migrate(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("open sqlite: %w", err)
+	}
+	return db, nil
+}
+
+// linkColumns lists the links columns read by scanLink, in order.
+const linkColumns = `id, url, retrieved_at, status_code, content_type, content_length, etag, last_modified, failure_count, last_error, canonical_id`
+
+// upsertLinkQuery keeps the most recent retrieval timestamp of a link and
+// only replaces its fetch metadata with that of a newer retrieval.
+var upsertLinkQuery = `
+	INSERT INTO links (id, url, retrieved_at, status_code, content_type, content_length, etag, last_modified, failure_count, last_error)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (url) DO UPDATE SET
+	    retrieved_at=MAX(links.retrieved_at, excluded.retrieved_at),
+	    status_code=IIF(excluded.retrieved_at > links.retrieved_at, excluded.status_code, links.status_code),
+	    content_type=IIF(excluded.retrieved_at > links.retrieved_at, excluded.content_type, links.content_type),
+	    content_length=IIF(excluded.retrieved_at > links.retrieved_at, excluded.content_length, links.content_length),
+	    etag=IIF(excluded.retrieved_at > links.retrieved_at, excluded.etag, links.etag),
+	    last_modified=IIF(excluded.retrieved_at > links.retrieved_at, excluded.last_modified, links.last_modified),
+	    failure_count=IIF(excluded.retrieved_at > links.retrieved_at, excluded.failure_count, links.failure_count),
+	    last_error=IIF(excluded.retrieved_at > links.retrieved_at, excluded.last_error, links.last_error)
+	RETURNING ` + linkColumns
+
+// queryRower is implemented by both *sql.DB and *sql.Tx.
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func (r *GraphSQLiteRepository) UpsertLink(ctx context.Context, link *domain.Link) error {
+	if err := upsertLink(ctx, r.db, link); err != nil {
+		return fmt.Errorf("upsert link: %w", err)
+	}
+	return nil
+}
+
+func (r *GraphSQLiteRepository) UpsertLinks(ctx context.Context, links []*domain.Link) error {
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		for _, link := range links {
+			if err := upsertLink(ctx, tx, link); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("upsert links: %w", err)
+	}
+	return nil
+}
+
+func upsertLink(ctx context.Context, q queryRower, link *domain.Link) error {
+	row := q.QueryRowContext(ctx, upsertLinkQuery,
+		uuid.New(), link.URL, formatTime(link.RetrievedAt), link.StatusCode, link.ContentType, link.ContentLength,
+		link.ETag, link.LastModified, link.FailureCount, link.LastError,
+	)
+	stored, err := scanLink(row)
+	if err != nil {
+		return err
+	}
+	*link = *stored
+	return nil
+}
+
+var findLinkQuery = `
+	SELECT ` + linkColumns + ` FROM links WHERE id=?
+`
+
+func (r *GraphSQLiteRepository) FindLink(ctx context.Context, id uuid.UUID) (*domain.Link, error) {
+	link, err := scanLink(r.db.QueryRowContext(ctx, findLinkQuery, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("find link: %w", repository.GraphErrNotFound)
+		}
+		return nil, fmt.Errorf("find link: %w", err)
+	}
+	return link, nil
+}
+
+var findLinkByURLQuery = `
+	SELECT ` + linkColumns + ` FROM links WHERE url=?
+`
+
+func (r *GraphSQLiteRepository) FindLinkByURL(ctx context.Context, url string) (*domain.Link, error) {
+	link, err := scanLink(r.db.QueryRowContext(ctx, findLinkByURLQuery, url))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("find link by URL: %w", repository.GraphErrNotFound)
+		}
+		return nil, fmt.Errorf("find link by URL: %w", err)
+	}
+	return link, nil
+}
+
+var findLinksQueryPrefix = `SELECT ` + linkColumns + ` FROM links WHERE id IN `
+
+func (r *GraphSQLiteRepository) FindLinks(ctx context.Context, ids []uuid.UUID) ([]*domain.Link, error) {
+	found := make(map[uuid.UUID]*domain.Link, len(ids))
+	for start := 0; start < len(ids); start += maxBatchParams {
+		chunk := ids[start:min(start+maxBatchParams, len(ids))]
+		args := make([]any, len(chunk))
+		for i, id := range chunk {
+			args[i] = id
+		}
+
+		rows, err := r.db.QueryContext(ctx, findLinksQueryPrefix+placeholders(len(chunk)), args...)
+		if err != nil {
+			return nil, fmt.Errorf("find links: %w", err)
+		}
+		it := newLinkIterator(rows)
+		for it.Next() {
+			link := it.Link()
+			found[link.ID] = link
+		}
+		if err = it.Error(); err != nil {
+			_ = it.Close()
+			return nil, fmt.Errorf("find links: %w", err)
+		}
+		if err = it.Close(); err != nil {
+			return nil, fmt.Errorf("find links: %w", err)
+		}
+	}
+
+	// Return the links in the order they were requested.
+	list := make([]*domain.Link, 0, len(found))
+	for _, id := range ids {
+		if link := found[id]; link != nil {
+			lCopy := *link
+			list = append(list, &lCopy)
+		}
+	}
+	return list, nil
+}
+
+// Edges pointing to or from the link are removed by the ON DELETE CASCADE
+// constraints of the edges table.
+var removeLinkQuery = `
+	DELETE FROM links WHERE id=?
+`
+
+func (r *GraphSQLiteRepository) RemoveLink(ctx context.Context, id uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx, removeLinkQuery, id)
+	if err != nil {
+		return fmt.Errorf("remove link: %w", err)
+	}
+	if err = ensureAffected(res); err != nil {
+		return fmt.Errorf("remove link: %w", err)
+	}
+	return nil
+}
+
+// touchLinkQuery only replaces the fetch outcome of a link with a newer one,
+// like upsertLinkQuery.
+var touchLinkQuery = `
+	UPDATE links SET
+	    retrieved_at=MAX(retrieved_at, ?2),
+	    failure_count=IIF(?2 > retrieved_at, 0, failure_count),
+	    last_error=IIF(?2 > retrieved_at, '', last_error)
+	WHERE id=?1
+`
+
+var touchOutEdgesQuery = `
+	UPDATE edges SET updated_at=? WHERE src=?
+`
+
+func (r *GraphSQLiteRepository) TouchLink(ctx context.Context, id uuid.UUID, retrievedAt time.Time) error {
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, touchLinkQuery, id, formatTime(retrievedAt))
+		if err != nil {
+			return err
+		}
+		if err = ensureAffected(res); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, touchOutEdgesQuery, formatTime(time.Now()), id)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("touch link: %w", err)
+	}
+	return nil
+}
+
+var canonicalIDQuery = `
+	SELECT canonical_id FROM links WHERE id=?
+`
+
+var clearCanonicalIDQuery = `
+	UPDATE links SET canonical_id=NULL WHERE id=?
+`
+
+// The merge queries below take the merged link as ?1 and its canonical link
+// as ?2.
+var setCanonicalIDQuery = `
+	UPDATE links SET canonical_id=?2 WHERE id=?1
+`
+
+// Duplicates of the merged link follow it to its canonical link, except the
+// canonical link itself if it used to duplicate the merged link.
+var moveDuplicatesQuery = `
+	UPDATE links SET canonical_id=IIF(id=?2, NULL, ?2) WHERE canonical_id=?1
+`
+
+var moveOutEdgesQuery = `
+	UPDATE edges SET src=?2
+	WHERE src=?1 AND dst <> ?1 AND dst <> ?2 AND
+	    NOT EXISTS (SELECT 1 FROM edges AS e WHERE e.src=?2 AND e.dst=edges.dst AND e.kind=edges.kind)
+`
+
+var moveInEdgesQuery = `
+	UPDATE edges SET dst=?2
+	WHERE dst=?1 AND src <> ?1 AND src <> ?2 AND
+	    NOT EXISTS (SELECT 1 FROM edges AS e WHERE e.dst=?2 AND e.src=edges.src AND e.kind=edges.kind)
+`
+
+// Edges that could not be moved are dropped.
+var removeLinkEdgesQuery = `
+	DELETE FROM edges WHERE src=?1 OR dst=?1
+`
+
+func (r *GraphSQLiteRepository) MergeLink(ctx context.Context, id, canonicalID uuid.UUID) error {
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		// Both links must exist; sql.ErrNoRows is reported as not found.
+		var linkCanonicalID, canonicalCanonicalID uuid.NullUUID
+		if err := tx.QueryRowContext(ctx, canonicalIDQuery, id).Scan(&linkCanonicalID); err != nil {
+			return err
+		}
+		if err := tx.QueryRowContext(ctx, canonicalIDQuery, canonicalID).Scan(&canonicalCanonicalID); err != nil {
+			return err
+		}
+		if id == canonicalID {
+			_, err := tx.ExecContext(ctx, clearCanonicalIDQuery, id)
+			return err
+		}
+
+		target := canonicalID
+		if c := canonicalCanonicalID; c.Valid && c.UUID != id {
+			target = c.UUID
+		}
+		for _, query := range []string{moveDuplicatesQuery, setCanonicalIDQuery, moveOutEdgesQuery, moveInEdgesQuery, removeLinkEdgesQuery} {
+			if _, err := tx.ExecContext(ctx, query, id, target); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.GraphErrNotFound
+		}
+		return fmt.Errorf("merge link: %w", err)
+	}
+	return nil
+}
+
+var linksInPartitionQuery = `
+	SELECT ` + linkColumns + ` FROM links WHERE id >= ? AND (? OR id < ?) AND retrieved_at < ?
+`
+
+func (r *GraphSQLiteRepository) Links(ctx context.Context, ids repository.IDRange, retrievedBefore time.Time) (repository.LinkIterator, error) {
+	rows, err := r.db.QueryContext(ctx, linksInPartitionQuery, ids.From, ids.Unbounded(), ids.To, formatTime(retrievedBefore))
+	if err != nil {
+		return nil, fmt.Errorf("links: %w", err)
+	}
+	return newLinkIterator(rows), nil
+}
+
+// edgeColumns lists the edges columns read by scanEdge, in order.
+const edgeColumns = `id, src, dst, updated_at, anchor_text, rel, kind`
+
+// upsertEdgeQuery refreshes an existing edge with the way it was discovered
+// most recently.
+var upsertEdgeQuery = `
+	INSERT INTO edges (id, src, dst, updated_at, anchor_text, rel, kind) VALUES (?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (src, dst, kind) DO UPDATE SET
+	    updated_at=excluded.updated_at,
+	    anchor_text=excluded.anchor_text,
+	    rel=excluded.rel
+	RETURNING ` + edgeColumns
+
+func (r *GraphSQLiteRepository) UpsertEdge(ctx context.Context, edge *domain.Edge) error {
+	if err := upsertEdge(ctx, r.db, edge); err != nil {
+		if isForeignKeyViolationError(err) {
+			err = repository.GraphErrUnknownEdgeLinks
+		}
+		return fmt.Errorf("upsert edge: %w", err)
+	}
+	return nil
+}
+
+func (r *GraphSQLiteRepository) UpsertEdges(ctx context.Context, edges []*domain.Edge) error {
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		for _, edge := range edges {
+			if err := upsertEdge(ctx, tx, edge); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if isForeignKeyViolationError(err) {
+			err = repository.GraphErrUnknownEdgeLinks
+		}
+		return fmt.Errorf("upsert edges: %w", err)
+	}
+	return nil
+}
+
+func upsertEdge(ctx context.Context, q queryRower, edge *domain.Edge) error {
+	row := q.QueryRowContext(ctx, upsertEdgeQuery, uuid.New(), edge.Src, edge.Dst, formatTime(time.Now()), edge.AnchorText, edge.Rel, edge.Kind)
+	stored, err := scanEdge(row)
+	if err != nil {
+		return err
+	}
+	*edge = *stored
+	return nil
+}
+
+func isForeignKeyViolationError(err error) bool {
+	var sqliteErr *sqlite.Error
+	valid := errors.As(err, &sqliteErr)
+	if !valid {
+		return false
+	}
+	return sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY
+}
+
+var removeEdgeQuery = `
+	DELETE FROM edges WHERE id=?
+`
+
+func (r *GraphSQLiteRepository) RemoveEdge(ctx context.Context, id uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx, removeEdgeQuery, id)
+	if err != nil {
+		return fmt.Errorf("remove edge: %w", err)
+	}
+	if err = ensureAffected(res); err != nil {
+		return fmt.Errorf("remove edge: %w", err)
+	}
+	return nil
+}
+
+var removeStaleEdgesQuery = `
+	DELETE FROM edges WHERE src=? AND updated_at < ?
+`
+
+func (r *GraphSQLiteRepository) RemoveStaleEdges(ctx context.Context, fromID uuid.UUID, updatedBefore time.Time) error {
+	_, err := r.db.ExecContext(ctx, removeStaleEdgesQuery, fromID, formatTime(updatedBefore))
+	if err != nil {
+		return fmt.Errorf("remove stale edges: %w", err)
+	}
+	return nil
+}
+
+var edgesInPartitionQuery = `
+	SELECT ` + edgeColumns + `
+	FROM edges
+	WHERE
+	    src >= ? AND
+	    (? OR src < ?) AND
+	    updated_at < ?
+`
+
+func (r *GraphSQLiteRepository) Edges(ctx context.Context, srcIDs repository.IDRange, updatedBefore time.Time) (repository.EdgeIterator, error) {
+	rows, err := r.db.QueryContext(ctx, edgesInPartitionQuery, srcIDs.From, srcIDs.Unbounded(), srcIDs.To, formatTime(updatedBefore))
+	if err != nil {
+		return nil, fmt.Errorf("edges: %w", err)
+	}
+	return newEdgeIterator(rows), nil
+}
+
+var inEdgesQuery = `
+	SELECT ` + edgeColumns + ` FROM edges WHERE dst=?
+`
+
+func (r *GraphSQLiteRepository) InEdges(ctx context.Context, linkID uuid.UUID) (repository.EdgeIterator, error) {
+	rows, err := r.db.QueryContext(ctx, inEdgesQuery, linkID)
+	if err != nil {
+		return nil, fmt.Errorf("in edges: %w", err)
+	}
+	return newEdgeIterator(rows), nil
+}
+
+var outEdgesQuery = `
+	SELECT ` + edgeColumns + ` FROM edges WHERE src=?
+`
+
+func (r *GraphSQLiteRepository) OutEdges(ctx context.Context, linkID uuid.UUID) (repository.EdgeIterator, error) {
+	rows, err := r.db.QueryContext(ctx, outEdgesQuery, linkID)
+	if err != nil {
+		return nil, fmt.Errorf("out edges: %w", err)
+	}
+	return newEdgeIterator(rows), nil
+}
+
+var degreeQuery = `
+	SELECT
+	    (SELECT count(*) FROM edges WHERE dst=links.id),
+	    (SELECT count(*) FROM edges WHERE src=links.id)
+	FROM links WHERE id=?
+`
+
+func (r *GraphSQLiteRepository) Degree(ctx context.Context, linkID uuid.UUID) (int, int, error) {
+	var inDegree, outDegree int
+	row := r.db.QueryRowContext(ctx, degreeQuery, linkID)
+	if err := row.Scan(&inDegree, &outDegree); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, 0, fmt.Errorf("degree: %w", repository.GraphErrNotFound)
+		}
+		return 0, 0, fmt.Errorf("degree: %w", err)
+	}
+	return inDegree, outDegree, nil
+}
+
+// inTx runs fn inside a transaction which is committed if fn succeeds and
+// rolled back otherwise.
+func (r *GraphSQLiteRepository) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err = fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// ensureAffected returns repository.GraphErrNotFound if res reports that no
+// rows were affected.
+func ensureAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return repository.GraphErrNotFound
+	}
+	return nil
+}
+
+// placeholders returns a parenthesized list of n positional parameters.
+func placeholders(n int) string {
+	return "(" + strings.TrimSuffix(strings.Repeat("?, ", n), ", ") + ")"
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+func parseTime(s string) (time.Time, error) {
+	t, err := time.Parse(timeLayout, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
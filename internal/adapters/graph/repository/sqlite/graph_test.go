@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/graphtest"
+	"github.com/stretchr/testify/suite"
+	"path/filepath"
+	"testing"
+)
+
+func Test(t *testing.T) {
+	suite.Run(t, new(GraphSQLiteRepositoryTestSuite))
+}
+
+type GraphSQLiteRepositoryTestSuite struct {
+	suite.Suite
+	base graphtest.SuiteBase
+	db   *sql.DB
+}
+
+func (s *GraphSQLiteRepositoryTestSuite) SetupSuite() {
+	db, err := Open(context.TODO(), filepath.Join(s.T().TempDir(), "graph.db"))
+	if err != nil {
+		s.FailNowf("", "failed to open database %v", err)
+	}
+	s.db = db
+	s.base.SetGraph(NewGraphSQLiteRepository(db))
+}
+func (s *GraphSQLiteRepositoryTestSuite) SetupTest() {
+	s.flushDB()
+}
+
+func (s *GraphSQLiteRepositoryTestSuite) flushDB() {
+	_, err := s.db.Exec("DELETE FROM edges")
+	s.Nil(err)
+	_, err = s.db.Exec("DELETE FROM links")
+	s.Nil(err)
+}
+func (s *GraphSQLiteRepositoryTestSuite) TearDownSuite() {
+	if s.db != nil {
+		s.Nil(s.db.Close())
+	}
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestUpsertLink() {
+	s.base.TestUpsertLink(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestUpsertLinks() {
+	s.base.TestUpsertLinks(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestLinkFetchMetadata() {
+	s.base.TestLinkFetchMetadata(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestTouchLink() {
+	s.base.TestTouchLink(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestMergeLink() {
+	s.base.TestMergeLink(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestFindLink() {
+	s.base.TestFindLink(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestFindLinkByURL() {
+	s.base.TestFindLinkByURL(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestFindLinks() {
+	s.base.TestFindLinks(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestRemoveLink() {
+	s.base.TestRemoveLink(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestConcurrentLinkIterators() {
+	s.base.TestConcurrentLinkIterators(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestLinkIteratorTimeFilter() {
+	s.base.TestLinkIteratorTimeFilter(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestPartitionedLinkIterators() {
+	s.base.TestPartitionedLinkIterators(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestLinkIteratorContextCancellation() {
+	s.base.TestLinkIteratorContextCancellation(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestLinkRangeBoundaries() {
+	s.base.TestLinkRangeBoundaries(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestUpsertEdge() {
+	s.base.TestUpsertEdge(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestUpsertEdges() {
+	s.base.TestUpsertEdges(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestEdgeAnchor() {
+	s.base.TestEdgeAnchor(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestRedirectEdge() {
+	s.base.TestRedirectEdge(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestRemoveEdge() {
+	s.base.TestRemoveEdge(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestInOutEdges() {
+	s.base.TestInOutEdges(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestStats() {
+	s.base.TestStats(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestConcurrentEdgeIterators() {
+	s.base.TestConcurrentEdgeIterators(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestEdgeIteratorTimeFilter() {
+	s.base.TestEdgeIteratorTimeFilter(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestPartitionedEdgeIterators() {
+	s.base.TestPartitionedEdgeIterators(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestRemoveStaleEdges() {
+	s.base.TestRemoveStaleEdges(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestEdgeIteratorContextCancellation() {
+	s.base.TestEdgeIteratorContextCancellation(s.T())
+}
+func (s *GraphSQLiteRepositoryTestSuite) TestEdgeRangeBoundaries() {
+	s.base.TestEdgeRangeBoundaries(s.T())
+}
@@ -0,0 +1,140 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+)
+
+type linkIterator struct {
+	rows        *sql.Rows
+	lastErr     error
+	latchedLink *domain.Link
+}
+
+func newLinkIterator(rows *sql.Rows) repository.LinkIterator {
+	return &linkIterator{rows: rows}
+}
+
+func (i *linkIterator) Next() bool {
+	if i.lastErr != nil {
+		return false
+	}
+	if !i.rows.Next() {
+		i.lastErr = i.rows.Err()
+		return false
+	}
+
+	link, err := scanLink(i.rows)
+	if err != nil {
+		i.lastErr = err
+		return false
+	}
+
+	i.latchedLink = link
+	return true
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanLink reads a link from a row holding the linkColumns.
+func scanLink(row rowScanner) (*domain.Link, error) {
+	var (
+		retrievedAt string
+		canonicalID uuid.NullUUID
+	)
+	link := new(domain.Link)
+	err := row.Scan(
+		&link.ID, &link.URL, &retrievedAt, &link.StatusCode, &link.ContentType, &link.ContentLength,
+		&link.ETag, &link.LastModified, &link.FailureCount, &link.LastError, &canonicalID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	link.CanonicalID = canonicalID.UUID
+	if link.RetrievedAt, err = parseTime(retrievedAt); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+func (i *linkIterator) Error() error {
+	return i.lastErr
+}
+
+func (i *linkIterator) Close() error {
+	err := i.rows.Close()
+	if err != nil {
+		return fmt.Errorf("link iterator: %w", err)
+	}
+	return nil
+}
+
+func (i *linkIterator) Link() *domain.Link {
+	return i.latchedLink
+}
+
+type edgeIterator struct {
+	rows        *sql.Rows
+	lastErr     error
+	latchedEdge *domain.Edge
+}
+
+func newEdgeIterator(rows *sql.Rows) repository.EdgeIterator {
+	return &edgeIterator{rows: rows}
+}
+
+func (i *edgeIterator) Next() bool {
+	if i.lastErr != nil {
+		return false
+	}
+	if !i.rows.Next() {
+		i.lastErr = i.rows.Err()
+		return false
+	}
+
+	edge, err := scanEdge(i.rows)
+	if err != nil {
+		i.lastErr = err
+		return false
+	}
+
+	i.latchedEdge = edge
+	return true
+}
+
+// scanEdge reads an edge from a row holding the edgeColumns.
+func scanEdge(row rowScanner) (*domain.Edge, error) {
+	var updatedAt string
+	edge := new(domain.Edge)
+	if err := row.Scan(&edge.ID, &edge.Src, &edge.Dst, &updatedAt, &edge.AnchorText, &edge.Rel, &edge.Kind); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if edge.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return edge, nil
+}
+
+func (i *edgeIterator) Error() error {
+	return i.lastErr
+}
+
+func (i *edgeIterator) Close() error {
+	err := i.rows.Close()
+	if err != nil {
+		return fmt.Errorf("edge iterator: %w", err)
+	}
+	return nil
+}
+
+func (i *edgeIterator) Edge() *domain.Edge {
+	return i.latchedEdge
+}
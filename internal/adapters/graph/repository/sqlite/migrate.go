@@ -0,0 +1,33 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/repository/migrations"
+)
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+// Migrations returns the migrations of the SQLite graph schema, so the
+// database can be managed with migrations.NewWithSource.
+func Migrations() (fs.FS, error) {
+	return fs.Sub(migrationFS, "migrations")
+}
+
+// migrate applies any pending migration to db.
+func migrate(ctx context.Context, db *sql.DB) error {
+	src, err := Migrations()
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	m, err := migrations.NewWithSource(db, src)
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	return m.Up(ctx)
+}
@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/repository/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAppliesMigrations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.db")
+	db, err := Open(context.TODO(), path)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	assertMigrated(t, db)
+}
+
+func assertMigrated(t *testing.T, db *sql.DB) {
+	status := migrationStatus(t, db)
+	assert.NotZero(t, status.Version)
+	assert.False(t, status.Dirty)
+	assert.Empty(t, status.Pending)
+}
+
+func migrationStatus(t *testing.T, db *sql.DB) *migrations.Status {
+	src, err := Migrations()
+	require.NoError(t, err)
+	m, err := migrations.NewWithSource(db, src)
+	require.NoError(t, err)
+	status, err := m.Status(context.TODO())
+	require.NoError(t, err)
+	return status
+}
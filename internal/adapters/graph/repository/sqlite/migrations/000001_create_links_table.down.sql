@@ -0,0 +1 @@
+DROP TABLE IF EXISTS links;
\ No newline at end of file
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS links
+(
+    id           TEXT PRIMARY KEY,
+    url          TEXT NOT NULL UNIQUE,
+    retrieved_at TEXT NOT NULL
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS edges;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS edges
+(
+    id         TEXT PRIMARY KEY,
+    src        TEXT NOT NULL REFERENCES links (id) ON DELETE CASCADE,
+    dst        TEXT NOT NULL REFERENCES links (id) ON DELETE CASCADE,
+    updated_at TEXT NOT NULL,
+    CONSTRAINT edge_links UNIQUE (src, dst)
+);
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE links DROP COLUMN status_code;
+ALTER TABLE links DROP COLUMN content_type;
+ALTER TABLE links DROP COLUMN content_length;
+ALTER TABLE links DROP COLUMN etag;
+ALTER TABLE links DROP COLUMN last_modified;
+ALTER TABLE links DROP COLUMN failure_count;
+ALTER TABLE links DROP COLUMN last_error;
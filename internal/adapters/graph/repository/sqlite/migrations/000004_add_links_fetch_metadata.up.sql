@@ -0,0 +1,7 @@
+ALTER TABLE links ADD COLUMN status_code INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE links ADD COLUMN content_type TEXT NOT NULL DEFAULT '';
+ALTER TABLE links ADD COLUMN content_length INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE links ADD COLUMN etag TEXT NOT NULL DEFAULT '';
+ALTER TABLE links ADD COLUMN last_modified TEXT NOT NULL DEFAULT '';
+ALTER TABLE links ADD COLUMN failure_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE links ADD COLUMN last_error TEXT NOT NULL DEFAULT '';
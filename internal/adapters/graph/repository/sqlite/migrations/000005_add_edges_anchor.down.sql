@@ -0,0 +1,2 @@
+ALTER TABLE edges DROP COLUMN anchor_text;
+ALTER TABLE edges DROP COLUMN rel;
@@ -0,0 +1,2 @@
+ALTER TABLE edges ADD COLUMN anchor_text TEXT NOT NULL DEFAULT '';
+ALTER TABLE edges ADD COLUMN rel INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE edges ADD COLUMN kind INTEGER NOT NULL DEFAULT 0;
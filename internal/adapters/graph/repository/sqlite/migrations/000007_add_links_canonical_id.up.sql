@@ -0,0 +1 @@
+ALTER TABLE links ADD COLUMN canonical_id TEXT REFERENCES links (id) ON DELETE SET NULL;
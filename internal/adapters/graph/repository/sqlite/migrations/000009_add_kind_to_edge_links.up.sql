@@ -0,0 +1,16 @@
+CREATE TABLE edges_new
+(
+    id          TEXT PRIMARY KEY,
+    src         TEXT NOT NULL REFERENCES links (id) ON DELETE CASCADE,
+    dst         TEXT NOT NULL REFERENCES links (id) ON DELETE CASCADE,
+    updated_at  TEXT NOT NULL,
+    anchor_text TEXT NOT NULL DEFAULT '',
+    rel         INTEGER NOT NULL DEFAULT 0,
+    kind        INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT edge_links UNIQUE (src, dst, kind)
+);
+INSERT INTO edges_new (id, src, dst, updated_at, anchor_text, rel, kind)
+SELECT id, src, dst, updated_at, anchor_text, rel, kind FROM edges;
+DROP TABLE edges;
+ALTER TABLE edges_new RENAME TO edges;
+CREATE INDEX IF NOT EXISTS edges_dst_idx ON edges (dst);
@@ -0,0 +1,351 @@
+package linkgraph
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/linkgraph/linkgraphpb"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Client is a repository.GraphRepository backed by a remote LinkGraph
+// gRPC service.
+type Client struct {
+	cli linkgraphpb.LinkGraphClient
+}
+
+// NewClient creates a Client that issues its requests over conn.
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{cli: linkgraphpb.NewLinkGraphClient(conn)}
+}
+
+// UpsertLink implements repository.GraphRepository.
+func (c *Client) UpsertLink(ctx context.Context, link *domain.Link) error {
+	res, err := c.cli.UpsertLink(ctx, linkToProto(link))
+	if err != nil {
+		return fromStatus(err)
+	}
+	return copyLink(link, res)
+}
+
+// UpsertLinks implements repository.GraphRepository.
+func (c *Client) UpsertLinks(ctx context.Context, links []*domain.Link) error {
+	res, err := c.cli.UpsertLinks(ctx, linkBatch(links))
+	if err != nil {
+		return fromStatus(err)
+	}
+	for i, msg := range res.GetLinks() {
+		if err = copyLink(links[i], msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindLink implements repository.GraphRepository.
+func (c *Client) FindLink(ctx context.Context, id uuid.UUID) (*domain.Link, error) {
+	res, err := c.cli.FindLink(ctx, &linkgraphpb.LinkID{Id: idToProto(id)})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return linkFromProto(res)
+}
+
+// FindLinkByURL implements repository.GraphRepository.
+func (c *Client) FindLinkByURL(ctx context.Context, url string) (*domain.Link, error) {
+	res, err := c.cli.FindLinkByURL(ctx, &linkgraphpb.FindLinkByURLRequest{Url: url})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return linkFromProto(res)
+}
+
+// FindLinks implements repository.GraphRepository.
+func (c *Client) FindLinks(ctx context.Context, ids []uuid.UUID) ([]*domain.Link, error) {
+	req := &linkgraphpb.LinkIDs{Ids: make([][]byte, len(ids))}
+	for i, id := range ids {
+		req.Ids[i] = idToProto(id)
+	}
+	res, err := c.cli.FindLinks(ctx, req)
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	links := make([]*domain.Link, len(res.GetLinks()))
+	for i, msg := range res.GetLinks() {
+		if links[i], err = linkFromProto(msg); err != nil {
+			return nil, err
+		}
+	}
+	return links, nil
+}
+
+// TouchLink implements repository.GraphRepository.
+func (c *Client) TouchLink(ctx context.Context, id uuid.UUID, retrievedAt time.Time) error {
+	_, err := c.cli.TouchLink(ctx, &linkgraphpb.TouchLinkRequest{
+		Id:          idToProto(id),
+		RetrievedAt: timeToProto(retrievedAt),
+	})
+	return fromStatus(err)
+}
+
+// RemoveLink implements repository.GraphRepository.
+func (c *Client) RemoveLink(ctx context.Context, id uuid.UUID) error {
+	_, err := c.cli.RemoveLink(ctx, &linkgraphpb.LinkID{Id: idToProto(id)})
+	return fromStatus(err)
+}
+
+// MergeLink implements repository.GraphRepository.
+func (c *Client) MergeLink(ctx context.Context, id, canonicalID uuid.UUID) error {
+	_, err := c.cli.MergeLink(ctx, &linkgraphpb.MergeLinkRequest{
+		Id:          idToProto(id),
+		CanonicalId: idToProto(canonicalID),
+	})
+	return fromStatus(err)
+}
+
+// UpsertEdge implements repository.GraphRepository.
+func (c *Client) UpsertEdge(ctx context.Context, edge *domain.Edge) error {
+	res, err := c.cli.UpsertEdge(ctx, edgeToProto(edge))
+	if err != nil {
+		return fromStatus(err)
+	}
+	return copyEdge(edge, res)
+}
+
+// UpsertEdges implements repository.GraphRepository.
+func (c *Client) UpsertEdges(ctx context.Context, edges []*domain.Edge) error {
+	req := &linkgraphpb.EdgeBatch{Edges: make([]*linkgraphpb.Edge, len(edges))}
+	for i, edge := range edges {
+		req.Edges[i] = edgeToProto(edge)
+	}
+	res, err := c.cli.UpsertEdges(ctx, req)
+	if err != nil {
+		return fromStatus(err)
+	}
+	for i, msg := range res.GetEdges() {
+		if err = copyEdge(edges[i], msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveEdge implements repository.GraphRepository.
+func (c *Client) RemoveEdge(ctx context.Context, id uuid.UUID) error {
+	_, err := c.cli.RemoveEdge(ctx, &linkgraphpb.EdgeID{Id: idToProto(id)})
+	return fromStatus(err)
+}
+
+// RemoveStaleEdges implements repository.GraphRepository.
+func (c *Client) RemoveStaleEdges(ctx context.Context, fromID uuid.UUID, updatedBefore time.Time) error {
+	_, err := c.cli.RemoveStaleEdges(ctx, &linkgraphpb.RemoveStaleEdgesRequest{
+		FromId:        idToProto(fromID),
+		UpdatedBefore: timeToProto(updatedBefore),
+	})
+	return fromStatus(err)
+}
+
+// Links implements repository.GraphRepository.
+func (c *Client) Links(ctx context.Context, ids repository.IDRange, retrievedBefore time.Time) (repository.LinkIterator, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream, err := c.cli.Links(streamCtx, rangeToProto(ids, retrievedBefore))
+	if err != nil {
+		cancel()
+		return nil, fromStatus(err)
+	}
+	return &linkIterator{streamIterator: streamIterator[linkgraphpb.Link]{ctx: ctx, cancel: cancel, recv: stream.Recv}}, nil
+}
+
+// Edges implements repository.GraphRepository.
+func (c *Client) Edges(ctx context.Context, srcIDs repository.IDRange, updatedBefore time.Time) (repository.EdgeIterator, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream, err := c.cli.Edges(streamCtx, rangeToProto(srcIDs, updatedBefore))
+	if err != nil {
+		cancel()
+		return nil, fromStatus(err)
+	}
+	return &edgeIterator{streamIterator: streamIterator[linkgraphpb.Edge]{ctx: ctx, cancel: cancel, recv: stream.Recv}}, nil
+}
+
+// InEdges implements repository.GraphRepository.
+func (c *Client) InEdges(ctx context.Context, linkID uuid.UUID) (repository.EdgeIterator, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream, err := c.cli.InEdges(streamCtx, &linkgraphpb.LinkID{Id: idToProto(linkID)})
+	if err != nil {
+		cancel()
+		return nil, fromStatus(err)
+	}
+	return &edgeIterator{streamIterator: streamIterator[linkgraphpb.Edge]{ctx: ctx, cancel: cancel, recv: stream.Recv}}, nil
+}
+
+// OutEdges implements repository.GraphRepository.
+func (c *Client) OutEdges(ctx context.Context, linkID uuid.UUID) (repository.EdgeIterator, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream, err := c.cli.OutEdges(streamCtx, &linkgraphpb.LinkID{Id: idToProto(linkID)})
+	if err != nil {
+		cancel()
+		return nil, fromStatus(err)
+	}
+	return &edgeIterator{streamIterator: streamIterator[linkgraphpb.Edge]{ctx: ctx, cancel: cancel, recv: stream.Recv}}, nil
+}
+
+// Degree implements repository.GraphRepository.
+func (c *Client) Degree(ctx context.Context, linkID uuid.UUID) (int, int, error) {
+	res, err := c.cli.Degree(ctx, &linkgraphpb.LinkID{Id: idToProto(linkID)})
+	if err != nil {
+		return 0, 0, fromStatus(err)
+	}
+	return int(res.GetInDegree()), int(res.GetOutDegree()), nil
+}
+
+// Stats implements repository.GraphRepository.
+func (c *Client) Stats(ctx context.Context, opts repository.GraphStatsOptions) (*repository.GraphStats, error) {
+	res, err := c.cli.Stats(ctx, &linkgraphpb.StatsRequest{
+		RetrievedWithin: durationpb.New(opts.RetrievedWithin),
+		NumPartitions:   int32(opts.NumPartitions),
+	})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	stats := &repository.GraphStats{
+		Links:                  int(res.GetLinks()),
+		Edges:                  int(res.GetEdges()),
+		UnretrievedLinks:       int(res.GetUnretrievedLinks()),
+		RecentlyRetrievedLinks: int(res.GetRecentlyRetrievedLinks()),
+		EdgesPerPartition:      make([]int, len(res.GetEdgesPerPartition())),
+		InDegreeHistogram:      make(map[int]int, len(res.GetInDegreeHistogram())),
+		OutDegreeHistogram:     make(map[int]int, len(res.GetOutDegreeHistogram())),
+	}
+	for i, n := range res.GetEdgesPerPartition() {
+		stats.EdgesPerPartition[i] = int(n)
+	}
+	for degree, n := range res.GetInDegreeHistogram() {
+		stats.InDegreeHistogram[int(degree)] = int(n)
+	}
+	for degree, n := range res.GetOutDegreeHistogram() {
+		stats.OutDegreeHistogram[int(degree)] = int(n)
+	}
+	return stats, nil
+}
+
+func rangeToProto(ids repository.IDRange, before time.Time) *linkgraphpb.RangeRequest {
+	return &linkgraphpb.RangeRequest{
+		From:   idToProto(ids.From),
+		To:     idToProto(ids.To),
+		Before: timeToProto(before),
+	}
+}
+
+// copyLink overwrites link with the link returned by the server.
+func copyLink(link *domain.Link, msg *linkgraphpb.Link) error {
+	res, err := linkFromProto(msg)
+	if err != nil {
+		return err
+	}
+	*link = *res
+	return nil
+}
+
+// copyEdge overwrites edge with the edge returned by the server.
+func copyEdge(edge *domain.Edge, msg *linkgraphpb.Edge) error {
+	res, err := edgeFromProto(msg)
+	if err != nil {
+		return err
+	}
+	*edge = *res
+	return nil
+}
+
+// streamIterator receives the messages of a server stream one at a time.
+// Closing it cancels the stream.
+type streamIterator[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	recv   func() (*T, error)
+
+	cur     *T
+	lastErr error
+}
+
+// Next implements repository.Iterator.
+func (i *streamIterator[T]) Next() bool {
+	if i.lastErr != nil {
+		return false
+	}
+	msg, err := i.recv()
+	switch {
+	case errors.Is(err, io.EOF):
+		return false
+	case err != nil:
+		// The stream reports a cancelled ctx with its own status; report
+		// the context error as the other repositories do.
+		if i.lastErr = i.ctx.Err(); i.lastErr == nil {
+			i.lastErr = fromStatus(err)
+		}
+		return false
+	}
+	i.cur = msg
+	return true
+}
+
+// Error implements repository.Iterator.
+func (i *streamIterator[T]) Error() error {
+	return i.lastErr
+}
+
+// Close implements repository.Iterator.
+func (i *streamIterator[T]) Close() error {
+	i.cancel()
+	return nil
+}
+
+// linkIterator is a repository.LinkIterator receiving links from the server.
+type linkIterator struct {
+	streamIterator[linkgraphpb.Link]
+	link *domain.Link
+}
+
+// Next implements repository.LinkIterator.
+func (i *linkIterator) Next() bool {
+	if !i.streamIterator.Next() {
+		return false
+	}
+	if i.link, i.lastErr = linkFromProto(i.cur); i.lastErr != nil {
+		return false
+	}
+	return true
+}
+
+// Link implements repository.LinkIterator.
+func (i *linkIterator) Link() *domain.Link {
+	return i.link
+}
+
+// edgeIterator is a repository.EdgeIterator receiving edges from the server.
+type edgeIterator struct {
+	streamIterator[linkgraphpb.Edge]
+	edge *domain.Edge
+}
+
+// Next implements repository.EdgeIterator.
+func (i *edgeIterator) Next() bool {
+	if !i.streamIterator.Next() {
+		return false
+	}
+	if i.edge, i.lastErr = edgeFromProto(i.cur); i.lastErr != nil {
+		return false
+	}
+	return true
+}
+
+// Edge implements repository.EdgeIterator.
+func (i *edgeIterator) Edge() *domain.Edge {
+	return i.edge
+}
@@ -0,0 +1,158 @@
+package linkgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/linkgraph/linkgraphpb"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// idToProto encodes id as its 16 bytes, or as no bytes if it is uuid.Nil.
+func idToProto(id uuid.UUID) []byte {
+	if id == uuid.Nil {
+		return nil
+	}
+	return id[:]
+}
+
+// idFromProto decodes an ID encoded by idToProto.
+func idFromProto(b []byte) (uuid.UUID, error) {
+	if len(b) == 0 {
+		return uuid.Nil, nil
+	}
+	id, err := uuid.FromBytes(b)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("decode id: %w", err)
+	}
+	return id, nil
+}
+
+// timeToProto encodes t, leaving the zero time unset.
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// timeFromProto decodes a time encoded by timeToProto.
+func timeFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func linkToProto(link *domain.Link) *linkgraphpb.Link {
+	return &linkgraphpb.Link{
+		Id:            idToProto(link.ID),
+		Url:           link.URL,
+		RetrievedAt:   timeToProto(link.RetrievedAt),
+		CanonicalId:   idToProto(link.CanonicalID),
+		StatusCode:    int32(link.StatusCode),
+		ContentType:   link.ContentType,
+		ContentLength: link.ContentLength,
+		Etag:          link.ETag,
+		LastModified:  link.LastModified,
+		FailureCount:  int32(link.FailureCount),
+		LastError:     link.LastError,
+	}
+}
+
+func linkFromProto(msg *linkgraphpb.Link) (*domain.Link, error) {
+	id, err := idFromProto(msg.GetId())
+	if err != nil {
+		return nil, err
+	}
+	canonicalID, err := idFromProto(msg.GetCanonicalId())
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Link{
+		ID:            id,
+		URL:           msg.GetUrl(),
+		RetrievedAt:   timeFromProto(msg.GetRetrievedAt()),
+		CanonicalID:   canonicalID,
+		StatusCode:    int(msg.GetStatusCode()),
+		ContentType:   msg.GetContentType(),
+		ContentLength: msg.GetContentLength(),
+		ETag:          msg.GetEtag(),
+		LastModified:  msg.GetLastModified(),
+		FailureCount:  int(msg.GetFailureCount()),
+		LastError:     msg.GetLastError(),
+	}, nil
+}
+
+func edgeToProto(edge *domain.Edge) *linkgraphpb.Edge {
+	return &linkgraphpb.Edge{
+		Id:         idToProto(edge.ID),
+		Src:        idToProto(edge.Src),
+		Dst:        idToProto(edge.Dst),
+		UpdatedAt:  timeToProto(edge.UpdatedAt),
+		AnchorText: edge.AnchorText,
+		Rel:        uint32(edge.Rel),
+		Kind:       linkgraphpb.EdgeKind(edge.Kind),
+	}
+}
+
+func edgeFromProto(msg *linkgraphpb.Edge) (*domain.Edge, error) {
+	var ids [3]uuid.UUID
+	for i, b := range [][]byte{msg.GetId(), msg.GetSrc(), msg.GetDst()} {
+		id, err := idFromProto(b)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return &domain.Edge{
+		ID:         ids[0],
+		Src:        ids[1],
+		Dst:        ids[2],
+		UpdatedAt:  timeFromProto(msg.GetUpdatedAt()),
+		AnchorText: msg.GetAnchorText(),
+		Rel:        domain.EdgeRel(msg.GetRel()),
+		Kind:       domain.EdgeKind(msg.GetKind()),
+	}, nil
+}
+
+// toStatus converts an error returned by a graph repository into a gRPC
+// status error.
+func toStatus(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, repository.GraphErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, repository.GraphErrUnknownEdgeLinks):
+		code = codes.FailedPrecondition
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	}
+	return status.Error(code, err.Error())
+}
+
+// fromStatus converts a gRPC status error returned by the server into the
+// error the graph repository returned.
+func fromStatus(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return repository.GraphErrNotFound
+	case codes.FailedPrecondition:
+		return repository.GraphErrUnknownEdgeLinks
+	case codes.Canceled:
+		return context.Canceled
+	case codes.DeadlineExceeded:
+		return context.DeadlineExceeded
+	default:
+		return err
+	}
+}
@@ -0,0 +1,130 @@
+package linkgraph
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/graphtest"
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/repository/memory"
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/linkgraph/linkgraphpb"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func Test(t *testing.T) {
+	suite.Run(t, new(GRPCGraphTestSuite))
+}
+
+// GRPCGraphTestSuite runs the graph test suite against a Client talking to a
+// Server backed by the in-memory graph.
+type GRPCGraphTestSuite struct {
+	suite.Suite
+	base graphtest.SuiteBase
+
+	srv  *grpc.Server
+	conn *grpc.ClientConn
+}
+
+func (s *GRPCGraphTestSuite) SetupTest() {
+	lis := bufconn.Listen(1 << 20)
+	s.srv = grpc.NewServer()
+	linkgraphpb.RegisterLinkGraphServer(s.srv, NewServer(memory.NewInMemoryGraph()))
+	go func() { _ = s.srv.Serve(lis) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	s.Require().NoError(err)
+	s.conn = conn
+	s.base.SetGraph(NewClient(conn))
+}
+
+func (s *GRPCGraphTestSuite) TearDownTest() {
+	_ = s.conn.Close()
+	s.srv.Stop()
+}
+func (s *GRPCGraphTestSuite) TestUpsertLink() {
+	s.base.TestUpsertLink(s.T())
+}
+func (s *GRPCGraphTestSuite) TestUpsertLinks() {
+	s.base.TestUpsertLinks(s.T())
+}
+func (s *GRPCGraphTestSuite) TestLinkFetchMetadata() {
+	s.base.TestLinkFetchMetadata(s.T())
+}
+func (s *GRPCGraphTestSuite) TestTouchLink() {
+	s.base.TestTouchLink(s.T())
+}
+func (s *GRPCGraphTestSuite) TestMergeLink() {
+	s.base.TestMergeLink(s.T())
+}
+func (s *GRPCGraphTestSuite) TestFindLink() {
+	s.base.TestFindLink(s.T())
+}
+func (s *GRPCGraphTestSuite) TestFindLinkByURL() {
+	s.base.TestFindLinkByURL(s.T())
+}
+func (s *GRPCGraphTestSuite) TestFindLinks() {
+	s.base.TestFindLinks(s.T())
+}
+func (s *GRPCGraphTestSuite) TestRemoveLink() {
+	s.base.TestRemoveLink(s.T())
+}
+func (s *GRPCGraphTestSuite) TestConcurrentLinkIterators() {
+	s.base.TestConcurrentLinkIterators(s.T())
+}
+func (s *GRPCGraphTestSuite) TestLinkIteratorTimeFilter() {
+	s.base.TestLinkIteratorTimeFilter(s.T())
+}
+func (s *GRPCGraphTestSuite) TestPartitionedLinkIterators() {
+	s.base.TestPartitionedLinkIterators(s.T())
+}
+func (s *GRPCGraphTestSuite) TestLinkIteratorContextCancellation() {
+	s.base.TestLinkIteratorContextCancellation(s.T())
+}
+func (s *GRPCGraphTestSuite) TestLinkRangeBoundaries() {
+	s.base.TestLinkRangeBoundaries(s.T())
+}
+func (s *GRPCGraphTestSuite) TestUpsertEdge() {
+	s.base.TestUpsertEdge(s.T())
+}
+func (s *GRPCGraphTestSuite) TestUpsertEdges() {
+	s.base.TestUpsertEdges(s.T())
+}
+func (s *GRPCGraphTestSuite) TestEdgeAnchor() {
+	s.base.TestEdgeAnchor(s.T())
+}
+func (s *GRPCGraphTestSuite) TestRedirectEdge() {
+	s.base.TestRedirectEdge(s.T())
+}
+func (s *GRPCGraphTestSuite) TestRemoveEdge() {
+	s.base.TestRemoveEdge(s.T())
+}
+func (s *GRPCGraphTestSuite) TestInOutEdges() {
+	s.base.TestInOutEdges(s.T())
+}
+func (s *GRPCGraphTestSuite) TestStats() {
+	s.base.TestStats(s.T())
+}
+func (s *GRPCGraphTestSuite) TestConcurrentEdgeIterators() {
+	s.base.TestConcurrentEdgeIterators(s.T())
+}
+func (s *GRPCGraphTestSuite) TestEdgeIteratorTimeFilter() {
+	s.base.TestEdgeIteratorTimeFilter(s.T())
+}
+func (s *GRPCGraphTestSuite) TestPartitionedEdgeIterators() {
+	s.base.TestPartitionedEdgeIterators(s.T())
+}
+func (s *GRPCGraphTestSuite) TestRemoveStaleEdges() {
+	s.base.TestRemoveStaleEdges(s.T())
+}
+func (s *GRPCGraphTestSuite) TestEdgeIteratorContextCancellation() {
+	s.base.TestEdgeIteratorContextCancellation(s.T())
+}
+func (s *GRPCGraphTestSuite) TestEdgeRangeBoundaries() {
+	s.base.TestEdgeRangeBoundaries(s.T())
+}
@@ -0,0 +1,1523 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        (unknown)
+// source: linkgraph/linkgraphpb/linkgraph.proto
+
+package linkgraphpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EdgeKind int32
+
+const (
+	EdgeKind_EDGE_KIND_LINK     EdgeKind = 0
+	EdgeKind_EDGE_KIND_REDIRECT EdgeKind = 1
+)
+
+// Enum value maps for EdgeKind.
+var (
+	EdgeKind_name = map[int32]string{
+		0: "EDGE_KIND_LINK",
+		1: "EDGE_KIND_REDIRECT",
+	}
+	EdgeKind_value = map[string]int32{
+		"EDGE_KIND_LINK":     0,
+		"EDGE_KIND_REDIRECT": 1,
+	}
+)
+
+func (x EdgeKind) Enum() *EdgeKind {
+	p := new(EdgeKind)
+	*p = x
+	return p
+}
+
+func (x EdgeKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EdgeKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_enumTypes[0].Descriptor()
+}
+
+func (EdgeKind) Type() protoreflect.EnumType {
+	return &file_linkgraph_linkgraphpb_linkgraph_proto_enumTypes[0]
+}
+
+func (x EdgeKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EdgeKind.Descriptor instead.
+func (EdgeKind) EnumDescriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{0}
+}
+
+// Link mirrors domain.Link. IDs are 16-byte UUIDs and a missing
+// retrieved_at denotes a link that was never retrieved.
+type Link struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	RetrievedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=retrieved_at,json=retrievedAt,proto3" json:"retrieved_at,omitempty"`
+	CanonicalId   []byte                 `protobuf:"bytes,4,opt,name=canonical_id,json=canonicalId,proto3" json:"canonical_id,omitempty"`
+	StatusCode    int32                  `protobuf:"varint,5,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	ContentType   string                 `protobuf:"bytes,6,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	ContentLength int64                  `protobuf:"varint,7,opt,name=content_length,json=contentLength,proto3" json:"content_length,omitempty"`
+	Etag          string                 `protobuf:"bytes,8,opt,name=etag,proto3" json:"etag,omitempty"`
+	LastModified  string                 `protobuf:"bytes,9,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"`
+	FailureCount  int32                  `protobuf:"varint,10,opt,name=failure_count,json=failureCount,proto3" json:"failure_count,omitempty"`
+	LastError     string                 `protobuf:"bytes,11,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+}
+
+func (x *Link) Reset() {
+	*x = Link{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Link) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Link) ProtoMessage() {}
+
+func (x *Link) ProtoReflect() protoreflect.Message {
+	mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Link.ProtoReflect.Descriptor instead.
+func (*Link) Descriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Link) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Link) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Link) GetRetrievedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RetrievedAt
+	}
+	return nil
+}
+
+func (x *Link) GetCanonicalId() []byte {
+	if x != nil {
+		return x.CanonicalId
+	}
+	return nil
+}
+
+func (x *Link) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *Link) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Link) GetContentLength() int64 {
+	if x != nil {
+		return x.ContentLength
+	}
+	return 0
+}
+
+func (x *Link) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *Link) GetLastModified() string {
+	if x != nil {
+		return x.LastModified
+	}
+	return ""
+}
+
+func (x *Link) GetFailureCount() int32 {
+	if x != nil {
+		return x.FailureCount
+	}
+	return 0
+}
+
+func (x *Link) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+// Edge mirrors domain.Edge. rel holds the domain.EdgeRel flags.
+type Edge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Src        []byte                 `protobuf:"bytes,2,opt,name=src,proto3" json:"src,omitempty"`
+	Dst        []byte                 `protobuf:"bytes,3,opt,name=dst,proto3" json:"dst,omitempty"`
+	UpdatedAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	AnchorText string                 `protobuf:"bytes,5,opt,name=anchor_text,json=anchorText,proto3" json:"anchor_text,omitempty"`
+	Rel        uint32                 `protobuf:"varint,6,opt,name=rel,proto3" json:"rel,omitempty"`
+	Kind       EdgeKind               `protobuf:"varint,7,opt,name=kind,proto3,enum=linkgraph.EdgeKind" json:"kind,omitempty"`
+}
+
+func (x *Edge) Reset() {
+	*x = Edge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Edge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Edge) ProtoMessage() {}
+
+func (x *Edge) ProtoReflect() protoreflect.Message {
+	mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Edge.ProtoReflect.Descriptor instead.
+func (*Edge) Descriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Edge) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Edge) GetSrc() []byte {
+	if x != nil {
+		return x.Src
+	}
+	return nil
+}
+
+func (x *Edge) GetDst() []byte {
+	if x != nil {
+		return x.Dst
+	}
+	return nil
+}
+
+func (x *Edge) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Edge) GetAnchorText() string {
+	if x != nil {
+		return x.AnchorText
+	}
+	return ""
+}
+
+func (x *Edge) GetRel() uint32 {
+	if x != nil {
+		return x.Rel
+	}
+	return 0
+}
+
+func (x *Edge) GetKind() EdgeKind {
+	if x != nil {
+		return x.Kind
+	}
+	return EdgeKind_EDGE_KIND_LINK
+}
+
+type LinkBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Links []*Link `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+}
+
+func (x *LinkBatch) Reset() {
+	*x = LinkBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkBatch) ProtoMessage() {}
+
+func (x *LinkBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkBatch.ProtoReflect.Descriptor instead.
+func (*LinkBatch) Descriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LinkBatch) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type EdgeBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Edges []*Edge `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+}
+
+func (x *EdgeBatch) Reset() {
+	*x = EdgeBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EdgeBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EdgeBatch) ProtoMessage() {}
+
+func (x *EdgeBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EdgeBatch.ProtoReflect.Descriptor instead.
+func (*EdgeBatch) Descriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EdgeBatch) GetEdges() []*Edge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+type LinkID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *LinkID) Reset() {
+	*x = LinkID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkID) ProtoMessage() {}
+
+func (x *LinkID) ProtoReflect() protoreflect.Message {
+	mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkID.ProtoReflect.Descriptor instead.
+func (*LinkID) Descriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LinkID) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type LinkIDs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids [][]byte `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *LinkIDs) Reset() {
+	*x = LinkIDs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkIDs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkIDs) ProtoMessage() {}
+
+func (x *LinkIDs) ProtoReflect() protoreflect.Message {
+	mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkIDs.ProtoReflect.Descriptor instead.
+func (*LinkIDs) Descriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LinkIDs) GetIds() [][]byte {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type EdgeID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *EdgeID) Reset() {
+	*x = EdgeID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EdgeID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EdgeID) ProtoMessage() {}
+
+func (x *EdgeID) ProtoReflect() protoreflect.Message {
+	mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EdgeID.ProtoReflect.Descriptor instead.
+func (*EdgeID) Descriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EdgeID) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type FindLinkByURLRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *FindLinkByURLRequest) Reset() {
+	*x = FindLinkByURLRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindLinkByURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindLinkByURLRequest) ProtoMessage() {}
+
+func (x *FindLinkByURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindLinkByURLRequest.ProtoReflect.Descriptor instead.
+func (*FindLinkByURLRequest) Descriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FindLinkByURLRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type TouchLinkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RetrievedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=retrieved_at,json=retrievedAt,proto3" json:"retrieved_at,omitempty"`
+}
+
+func (x *TouchLinkRequest) Reset() {
+	*x = TouchLinkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TouchLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TouchLinkRequest) ProtoMessage() {}
+
+func (x *TouchLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TouchLinkRequest.ProtoReflect.Descriptor instead.
+func (*TouchLinkRequest) Descriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TouchLinkRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *TouchLinkRequest) GetRetrievedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RetrievedAt
+	}
+	return nil
+}
+
+type MergeLinkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CanonicalId []byte `protobuf:"bytes,2,opt,name=canonical_id,json=canonicalId,proto3" json:"canonical_id,omitempty"`
+}
+
+func (x *MergeLinkRequest) Reset() {
+	*x = MergeLinkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MergeLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeLinkRequest) ProtoMessage() {}
+
+func (x *MergeLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeLinkRequest.ProtoReflect.Descriptor instead.
+func (*MergeLinkRequest) Descriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MergeLinkRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *MergeLinkRequest) GetCanonicalId() []byte {
+	if x != nil {
+		return x.CanonicalId
+	}
+	return nil
+}
+
+type RemoveStaleEdgesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromId        []byte                 `protobuf:"bytes,1,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	UpdatedBefore *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_before,json=updatedBefore,proto3" json:"updated_before,omitempty"`
+}
+
+func (x *RemoveStaleEdgesRequest) Reset() {
+	*x = RemoveStaleEdgesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveStaleEdgesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveStaleEdgesRequest) ProtoMessage() {}
+
+func (x *RemoveStaleEdgesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveStaleEdgesRequest.ProtoReflect.Descriptor instead.
+func (*RemoveStaleEdgesRequest) Descriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RemoveStaleEdgesRequest) GetFromId() []byte {
+	if x != nil {
+		return x.FromId
+	}
+	return nil
+}
+
+func (x *RemoveStaleEdgesRequest) GetUpdatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedBefore
+	}
+	return nil
+}
+
+// RangeRequest selects the links, or the edges whose source links, have IDs
+// in [from, to) and were retrieved, or updated, before the given time.
+type RangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From   []byte                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To     []byte                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Before *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=before,proto3" json:"before,omitempty"`
+}
+
+func (x *RangeRequest) Reset() {
+	*x = RangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeRequest) ProtoMessage() {}
+
+func (x *RangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeRequest.ProtoReflect.Descriptor instead.
+func (*RangeRequest) Descriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RangeRequest) GetFrom() []byte {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *RangeRequest) GetTo() []byte {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *RangeRequest) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+type DegreeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InDegree  int64 `protobuf:"varint,1,opt,name=in_degree,json=inDegree,proto3" json:"in_degree,omitempty"`
+	OutDegree int64 `protobuf:"varint,2,opt,name=out_degree,json=outDegree,proto3" json:"out_degree,omitempty"`
+}
+
+func (x *DegreeResponse) Reset() {
+	*x = DegreeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DegreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DegreeResponse) ProtoMessage() {}
+
+func (x *DegreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DegreeResponse.ProtoReflect.Descriptor instead.
+func (*DegreeResponse) Descriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DegreeResponse) GetInDegree() int64 {
+	if x != nil {
+		return x.InDegree
+	}
+	return 0
+}
+
+func (x *DegreeResponse) GetOutDegree() int64 {
+	if x != nil {
+		return x.OutDegree
+	}
+	return 0
+}
+
+type StatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RetrievedWithin *durationpb.Duration `protobuf:"bytes,1,opt,name=retrieved_within,json=retrievedWithin,proto3" json:"retrieved_within,omitempty"`
+	NumPartitions   int32                `protobuf:"varint,2,opt,name=num_partitions,json=numPartitions,proto3" json:"num_partitions,omitempty"`
+}
+
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequest) ProtoMessage() {}
+
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StatsRequest) GetRetrievedWithin() *durationpb.Duration {
+	if x != nil {
+		return x.RetrievedWithin
+	}
+	return nil
+}
+
+func (x *StatsRequest) GetNumPartitions() int32 {
+	if x != nil {
+		return x.NumPartitions
+	}
+	return 0
+}
+
+type StatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Links                  int64           `protobuf:"varint,1,opt,name=links,proto3" json:"links,omitempty"`
+	Edges                  int64           `protobuf:"varint,2,opt,name=edges,proto3" json:"edges,omitempty"`
+	UnretrievedLinks       int64           `protobuf:"varint,3,opt,name=unretrieved_links,json=unretrievedLinks,proto3" json:"unretrieved_links,omitempty"`
+	RecentlyRetrievedLinks int64           `protobuf:"varint,4,opt,name=recently_retrieved_links,json=recentlyRetrievedLinks,proto3" json:"recently_retrieved_links,omitempty"`
+	EdgesPerPartition      []int64         `protobuf:"varint,5,rep,packed,name=edges_per_partition,json=edgesPerPartition,proto3" json:"edges_per_partition,omitempty"`
+	InDegreeHistogram      map[int64]int64 `protobuf:"bytes,6,rep,name=in_degree_histogram,json=inDegreeHistogram,proto3" json:"in_degree_histogram,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	OutDegreeHistogram     map[int64]int64 `protobuf:"bytes,7,rep,name=out_degree_histogram,json=outDegreeHistogram,proto3" json:"out_degree_histogram,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *StatsResponse) Reset() {
+	*x = StatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResponse) ProtoMessage() {}
+
+func (x *StatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StatsResponse) GetLinks() int64 {
+	if x != nil {
+		return x.Links
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetEdges() int64 {
+	if x != nil {
+		return x.Edges
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetUnretrievedLinks() int64 {
+	if x != nil {
+		return x.UnretrievedLinks
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetRecentlyRetrievedLinks() int64 {
+	if x != nil {
+		return x.RecentlyRetrievedLinks
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetEdgesPerPartition() []int64 {
+	if x != nil {
+		return x.EdgesPerPartition
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetInDegreeHistogram() map[int64]int64 {
+	if x != nil {
+		return x.InDegreeHistogram
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetOutDegreeHistogram() map[int64]int64 {
+	if x != nil {
+		return x.OutDegreeHistogram
+	}
+	return nil
+}
+
+var File_linkgraph_linkgraphpb_linkgraph_proto protoreflect.FileDescriptor
+
+var file_linkgraph_linkgraphpb_linkgraph_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x6c, 0x69, 0x6e, 0x6b,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x70, 0x62, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xf2, 0x02, 0x0a, 0x04, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x3d, 0x0a, 0x0c, 0x72,
+	0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x72,
+	0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x64, 0x41, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61,
+	0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0b, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x21,
+	0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x74, 0x61, 0x67,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x74, 0x61, 0x67, 0x12, 0x23, 0x0a, 0x0d,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65,
+	0x64, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xd1, 0x01, 0x0a, 0x04, 0x45, 0x64, 0x67, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10,
+	0x0a, 0x03, 0x73, 0x72, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x73, 0x72, 0x63,
+	0x12, 0x10, 0x0a, 0x03, 0x64, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x64,
+	0x73, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1f, 0x0a,
+	0x0b, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x54, 0x65, 0x78, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x72, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x72, 0x65, 0x6c,
+	0x12, 0x27, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x4b,
+	0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x32, 0x0a, 0x09, 0x4c, 0x69, 0x6e,
+	0x6b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x25, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x22, 0x32, 0x0a,
+	0x09, 0x45, 0x64, 0x67, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x25, 0x0a, 0x05, 0x65, 0x64,
+	0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65,
+	0x73, 0x22, 0x18, 0x0a, 0x06, 0x4c, 0x69, 0x6e, 0x6b, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1b, 0x0a, 0x07, 0x4c,
+	0x69, 0x6e, 0x6b, 0x49, 0x44, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x18, 0x0a, 0x06, 0x45, 0x64, 0x67, 0x65,
+	0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x28, 0x0a, 0x14, 0x46, 0x69, 0x6e, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x42, 0x79,
+	0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x61, 0x0a, 0x10,
+	0x54, 0x6f, 0x75, 0x63, 0x68, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x3d, 0x0a, 0x0c, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0b, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x64, 0x41, 0x74, 0x22,
+	0x45, 0x0a, 0x10, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x63, 0x61, 0x6e, 0x6f, 0x6e,
+	0x69, 0x63, 0x61, 0x6c, 0x49, 0x64, 0x22, 0x75, 0x0a, 0x17, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x53, 0x74, 0x61, 0x6c, 0x65, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x66, 0x72, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x41, 0x0a, 0x0e, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x22, 0x66, 0x0a,
+	0x0c, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x74,
+	0x6f, 0x12, 0x32, 0x0a, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x62,
+	0x65, 0x66, 0x6f, 0x72, 0x65, 0x22, 0x4c, 0x0a, 0x0e, 0x44, 0x65, 0x67, 0x72, 0x65, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x5f, 0x64, 0x65,
+	0x67, 0x72, 0x65, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x44, 0x65,
+	0x67, 0x72, 0x65, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x75, 0x74, 0x5f, 0x64, 0x65, 0x67, 0x72,
+	0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6f, 0x75, 0x74, 0x44, 0x65, 0x67,
+	0x72, 0x65, 0x65, 0x22, 0x7b, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x10, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x64,
+	0x5f, 0x77, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x76, 0x65, 0x64, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x75, 0x6d,
+	0x5f, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0d, 0x6e, 0x75, 0x6d, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0xa4, 0x04, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x64, 0x67, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x12, 0x2b,
+	0x0a, 0x11, 0x75, 0x6e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x64, 0x5f, 0x6c, 0x69,
+	0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x75, 0x6e, 0x72, 0x65, 0x74,
+	0x72, 0x69, 0x65, 0x76, 0x65, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x72,
+	0x65, 0x63, 0x65, 0x6e, 0x74, 0x6c, 0x79, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65,
+	0x64, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x16, 0x72,
+	0x65, 0x63, 0x65, 0x6e, 0x74, 0x6c, 0x79, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x64,
+	0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x65, 0x64, 0x67, 0x65, 0x73, 0x5f, 0x70,
+	0x65, 0x72, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x03, 0x52, 0x11, 0x65, 0x64, 0x67, 0x65, 0x73, 0x50, 0x65, 0x72, 0x50, 0x61, 0x72, 0x74,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x5f, 0x0a, 0x13, 0x69, 0x6e, 0x5f, 0x64, 0x65, 0x67, 0x72,
+	0x65, 0x65, 0x5f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x49, 0x6e, 0x44,
+	0x65, 0x67, 0x72, 0x65, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x11, 0x69, 0x6e, 0x44, 0x65, 0x67, 0x72, 0x65, 0x65, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x62, 0x0a, 0x14, 0x6f, 0x75, 0x74, 0x5f, 0x64, 0x65,
+	0x67, 0x72, 0x65, 0x65, 0x5f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f,
+	0x75, 0x74, 0x44, 0x65, 0x67, 0x72, 0x65, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61,
+	0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x12, 0x6f, 0x75, 0x74, 0x44, 0x65, 0x67, 0x72, 0x65,
+	0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x1a, 0x44, 0x0a, 0x16, 0x49, 0x6e,
+	0x44, 0x65, 0x67, 0x72, 0x65, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x45, 0x0a, 0x17, 0x4f, 0x75, 0x74, 0x44, 0x65, 0x67, 0x72, 0x65, 0x65, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x36, 0x0a, 0x08, 0x45, 0x64, 0x67, 0x65, 0x4b,
+	0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x0e, 0x45, 0x44, 0x47, 0x45, 0x5f, 0x4b, 0x49, 0x4e, 0x44,
+	0x5f, 0x4c, 0x49, 0x4e, 0x4b, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x45, 0x44, 0x47, 0x45, 0x5f,
+	0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x52, 0x45, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x10, 0x01, 0x32,
+	0x92, 0x08, 0x0a, 0x09, 0x4c, 0x69, 0x6e, 0x6b, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x2e, 0x0a,
+	0x0a, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x0f, 0x2e, 0x6c, 0x69,
+	0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x1a, 0x0f, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x39, 0x0a,
+	0x0b, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x14, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x1a, 0x14, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c,
+	0x69, 0x6e, 0x6b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2e, 0x0a, 0x08, 0x46, 0x69, 0x6e, 0x64,
+	0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x11, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x49, 0x44, 0x1a, 0x0f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x41, 0x0a, 0x0d, 0x46, 0x69, 0x6e, 0x64,
+	0x4c, 0x69, 0x6e, 0x6b, 0x42, 0x79, 0x55, 0x52, 0x4c, 0x12, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x42, 0x79,
+	0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x35, 0x0a, 0x09, 0x46,
+	0x69, 0x6e, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x12, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x49, 0x44, 0x73, 0x1a, 0x14, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x40, 0x0a, 0x09, 0x54, 0x6f, 0x75, 0x63, 0x68, 0x4c, 0x69, 0x6e, 0x6b, 0x12,
+	0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x54, 0x6f, 0x75, 0x63,
+	0x68, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x12, 0x37, 0x0a, 0x0a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4c, 0x69,
+	0x6e, 0x6b, 0x12, 0x11, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c,
+	0x69, 0x6e, 0x6b, 0x49, 0x44, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x40, 0x0a,
+	0x09, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x1b, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x4c, 0x69, 0x6e, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x2e, 0x0a, 0x0a, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74, 0x45, 0x64, 0x67, 0x65, 0x12, 0x0f, 0x2e,
+	0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x1a, 0x0f,
+	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x12,
+	0x39, 0x0a, 0x0b, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74, 0x45, 0x64, 0x67, 0x65, 0x73, 0x12, 0x14,
+	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x1a, 0x14, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x2e, 0x45, 0x64, 0x67, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x37, 0x0a, 0x0a, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x45, 0x64, 0x67, 0x65, 0x12, 0x11, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x49, 0x44, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x4e, 0x0a, 0x10, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x74, 0x61,
+	0x6c, 0x65, 0x45, 0x64, 0x67, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x45,
+	0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x33, 0x0a, 0x05, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x17, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x33, 0x0a, 0x05, 0x45, 0x64, 0x67, 0x65,
+	0x73, 0x12, 0x17, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x30, 0x01, 0x12, 0x2f, 0x0a,
+	0x07, 0x49, 0x6e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x12, 0x11, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x49, 0x44, 0x1a, 0x0f, 0x2e, 0x6c, 0x69,
+	0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x30, 0x01, 0x12, 0x30,
+	0x0a, 0x08, 0x4f, 0x75, 0x74, 0x45, 0x64, 0x67, 0x65, 0x73, 0x12, 0x11, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x49, 0x44, 0x1a, 0x0f, 0x2e,
+	0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x30, 0x01,
+	0x12, 0x36, 0x0a, 0x06, 0x44, 0x65, 0x67, 0x72, 0x65, 0x65, 0x12, 0x11, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x49, 0x44, 0x1a, 0x19, 0x2e,
+	0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x44, 0x65, 0x67, 0x72, 0x65, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x17, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x4e, 0x5a, 0x4c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x62, 0x72, 0x75, 0x63, 0x65, 0x6e, 0x65, 0x63, 0x6f, 0x2f, 0x6c, 0x69, 0x6e,
+	0x6b, 0x73, 0x2d, 0x72, 0x2d, 0x75, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x2f, 0x61, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x6c,
+	0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_linkgraph_linkgraphpb_linkgraph_proto_rawDescOnce sync.Once
+	file_linkgraph_linkgraphpb_linkgraph_proto_rawDescData = file_linkgraph_linkgraphpb_linkgraph_proto_rawDesc
+)
+
+func file_linkgraph_linkgraphpb_linkgraph_proto_rawDescGZIP() []byte {
+	file_linkgraph_linkgraphpb_linkgraph_proto_rawDescOnce.Do(func() {
+		file_linkgraph_linkgraphpb_linkgraph_proto_rawDescData = protoimpl.X.CompressGZIP(file_linkgraph_linkgraphpb_linkgraph_proto_rawDescData)
+	})
+	return file_linkgraph_linkgraphpb_linkgraph_proto_rawDescData
+}
+
+var file_linkgraph_linkgraphpb_linkgraph_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_linkgraph_linkgraphpb_linkgraph_proto_goTypes = []interface{}{
+	(EdgeKind)(0),                   // 0: linkgraph.EdgeKind
+	(*Link)(nil),                    // 1: linkgraph.Link
+	(*Edge)(nil),                    // 2: linkgraph.Edge
+	(*LinkBatch)(nil),               // 3: linkgraph.LinkBatch
+	(*EdgeBatch)(nil),               // 4: linkgraph.EdgeBatch
+	(*LinkID)(nil),                  // 5: linkgraph.LinkID
+	(*LinkIDs)(nil),                 // 6: linkgraph.LinkIDs
+	(*EdgeID)(nil),                  // 7: linkgraph.EdgeID
+	(*FindLinkByURLRequest)(nil),    // 8: linkgraph.FindLinkByURLRequest
+	(*TouchLinkRequest)(nil),        // 9: linkgraph.TouchLinkRequest
+	(*MergeLinkRequest)(nil),        // 10: linkgraph.MergeLinkRequest
+	(*RemoveStaleEdgesRequest)(nil), // 11: linkgraph.RemoveStaleEdgesRequest
+	(*RangeRequest)(nil),            // 12: linkgraph.RangeRequest
+	(*DegreeResponse)(nil),          // 13: linkgraph.DegreeResponse
+	(*StatsRequest)(nil),            // 14: linkgraph.StatsRequest
+	(*StatsResponse)(nil),           // 15: linkgraph.StatsResponse
+	nil,                             // 16: linkgraph.StatsResponse.InDegreeHistogramEntry
+	nil,                             // 17: linkgraph.StatsResponse.OutDegreeHistogramEntry
+	(*timestamppb.Timestamp)(nil),   // 18: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),     // 19: google.protobuf.Duration
+	(*emptypb.Empty)(nil),           // 20: google.protobuf.Empty
+}
+var file_linkgraph_linkgraphpb_linkgraph_proto_depIdxs = []int32{
+	18, // 0: linkgraph.Link.retrieved_at:type_name -> google.protobuf.Timestamp
+	18, // 1: linkgraph.Edge.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: linkgraph.Edge.kind:type_name -> linkgraph.EdgeKind
+	1,  // 3: linkgraph.LinkBatch.links:type_name -> linkgraph.Link
+	2,  // 4: linkgraph.EdgeBatch.edges:type_name -> linkgraph.Edge
+	18, // 5: linkgraph.TouchLinkRequest.retrieved_at:type_name -> google.protobuf.Timestamp
+	18, // 6: linkgraph.RemoveStaleEdgesRequest.updated_before:type_name -> google.protobuf.Timestamp
+	18, // 7: linkgraph.RangeRequest.before:type_name -> google.protobuf.Timestamp
+	19, // 8: linkgraph.StatsRequest.retrieved_within:type_name -> google.protobuf.Duration
+	16, // 9: linkgraph.StatsResponse.in_degree_histogram:type_name -> linkgraph.StatsResponse.InDegreeHistogramEntry
+	17, // 10: linkgraph.StatsResponse.out_degree_histogram:type_name -> linkgraph.StatsResponse.OutDegreeHistogramEntry
+	1,  // 11: linkgraph.LinkGraph.UpsertLink:input_type -> linkgraph.Link
+	3,  // 12: linkgraph.LinkGraph.UpsertLinks:input_type -> linkgraph.LinkBatch
+	5,  // 13: linkgraph.LinkGraph.FindLink:input_type -> linkgraph.LinkID
+	8,  // 14: linkgraph.LinkGraph.FindLinkByURL:input_type -> linkgraph.FindLinkByURLRequest
+	6,  // 15: linkgraph.LinkGraph.FindLinks:input_type -> linkgraph.LinkIDs
+	9,  // 16: linkgraph.LinkGraph.TouchLink:input_type -> linkgraph.TouchLinkRequest
+	5,  // 17: linkgraph.LinkGraph.RemoveLink:input_type -> linkgraph.LinkID
+	10, // 18: linkgraph.LinkGraph.MergeLink:input_type -> linkgraph.MergeLinkRequest
+	2,  // 19: linkgraph.LinkGraph.UpsertEdge:input_type -> linkgraph.Edge
+	4,  // 20: linkgraph.LinkGraph.UpsertEdges:input_type -> linkgraph.EdgeBatch
+	7,  // 21: linkgraph.LinkGraph.RemoveEdge:input_type -> linkgraph.EdgeID
+	11, // 22: linkgraph.LinkGraph.RemoveStaleEdges:input_type -> linkgraph.RemoveStaleEdgesRequest
+	12, // 23: linkgraph.LinkGraph.Links:input_type -> linkgraph.RangeRequest
+	12, // 24: linkgraph.LinkGraph.Edges:input_type -> linkgraph.RangeRequest
+	5,  // 25: linkgraph.LinkGraph.InEdges:input_type -> linkgraph.LinkID
+	5,  // 26: linkgraph.LinkGraph.OutEdges:input_type -> linkgraph.LinkID
+	5,  // 27: linkgraph.LinkGraph.Degree:input_type -> linkgraph.LinkID
+	14, // 28: linkgraph.LinkGraph.Stats:input_type -> linkgraph.StatsRequest
+	1,  // 29: linkgraph.LinkGraph.UpsertLink:output_type -> linkgraph.Link
+	3,  // 30: linkgraph.LinkGraph.UpsertLinks:output_type -> linkgraph.LinkBatch
+	1,  // 31: linkgraph.LinkGraph.FindLink:output_type -> linkgraph.Link
+	1,  // 32: linkgraph.LinkGraph.FindLinkByURL:output_type -> linkgraph.Link
+	3,  // 33: linkgraph.LinkGraph.FindLinks:output_type -> linkgraph.LinkBatch
+	20, // 34: linkgraph.LinkGraph.TouchLink:output_type -> google.protobuf.Empty
+	20, // 35: linkgraph.LinkGraph.RemoveLink:output_type -> google.protobuf.Empty
+	20, // 36: linkgraph.LinkGraph.MergeLink:output_type -> google.protobuf.Empty
+	2,  // 37: linkgraph.LinkGraph.UpsertEdge:output_type -> linkgraph.Edge
+	4,  // 38: linkgraph.LinkGraph.UpsertEdges:output_type -> linkgraph.EdgeBatch
+	20, // 39: linkgraph.LinkGraph.RemoveEdge:output_type -> google.protobuf.Empty
+	20, // 40: linkgraph.LinkGraph.RemoveStaleEdges:output_type -> google.protobuf.Empty
+	1,  // 41: linkgraph.LinkGraph.Links:output_type -> linkgraph.Link
+	2,  // 42: linkgraph.LinkGraph.Edges:output_type -> linkgraph.Edge
+	2,  // 43: linkgraph.LinkGraph.InEdges:output_type -> linkgraph.Edge
+	2,  // 44: linkgraph.LinkGraph.OutEdges:output_type -> linkgraph.Edge
+	13, // 45: linkgraph.LinkGraph.Degree:output_type -> linkgraph.DegreeResponse
+	15, // 46: linkgraph.LinkGraph.Stats:output_type -> linkgraph.StatsResponse
+	29, // [29:47] is the sub-list for method output_type
+	11, // [11:29] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_linkgraph_linkgraphpb_linkgraph_proto_init() }
+func file_linkgraph_linkgraphpb_linkgraph_proto_init() {
+	if File_linkgraph_linkgraphpb_linkgraph_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Link); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Edge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EdgeBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkIDs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EdgeID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindLinkByURLRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TouchLinkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MergeLinkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveStaleEdgesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DegreeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_linkgraph_linkgraphpb_linkgraph_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_linkgraph_linkgraphpb_linkgraph_proto_goTypes,
+		DependencyIndexes: file_linkgraph_linkgraphpb_linkgraph_proto_depIdxs,
+		EnumInfos:         file_linkgraph_linkgraphpb_linkgraph_proto_enumTypes,
+		MessageInfos:      file_linkgraph_linkgraphpb_linkgraph_proto_msgTypes,
+	}.Build()
+	File_linkgraph_linkgraphpb_linkgraph_proto = out.File
+	file_linkgraph_linkgraphpb_linkgraph_proto_rawDesc = nil
+	file_linkgraph_linkgraphpb_linkgraph_proto_goTypes = nil
+	file_linkgraph_linkgraphpb_linkgraph_proto_depIdxs = nil
+}
@@ -0,0 +1,876 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: linkgraph/linkgraphpb/linkgraph.proto
+
+package linkgraphpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	LinkGraph_UpsertLink_FullMethodName       = "/linkgraph.LinkGraph/UpsertLink"
+	LinkGraph_UpsertLinks_FullMethodName      = "/linkgraph.LinkGraph/UpsertLinks"
+	LinkGraph_FindLink_FullMethodName         = "/linkgraph.LinkGraph/FindLink"
+	LinkGraph_FindLinkByURL_FullMethodName    = "/linkgraph.LinkGraph/FindLinkByURL"
+	LinkGraph_FindLinks_FullMethodName        = "/linkgraph.LinkGraph/FindLinks"
+	LinkGraph_TouchLink_FullMethodName        = "/linkgraph.LinkGraph/TouchLink"
+	LinkGraph_RemoveLink_FullMethodName       = "/linkgraph.LinkGraph/RemoveLink"
+	LinkGraph_MergeLink_FullMethodName        = "/linkgraph.LinkGraph/MergeLink"
+	LinkGraph_UpsertEdge_FullMethodName       = "/linkgraph.LinkGraph/UpsertEdge"
+	LinkGraph_UpsertEdges_FullMethodName      = "/linkgraph.LinkGraph/UpsertEdges"
+	LinkGraph_RemoveEdge_FullMethodName       = "/linkgraph.LinkGraph/RemoveEdge"
+	LinkGraph_RemoveStaleEdges_FullMethodName = "/linkgraph.LinkGraph/RemoveStaleEdges"
+	LinkGraph_Links_FullMethodName            = "/linkgraph.LinkGraph/Links"
+	LinkGraph_Edges_FullMethodName            = "/linkgraph.LinkGraph/Edges"
+	LinkGraph_InEdges_FullMethodName          = "/linkgraph.LinkGraph/InEdges"
+	LinkGraph_OutEdges_FullMethodName         = "/linkgraph.LinkGraph/OutEdges"
+	LinkGraph_Degree_FullMethodName           = "/linkgraph.LinkGraph/Degree"
+	LinkGraph_Stats_FullMethodName            = "/linkgraph.LinkGraph/Stats"
+)
+
+// LinkGraphClient is the client API for LinkGraph service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LinkGraph exposes a link graph repository over gRPC. Its methods mirror
+// those of repository.GraphRepository; unknown links and edges yield a
+// NOT_FOUND status and edges referencing unknown links a
+// FAILED_PRECONDITION status.
+type LinkGraphClient interface {
+	UpsertLink(ctx context.Context, in *Link, opts ...grpc.CallOption) (*Link, error)
+	UpsertLinks(ctx context.Context, in *LinkBatch, opts ...grpc.CallOption) (*LinkBatch, error)
+	FindLink(ctx context.Context, in *LinkID, opts ...grpc.CallOption) (*Link, error)
+	FindLinkByURL(ctx context.Context, in *FindLinkByURLRequest, opts ...grpc.CallOption) (*Link, error)
+	FindLinks(ctx context.Context, in *LinkIDs, opts ...grpc.CallOption) (*LinkBatch, error)
+	TouchLink(ctx context.Context, in *TouchLinkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveLink(ctx context.Context, in *LinkID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	MergeLink(ctx context.Context, in *MergeLinkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpsertEdge(ctx context.Context, in *Edge, opts ...grpc.CallOption) (*Edge, error)
+	UpsertEdges(ctx context.Context, in *EdgeBatch, opts ...grpc.CallOption) (*EdgeBatch, error)
+	RemoveEdge(ctx context.Context, in *EdgeID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveStaleEdges(ctx context.Context, in *RemoveStaleEdgesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Links(ctx context.Context, in *RangeRequest, opts ...grpc.CallOption) (LinkGraph_LinksClient, error)
+	Edges(ctx context.Context, in *RangeRequest, opts ...grpc.CallOption) (LinkGraph_EdgesClient, error)
+	InEdges(ctx context.Context, in *LinkID, opts ...grpc.CallOption) (LinkGraph_InEdgesClient, error)
+	OutEdges(ctx context.Context, in *LinkID, opts ...grpc.CallOption) (LinkGraph_OutEdgesClient, error)
+	Degree(ctx context.Context, in *LinkID, opts ...grpc.CallOption) (*DegreeResponse, error)
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+}
+
+type linkGraphClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLinkGraphClient(cc grpc.ClientConnInterface) LinkGraphClient {
+	return &linkGraphClient{cc}
+}
+
+func (c *linkGraphClient) UpsertLink(ctx context.Context, in *Link, opts ...grpc.CallOption) (*Link, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Link)
+	err := c.cc.Invoke(ctx, LinkGraph_UpsertLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkGraphClient) UpsertLinks(ctx context.Context, in *LinkBatch, opts ...grpc.CallOption) (*LinkBatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LinkBatch)
+	err := c.cc.Invoke(ctx, LinkGraph_UpsertLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkGraphClient) FindLink(ctx context.Context, in *LinkID, opts ...grpc.CallOption) (*Link, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Link)
+	err := c.cc.Invoke(ctx, LinkGraph_FindLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkGraphClient) FindLinkByURL(ctx context.Context, in *FindLinkByURLRequest, opts ...grpc.CallOption) (*Link, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Link)
+	err := c.cc.Invoke(ctx, LinkGraph_FindLinkByURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkGraphClient) FindLinks(ctx context.Context, in *LinkIDs, opts ...grpc.CallOption) (*LinkBatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LinkBatch)
+	err := c.cc.Invoke(ctx, LinkGraph_FindLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkGraphClient) TouchLink(ctx context.Context, in *TouchLinkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LinkGraph_TouchLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkGraphClient) RemoveLink(ctx context.Context, in *LinkID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LinkGraph_RemoveLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkGraphClient) MergeLink(ctx context.Context, in *MergeLinkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LinkGraph_MergeLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkGraphClient) UpsertEdge(ctx context.Context, in *Edge, opts ...grpc.CallOption) (*Edge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Edge)
+	err := c.cc.Invoke(ctx, LinkGraph_UpsertEdge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkGraphClient) UpsertEdges(ctx context.Context, in *EdgeBatch, opts ...grpc.CallOption) (*EdgeBatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EdgeBatch)
+	err := c.cc.Invoke(ctx, LinkGraph_UpsertEdges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkGraphClient) RemoveEdge(ctx context.Context, in *EdgeID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LinkGraph_RemoveEdge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkGraphClient) RemoveStaleEdges(ctx context.Context, in *RemoveStaleEdgesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LinkGraph_RemoveStaleEdges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkGraphClient) Links(ctx context.Context, in *RangeRequest, opts ...grpc.CallOption) (LinkGraph_LinksClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LinkGraph_ServiceDesc.Streams[0], LinkGraph_Links_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &linkGraphLinksClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LinkGraph_LinksClient interface {
+	Recv() (*Link, error)
+	grpc.ClientStream
+}
+
+type linkGraphLinksClient struct {
+	grpc.ClientStream
+}
+
+func (x *linkGraphLinksClient) Recv() (*Link, error) {
+	m := new(Link)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *linkGraphClient) Edges(ctx context.Context, in *RangeRequest, opts ...grpc.CallOption) (LinkGraph_EdgesClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LinkGraph_ServiceDesc.Streams[1], LinkGraph_Edges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &linkGraphEdgesClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LinkGraph_EdgesClient interface {
+	Recv() (*Edge, error)
+	grpc.ClientStream
+}
+
+type linkGraphEdgesClient struct {
+	grpc.ClientStream
+}
+
+func (x *linkGraphEdgesClient) Recv() (*Edge, error) {
+	m := new(Edge)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *linkGraphClient) InEdges(ctx context.Context, in *LinkID, opts ...grpc.CallOption) (LinkGraph_InEdgesClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LinkGraph_ServiceDesc.Streams[2], LinkGraph_InEdges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &linkGraphInEdgesClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LinkGraph_InEdgesClient interface {
+	Recv() (*Edge, error)
+	grpc.ClientStream
+}
+
+type linkGraphInEdgesClient struct {
+	grpc.ClientStream
+}
+
+func (x *linkGraphInEdgesClient) Recv() (*Edge, error) {
+	m := new(Edge)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *linkGraphClient) OutEdges(ctx context.Context, in *LinkID, opts ...grpc.CallOption) (LinkGraph_OutEdgesClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LinkGraph_ServiceDesc.Streams[3], LinkGraph_OutEdges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &linkGraphOutEdgesClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LinkGraph_OutEdgesClient interface {
+	Recv() (*Edge, error)
+	grpc.ClientStream
+}
+
+type linkGraphOutEdgesClient struct {
+	grpc.ClientStream
+}
+
+func (x *linkGraphOutEdgesClient) Recv() (*Edge, error) {
+	m := new(Edge)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *linkGraphClient) Degree(ctx context.Context, in *LinkID, opts ...grpc.CallOption) (*DegreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DegreeResponse)
+	err := c.cc.Invoke(ctx, LinkGraph_Degree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkGraphClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, LinkGraph_Stats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LinkGraphServer is the server API for LinkGraph service.
+// All implementations must embed UnimplementedLinkGraphServer
+// for forward compatibility
+//
+// LinkGraph exposes a link graph repository over gRPC. Its methods mirror
+// those of repository.GraphRepository; unknown links and edges yield a
+// NOT_FOUND status and edges referencing unknown links a
+// FAILED_PRECONDITION status.
+type LinkGraphServer interface {
+	UpsertLink(context.Context, *Link) (*Link, error)
+	UpsertLinks(context.Context, *LinkBatch) (*LinkBatch, error)
+	FindLink(context.Context, *LinkID) (*Link, error)
+	FindLinkByURL(context.Context, *FindLinkByURLRequest) (*Link, error)
+	FindLinks(context.Context, *LinkIDs) (*LinkBatch, error)
+	TouchLink(context.Context, *TouchLinkRequest) (*emptypb.Empty, error)
+	RemoveLink(context.Context, *LinkID) (*emptypb.Empty, error)
+	MergeLink(context.Context, *MergeLinkRequest) (*emptypb.Empty, error)
+	UpsertEdge(context.Context, *Edge) (*Edge, error)
+	UpsertEdges(context.Context, *EdgeBatch) (*EdgeBatch, error)
+	RemoveEdge(context.Context, *EdgeID) (*emptypb.Empty, error)
+	RemoveStaleEdges(context.Context, *RemoveStaleEdgesRequest) (*emptypb.Empty, error)
+	Links(*RangeRequest, LinkGraph_LinksServer) error
+	Edges(*RangeRequest, LinkGraph_EdgesServer) error
+	InEdges(*LinkID, LinkGraph_InEdgesServer) error
+	OutEdges(*LinkID, LinkGraph_OutEdgesServer) error
+	Degree(context.Context, *LinkID) (*DegreeResponse, error)
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+	mustEmbedUnimplementedLinkGraphServer()
+}
+
+// UnimplementedLinkGraphServer must be embedded to have forward compatible implementations.
+type UnimplementedLinkGraphServer struct {
+}
+
+func (UnimplementedLinkGraphServer) UpsertLink(context.Context, *Link) (*Link, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertLink not implemented")
+}
+func (UnimplementedLinkGraphServer) UpsertLinks(context.Context, *LinkBatch) (*LinkBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertLinks not implemented")
+}
+func (UnimplementedLinkGraphServer) FindLink(context.Context, *LinkID) (*Link, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindLink not implemented")
+}
+func (UnimplementedLinkGraphServer) FindLinkByURL(context.Context, *FindLinkByURLRequest) (*Link, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindLinkByURL not implemented")
+}
+func (UnimplementedLinkGraphServer) FindLinks(context.Context, *LinkIDs) (*LinkBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindLinks not implemented")
+}
+func (UnimplementedLinkGraphServer) TouchLink(context.Context, *TouchLinkRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TouchLink not implemented")
+}
+func (UnimplementedLinkGraphServer) RemoveLink(context.Context, *LinkID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveLink not implemented")
+}
+func (UnimplementedLinkGraphServer) MergeLink(context.Context, *MergeLinkRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeLink not implemented")
+}
+func (UnimplementedLinkGraphServer) UpsertEdge(context.Context, *Edge) (*Edge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertEdge not implemented")
+}
+func (UnimplementedLinkGraphServer) UpsertEdges(context.Context, *EdgeBatch) (*EdgeBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertEdges not implemented")
+}
+func (UnimplementedLinkGraphServer) RemoveEdge(context.Context, *EdgeID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveEdge not implemented")
+}
+func (UnimplementedLinkGraphServer) RemoveStaleEdges(context.Context, *RemoveStaleEdgesRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveStaleEdges not implemented")
+}
+func (UnimplementedLinkGraphServer) Links(*RangeRequest, LinkGraph_LinksServer) error {
+	return status.Errorf(codes.Unimplemented, "method Links not implemented")
+}
+func (UnimplementedLinkGraphServer) Edges(*RangeRequest, LinkGraph_EdgesServer) error {
+	return status.Errorf(codes.Unimplemented, "method Edges not implemented")
+}
+func (UnimplementedLinkGraphServer) InEdges(*LinkID, LinkGraph_InEdgesServer) error {
+	return status.Errorf(codes.Unimplemented, "method InEdges not implemented")
+}
+func (UnimplementedLinkGraphServer) OutEdges(*LinkID, LinkGraph_OutEdgesServer) error {
+	return status.Errorf(codes.Unimplemented, "method OutEdges not implemented")
+}
+func (UnimplementedLinkGraphServer) Degree(context.Context, *LinkID) (*DegreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Degree not implemented")
+}
+func (UnimplementedLinkGraphServer) Stats(context.Context, *StatsRequest) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+func (UnimplementedLinkGraphServer) mustEmbedUnimplementedLinkGraphServer() {}
+
+// UnsafeLinkGraphServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LinkGraphServer will
+// result in compilation errors.
+type UnsafeLinkGraphServer interface {
+	mustEmbedUnimplementedLinkGraphServer()
+}
+
+func RegisterLinkGraphServer(s grpc.ServiceRegistrar, srv LinkGraphServer) {
+	s.RegisterService(&LinkGraph_ServiceDesc, srv)
+}
+
+func _LinkGraph_UpsertLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Link)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkGraphServer).UpsertLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinkGraph_UpsertLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkGraphServer).UpsertLink(ctx, req.(*Link))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkGraph_UpsertLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkGraphServer).UpsertLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinkGraph_UpsertLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkGraphServer).UpsertLinks(ctx, req.(*LinkBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkGraph_FindLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkGraphServer).FindLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinkGraph_FindLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkGraphServer).FindLink(ctx, req.(*LinkID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkGraph_FindLinkByURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindLinkByURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkGraphServer).FindLinkByURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinkGraph_FindLinkByURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkGraphServer).FindLinkByURL(ctx, req.(*FindLinkByURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkGraph_FindLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkIDs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkGraphServer).FindLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinkGraph_FindLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkGraphServer).FindLinks(ctx, req.(*LinkIDs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkGraph_TouchLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TouchLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkGraphServer).TouchLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinkGraph_TouchLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkGraphServer).TouchLink(ctx, req.(*TouchLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkGraph_RemoveLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkGraphServer).RemoveLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinkGraph_RemoveLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkGraphServer).RemoveLink(ctx, req.(*LinkID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkGraph_MergeLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkGraphServer).MergeLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinkGraph_MergeLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkGraphServer).MergeLink(ctx, req.(*MergeLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkGraph_UpsertEdge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Edge)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkGraphServer).UpsertEdge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinkGraph_UpsertEdge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkGraphServer).UpsertEdge(ctx, req.(*Edge))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkGraph_UpsertEdges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EdgeBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkGraphServer).UpsertEdges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinkGraph_UpsertEdges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkGraphServer).UpsertEdges(ctx, req.(*EdgeBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkGraph_RemoveEdge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EdgeID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkGraphServer).RemoveEdge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinkGraph_RemoveEdge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkGraphServer).RemoveEdge(ctx, req.(*EdgeID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkGraph_RemoveStaleEdges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveStaleEdgesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkGraphServer).RemoveStaleEdges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinkGraph_RemoveStaleEdges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkGraphServer).RemoveStaleEdges(ctx, req.(*RemoveStaleEdgesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkGraph_Links_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LinkGraphServer).Links(m, &linkGraphLinksServer{ServerStream: stream})
+}
+
+type LinkGraph_LinksServer interface {
+	Send(*Link) error
+	grpc.ServerStream
+}
+
+type linkGraphLinksServer struct {
+	grpc.ServerStream
+}
+
+func (x *linkGraphLinksServer) Send(m *Link) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _LinkGraph_Edges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LinkGraphServer).Edges(m, &linkGraphEdgesServer{ServerStream: stream})
+}
+
+type LinkGraph_EdgesServer interface {
+	Send(*Edge) error
+	grpc.ServerStream
+}
+
+type linkGraphEdgesServer struct {
+	grpc.ServerStream
+}
+
+func (x *linkGraphEdgesServer) Send(m *Edge) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _LinkGraph_InEdges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LinkID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LinkGraphServer).InEdges(m, &linkGraphInEdgesServer{ServerStream: stream})
+}
+
+type LinkGraph_InEdgesServer interface {
+	Send(*Edge) error
+	grpc.ServerStream
+}
+
+type linkGraphInEdgesServer struct {
+	grpc.ServerStream
+}
+
+func (x *linkGraphInEdgesServer) Send(m *Edge) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _LinkGraph_OutEdges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LinkID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LinkGraphServer).OutEdges(m, &linkGraphOutEdgesServer{ServerStream: stream})
+}
+
+type LinkGraph_OutEdgesServer interface {
+	Send(*Edge) error
+	grpc.ServerStream
+}
+
+type linkGraphOutEdgesServer struct {
+	grpc.ServerStream
+}
+
+func (x *linkGraphOutEdgesServer) Send(m *Edge) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _LinkGraph_Degree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkGraphServer).Degree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinkGraph_Degree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkGraphServer).Degree(ctx, req.(*LinkID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkGraph_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkGraphServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinkGraph_Stats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkGraphServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LinkGraph_ServiceDesc is the grpc.ServiceDesc for LinkGraph service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LinkGraph_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "linkgraph.LinkGraph",
+	HandlerType: (*LinkGraphServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UpsertLink",
+			Handler:    _LinkGraph_UpsertLink_Handler,
+		},
+		{
+			MethodName: "UpsertLinks",
+			Handler:    _LinkGraph_UpsertLinks_Handler,
+		},
+		{
+			MethodName: "FindLink",
+			Handler:    _LinkGraph_FindLink_Handler,
+		},
+		{
+			MethodName: "FindLinkByURL",
+			Handler:    _LinkGraph_FindLinkByURL_Handler,
+		},
+		{
+			MethodName: "FindLinks",
+			Handler:    _LinkGraph_FindLinks_Handler,
+		},
+		{
+			MethodName: "TouchLink",
+			Handler:    _LinkGraph_TouchLink_Handler,
+		},
+		{
+			MethodName: "RemoveLink",
+			Handler:    _LinkGraph_RemoveLink_Handler,
+		},
+		{
+			MethodName: "MergeLink",
+			Handler:    _LinkGraph_MergeLink_Handler,
+		},
+		{
+			MethodName: "UpsertEdge",
+			Handler:    _LinkGraph_UpsertEdge_Handler,
+		},
+		{
+			MethodName: "UpsertEdges",
+			Handler:    _LinkGraph_UpsertEdges_Handler,
+		},
+		{
+			MethodName: "RemoveEdge",
+			Handler:    _LinkGraph_RemoveEdge_Handler,
+		},
+		{
+			MethodName: "RemoveStaleEdges",
+			Handler:    _LinkGraph_RemoveStaleEdges_Handler,
+		},
+		{
+			MethodName: "Degree",
+			Handler:    _LinkGraph_Degree_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _LinkGraph_Stats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Links",
+			Handler:       _LinkGraph_Links_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Edges",
+			Handler:       _LinkGraph_Edges_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "InEdges",
+			Handler:       _LinkGraph_InEdges_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "OutEdges",
+			Handler:       _LinkGraph_OutEdges_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "linkgraph/linkgraphpb/linkgraph.proto",
+}
@@ -0,0 +1,319 @@
+// Package linkgraph exposes a repository.GraphRepository over gRPC and
+// provides a client implementing repository.GraphRepository on top of it.
+package linkgraph
+
+import (
+	"context"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/linkgraph/linkgraphpb"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Server serves the LinkGraph gRPC API on top of a graph repository.
+type Server struct {
+	linkgraphpb.UnimplementedLinkGraphServer
+	graph repository.GraphRepository
+}
+
+// NewServer creates a Server backed by graph.
+func NewServer(graph repository.GraphRepository) *Server {
+	return &Server{graph: graph}
+}
+
+// UpsertLink implements linkgraphpb.LinkGraphServer.
+func (s *Server) UpsertLink(ctx context.Context, req *linkgraphpb.Link) (*linkgraphpb.Link, error) {
+	link, err := linkFromProto(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = s.graph.UpsertLink(ctx, link); err != nil {
+		return nil, toStatus(err)
+	}
+	return linkToProto(link), nil
+}
+
+// UpsertLinks implements linkgraphpb.LinkGraphServer.
+func (s *Server) UpsertLinks(ctx context.Context, req *linkgraphpb.LinkBatch) (*linkgraphpb.LinkBatch, error) {
+	links := make([]*domain.Link, len(req.GetLinks()))
+	for i, msg := range req.GetLinks() {
+		link, err := linkFromProto(msg)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		links[i] = link
+	}
+	if err := s.graph.UpsertLinks(ctx, links); err != nil {
+		return nil, toStatus(err)
+	}
+	return linkBatch(links), nil
+}
+
+// FindLink implements linkgraphpb.LinkGraphServer.
+func (s *Server) FindLink(ctx context.Context, req *linkgraphpb.LinkID) (*linkgraphpb.Link, error) {
+	id, err := idFromProto(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	link, err := s.graph.FindLink(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return linkToProto(link), nil
+}
+
+// FindLinkByURL implements linkgraphpb.LinkGraphServer.
+func (s *Server) FindLinkByURL(ctx context.Context, req *linkgraphpb.FindLinkByURLRequest) (*linkgraphpb.Link, error) {
+	link, err := s.graph.FindLinkByURL(ctx, req.GetUrl())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return linkToProto(link), nil
+}
+
+// FindLinks implements linkgraphpb.LinkGraphServer.
+func (s *Server) FindLinks(ctx context.Context, req *linkgraphpb.LinkIDs) (*linkgraphpb.LinkBatch, error) {
+	ids := make([]uuid.UUID, len(req.GetIds()))
+	for i, b := range req.GetIds() {
+		id, err := idFromProto(b)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		ids[i] = id
+	}
+	links, err := s.graph.FindLinks(ctx, ids)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return linkBatch(links), nil
+}
+
+// TouchLink implements linkgraphpb.LinkGraphServer.
+func (s *Server) TouchLink(ctx context.Context, req *linkgraphpb.TouchLinkRequest) (*emptypb.Empty, error) {
+	id, err := idFromProto(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = s.graph.TouchLink(ctx, id, timeFromProto(req.GetRetrievedAt())); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RemoveLink implements linkgraphpb.LinkGraphServer.
+func (s *Server) RemoveLink(ctx context.Context, req *linkgraphpb.LinkID) (*emptypb.Empty, error) {
+	id, err := idFromProto(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = s.graph.RemoveLink(ctx, id); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// MergeLink implements linkgraphpb.LinkGraphServer.
+func (s *Server) MergeLink(ctx context.Context, req *linkgraphpb.MergeLinkRequest) (*emptypb.Empty, error) {
+	id, err := idFromProto(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	canonicalID, err := idFromProto(req.GetCanonicalId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = s.graph.MergeLink(ctx, id, canonicalID); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// UpsertEdge implements linkgraphpb.LinkGraphServer.
+func (s *Server) UpsertEdge(ctx context.Context, req *linkgraphpb.Edge) (*linkgraphpb.Edge, error) {
+	edge, err := edgeFromProto(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = s.graph.UpsertEdge(ctx, edge); err != nil {
+		return nil, toStatus(err)
+	}
+	return edgeToProto(edge), nil
+}
+
+// UpsertEdges implements linkgraphpb.LinkGraphServer.
+func (s *Server) UpsertEdges(ctx context.Context, req *linkgraphpb.EdgeBatch) (*linkgraphpb.EdgeBatch, error) {
+	edges := make([]*domain.Edge, len(req.GetEdges()))
+	for i, msg := range req.GetEdges() {
+		edge, err := edgeFromProto(msg)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		edges[i] = edge
+	}
+	if err := s.graph.UpsertEdges(ctx, edges); err != nil {
+		return nil, toStatus(err)
+	}
+	res := &linkgraphpb.EdgeBatch{Edges: make([]*linkgraphpb.Edge, len(edges))}
+	for i, edge := range edges {
+		res.Edges[i] = edgeToProto(edge)
+	}
+	return res, nil
+}
+
+// RemoveEdge implements linkgraphpb.LinkGraphServer.
+func (s *Server) RemoveEdge(ctx context.Context, req *linkgraphpb.EdgeID) (*emptypb.Empty, error) {
+	id, err := idFromProto(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = s.graph.RemoveEdge(ctx, id); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RemoveStaleEdges implements linkgraphpb.LinkGraphServer.
+func (s *Server) RemoveStaleEdges(ctx context.Context, req *linkgraphpb.RemoveStaleEdgesRequest) (*emptypb.Empty, error) {
+	fromID, err := idFromProto(req.GetFromId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = s.graph.RemoveStaleEdges(ctx, fromID, timeFromProto(req.GetUpdatedBefore())); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Links implements linkgraphpb.LinkGraphServer.
+func (s *Server) Links(req *linkgraphpb.RangeRequest, stream linkgraphpb.LinkGraph_LinksServer) error {
+	ids, err := rangeFromProto(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	it, err := s.graph.Links(stream.Context(), ids, timeFromProto(req.GetBefore()))
+	if err != nil {
+		return toStatus(err)
+	}
+	return sendAll(it, func() error { return stream.Send(linkToProto(it.Link())) })
+}
+
+// Edges implements linkgraphpb.LinkGraphServer.
+func (s *Server) Edges(req *linkgraphpb.RangeRequest, stream linkgraphpb.LinkGraph_EdgesServer) error {
+	ids, err := rangeFromProto(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	it, err := s.graph.Edges(stream.Context(), ids, timeFromProto(req.GetBefore()))
+	if err != nil {
+		return toStatus(err)
+	}
+	return sendAll(it, func() error { return stream.Send(edgeToProto(it.Edge())) })
+}
+
+// InEdges implements linkgraphpb.LinkGraphServer.
+func (s *Server) InEdges(req *linkgraphpb.LinkID, stream linkgraphpb.LinkGraph_InEdgesServer) error {
+	id, err := idFromProto(req.GetId())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	it, err := s.graph.InEdges(stream.Context(), id)
+	if err != nil {
+		return toStatus(err)
+	}
+	return sendAll(it, func() error { return stream.Send(edgeToProto(it.Edge())) })
+}
+
+// OutEdges implements linkgraphpb.LinkGraphServer.
+func (s *Server) OutEdges(req *linkgraphpb.LinkID, stream linkgraphpb.LinkGraph_OutEdgesServer) error {
+	id, err := idFromProto(req.GetId())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	it, err := s.graph.OutEdges(stream.Context(), id)
+	if err != nil {
+		return toStatus(err)
+	}
+	return sendAll(it, func() error { return stream.Send(edgeToProto(it.Edge())) })
+}
+
+// Degree implements linkgraphpb.LinkGraphServer.
+func (s *Server) Degree(ctx context.Context, req *linkgraphpb.LinkID) (*linkgraphpb.DegreeResponse, error) {
+	id, err := idFromProto(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	in, out, err := s.graph.Degree(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &linkgraphpb.DegreeResponse{InDegree: int64(in), OutDegree: int64(out)}, nil
+}
+
+// Stats implements linkgraphpb.LinkGraphServer.
+func (s *Server) Stats(ctx context.Context, req *linkgraphpb.StatsRequest) (*linkgraphpb.StatsResponse, error) {
+	stats, err := s.graph.Stats(ctx, repository.GraphStatsOptions{
+		RetrievedWithin: req.GetRetrievedWithin().AsDuration(),
+		NumPartitions:   int(req.GetNumPartitions()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &linkgraphpb.StatsResponse{
+		Links:                  int64(stats.Links),
+		Edges:                  int64(stats.Edges),
+		UnretrievedLinks:       int64(stats.UnretrievedLinks),
+		RecentlyRetrievedLinks: int64(stats.RecentlyRetrievedLinks),
+		EdgesPerPartition:      make([]int64, len(stats.EdgesPerPartition)),
+		InDegreeHistogram:      make(map[int64]int64, len(stats.InDegreeHistogram)),
+		OutDegreeHistogram:     make(map[int64]int64, len(stats.OutDegreeHistogram)),
+	}
+	for i, n := range stats.EdgesPerPartition {
+		res.EdgesPerPartition[i] = int64(n)
+	}
+	for degree, n := range stats.InDegreeHistogram {
+		res.InDegreeHistogram[int64(degree)] = int64(n)
+	}
+	for degree, n := range stats.OutDegreeHistogram {
+		res.OutDegreeHistogram[int64(degree)] = int64(n)
+	}
+	return res, nil
+}
+
+func linkBatch(links []*domain.Link) *linkgraphpb.LinkBatch {
+	res := &linkgraphpb.LinkBatch{Links: make([]*linkgraphpb.Link, len(links))}
+	for i, link := range links {
+		res.Links[i] = linkToProto(link)
+	}
+	return res
+}
+
+func rangeFromProto(req *linkgraphpb.RangeRequest) (repository.IDRange, error) {
+	from, err := idFromProto(req.GetFrom())
+	if err != nil {
+		return repository.IDRange{}, err
+	}
+	to, err := idFromProto(req.GetTo())
+	if err != nil {
+		return repository.IDRange{}, err
+	}
+	return repository.NewIDRange(from, to), nil
+}
+
+// sendAll calls send for each item of it and closes it.
+func sendAll(it repository.Iterator, send func() error) error {
+	defer func() { _ = it.Close() }()
+	for it.Next() {
+		if err := send(); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return toStatus(err)
+	}
+	return nil
+}
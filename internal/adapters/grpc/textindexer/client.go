@@ -0,0 +1,170 @@
+package textindexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/textindexer/textindexerpb"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+)
+
+// Client is a ports.TextIndexer backed by a remote TextIndexer gRPC service.
+type Client struct {
+	cli textindexerpb.TextIndexerClient
+}
+
+// NewClient creates a Client that issues its requests over conn.
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{cli: textindexerpb.NewTextIndexerClient(conn)}
+}
+
+// Index implements ports.TextIndexer.
+func (c *Client) Index(ctx context.Context, doc *domain.Document) error {
+	res, err := c.cli.Index(ctx, docToProto(doc))
+	if err != nil {
+		return fromStatus(err)
+	}
+	indexed, err := docFromProto(res)
+	if err != nil {
+		return err
+	}
+	*doc = *indexed
+	return nil
+}
+
+// FindByID implements ports.TextIndexer.
+func (c *Client) FindByID(ctx context.Context, linkID uuid.UUID) (*domain.Document, error) {
+	res, err := c.cli.FindByID(ctx, &textindexerpb.LinkID{LinkId: idToProto(linkID)})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return docFromProto(res)
+}
+
+// Search implements ports.TextIndexer.
+func (c *Client) Search(ctx context.Context, query *ports.DocumentQuery) (ports.DocumentIterator, error) {
+	queryType := textindexerpb.Query_MATCH
+	if query.Type == ports.DocumentQueryTypePhrase {
+		queryType = textindexerpb.Query_PHRASE
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream, err := c.cli.Search(streamCtx, &textindexerpb.Query{
+		Type:       queryType,
+		Expression: query.Expression,
+		Offset:     query.Offset,
+	})
+	if err != nil {
+		cancel()
+		return nil, fromStatus(err)
+	}
+
+	// The server sends the total count ahead of the documents.
+	res, err := stream.Recv()
+	if err != nil {
+		cancel()
+		return nil, fromStatus(err)
+	}
+	if _, ok := res.GetResult().(*textindexerpb.SearchResult_TotalCount); !ok {
+		cancel()
+		return nil, fmt.Errorf("search: expected total count, got %T", res.GetResult())
+	}
+	return &documentIterator{
+		ctx:        ctx,
+		cancel:     cancel,
+		stream:     stream,
+		totalCount: res.GetTotalCount(),
+	}, nil
+}
+
+// UpdateScore implements ports.TextIndexer.
+func (c *Client) UpdateScore(ctx context.Context, linkID uuid.UUID, score float64) error {
+	_, err := c.cli.UpdateScore(ctx, &textindexerpb.UpdateScoreRequest{LinkId: idToProto(linkID), Score: score})
+	return fromStatus(err)
+}
+
+// UpdateAnchorText implements ports.TextIndexer.
+func (c *Client) UpdateAnchorText(ctx context.Context, linkID uuid.UUID, anchorText []string) error {
+	_, err := c.cli.UpdateAnchorText(ctx, &textindexerpb.UpdateAnchorTextRequest{
+		LinkId:     idToProto(linkID),
+		AnchorText: anchorText,
+	})
+	return fromStatus(err)
+}
+
+// FindNearDuplicates implements ports.TextIndexer.
+func (c *Client) FindNearDuplicates(ctx context.Context, fingerprint uint64, maxDistance int) ([]*domain.Document, error) {
+	res, err := c.cli.FindNearDuplicates(ctx, &textindexerpb.FindNearDuplicatesRequest{
+		Fingerprint: fingerprint,
+		MaxDistance: int32(maxDistance),
+	})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	docs := make([]*domain.Document, len(res.GetDocuments()))
+	for i, msg := range res.GetDocuments() {
+		if docs[i], err = docFromProto(msg); err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+// documentIterator is a ports.DocumentIterator receiving search results
+// from the server. Closing it cancels the stream.
+type documentIterator struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	stream textindexerpb.TextIndexer_SearchClient
+
+	totalCount uint64
+	doc        *domain.Document
+	lastErr    error
+}
+
+// Next implements ports.DocumentIterator.
+func (i *documentIterator) Next() bool {
+	if i.lastErr != nil {
+		return false
+	}
+	res, err := i.stream.Recv()
+	switch {
+	case errors.Is(err, io.EOF):
+		return false
+	case err != nil:
+		// The stream reports a cancelled ctx with its own status; report
+		// the context error as the other indexers do.
+		if i.lastErr = i.ctx.Err(); i.lastErr == nil {
+			i.lastErr = fromStatus(err)
+		}
+		return false
+	}
+	i.doc, i.lastErr = docFromProto(res.GetDocument())
+	return i.lastErr == nil
+}
+
+// Error implements ports.DocumentIterator.
+func (i *documentIterator) Error() error {
+	return i.lastErr
+}
+
+// Document implements ports.DocumentIterator.
+func (i *documentIterator) Document() *domain.Document {
+	return i.doc
+}
+
+// TotalCount implements ports.DocumentIterator.
+func (i *documentIterator) TotalCount() uint64 {
+	return i.totalCount
+}
+
+// Close implements ports.DocumentIterator.
+func (i *documentIterator) Close() error {
+	i.cancel()
+	return nil
+}
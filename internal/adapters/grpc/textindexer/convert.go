@@ -0,0 +1,109 @@
+package textindexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/textindexer/textindexerpb"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// idToProto encodes id as its 16 bytes, or as no bytes if it is uuid.Nil.
+func idToProto(id uuid.UUID) []byte {
+	if id == uuid.Nil {
+		return nil
+	}
+	return id[:]
+}
+
+// idFromProto decodes an ID encoded by idToProto.
+func idFromProto(b []byte) (uuid.UUID, error) {
+	if len(b) == 0 {
+		return uuid.Nil, nil
+	}
+	id, err := uuid.FromBytes(b)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("decode link id: %w", err)
+	}
+	return id, nil
+}
+
+func docToProto(doc *domain.Document) *textindexerpb.Document {
+	msg := &textindexerpb.Document{
+		LinkId:      idToProto(doc.LinkID),
+		Url:         doc.URL,
+		Title:       doc.Title,
+		Content:     doc.Content,
+		PageRank:    doc.PageRank,
+		AnchorText:  doc.AnchorText,
+		Fingerprint: doc.Fingerprint,
+	}
+	if !doc.IndexedAt.IsZero() {
+		msg.IndexedAt = timestamppb.New(doc.IndexedAt)
+	}
+	return msg
+}
+
+func docFromProto(msg *textindexerpb.Document) (*domain.Document, error) {
+	linkID, err := idFromProto(msg.GetLinkId())
+	if err != nil {
+		return nil, err
+	}
+	doc := &domain.Document{
+		LinkID:      linkID,
+		URL:         msg.GetUrl(),
+		Title:       msg.GetTitle(),
+		Content:     msg.GetContent(),
+		PageRank:    msg.GetPageRank(),
+		AnchorText:  msg.GetAnchorText(),
+		Fingerprint: msg.GetFingerprint(),
+	}
+	if msg.GetIndexedAt() != nil {
+		doc.IndexedAt = msg.GetIndexedAt().AsTime()
+	}
+	return doc, nil
+}
+
+// toStatus converts an error returned by a text indexer into a gRPC status
+// error.
+func toStatus(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, ports.TextIndexerErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, ports.TextIndexerErrMissingLinkID):
+		code = codes.InvalidArgument
+	case errors.Is(err, ports.TextIndexerErrDistanceTooLarge):
+		code = codes.OutOfRange
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	}
+	return status.Error(code, err.Error())
+}
+
+// fromStatus converts a gRPC status error returned by the server into the
+// error the text indexer returned.
+func fromStatus(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return ports.TextIndexerErrNotFound
+	case codes.InvalidArgument:
+		return ports.TextIndexerErrMissingLinkID
+	case codes.OutOfRange:
+		return ports.TextIndexerErrDistanceTooLarge
+	case codes.Canceled:
+		return context.Canceled
+	case codes.DeadlineExceeded:
+		return context.DeadlineExceeded
+	default:
+		return err
+	}
+}
@@ -0,0 +1,89 @@
+package textindexer
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/textindexer/textindexerpb"
+	"github.com/bruceneco/links-r-us/internal/adapters/textindexer/index/indextest"
+	"github.com/bruceneco/links-r-us/internal/adapters/textindexer/store/memory"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func Test(t *testing.T) {
+	suite.Run(t, new(GRPCIndexerTestSuite))
+}
+
+// GRPCIndexerTestSuite runs the indexer test suite against a Client talking
+// to a Server backed by the in-memory indexer.
+type GRPCIndexerTestSuite struct {
+	suite.Suite
+	base indextest.SuiteBase
+
+	idx  io.Closer
+	srv  *grpc.Server
+	conn *grpc.ClientConn
+}
+
+func (s *GRPCIndexerTestSuite) SetupTest() {
+	idx, err := memory.NewInMemoryIndexer()
+	s.Require().NoError(err)
+	s.idx = idx.(io.Closer)
+
+	lis := bufconn.Listen(1 << 20)
+	s.srv = grpc.NewServer()
+	textindexerpb.RegisterTextIndexerServer(s.srv, NewServer(idx))
+	go func() { _ = s.srv.Serve(lis) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	s.Require().NoError(err)
+	s.conn = conn
+	s.base.SetIndexer(NewClient(conn))
+}
+
+func (s *GRPCIndexerTestSuite) TearDownTest() {
+	_ = s.conn.Close()
+	s.srv.Stop()
+	s.Nil(s.idx.Close())
+}
+func (s *GRPCIndexerTestSuite) TestIndexDocument() {
+	s.base.TestIndexDocument(s.T())
+}
+func (s *GRPCIndexerTestSuite) TestIndexDoesNotOverridePageRank() {
+	s.base.TestIndexDoesNotOverridePageRank(s.T())
+}
+func (s *GRPCIndexerTestSuite) TestFindByID() {
+	s.base.TestFindByID(s.T())
+}
+func (s *GRPCIndexerTestSuite) TestPhraseSearch() {
+	s.base.TestPhraseSearch(s.T())
+}
+func (s *GRPCIndexerTestSuite) TestMatchSearch() {
+	s.base.TestMatchSearch(s.T())
+}
+func (s *GRPCIndexerTestSuite) TestMatchSearchWithOffset() {
+	s.base.TestMatchSearchWithOffset(s.T())
+}
+func (s *GRPCIndexerTestSuite) TestSearchContextCancellation() {
+	s.base.TestSearchContextCancellation(s.T())
+}
+func (s *GRPCIndexerTestSuite) TestUpdateScore() {
+	s.base.TestUpdateScore(s.T())
+}
+func (s *GRPCIndexerTestSuite) TestUpdateScoreForUnknownDocument() {
+	s.base.TestUpdateScoreForUnknownDocument(s.T())
+}
+func (s *GRPCIndexerTestSuite) TestUpdateAnchorText() {
+	s.base.TestUpdateAnchorText(s.T())
+}
+func (s *GRPCIndexerTestSuite) TestFindNearDuplicates() {
+	s.base.TestFindNearDuplicates(s.T())
+}
@@ -0,0 +1,120 @@
+// Package textindexer exposes a ports.TextIndexer over gRPC and provides a
+// client implementing ports.TextIndexer on top of it.
+package textindexer
+
+import (
+	"context"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/grpc/textindexer/textindexerpb"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Server serves the TextIndexer gRPC API on top of a text indexer.
+type Server struct {
+	textindexerpb.UnimplementedTextIndexerServer
+	indexer ports.TextIndexer
+}
+
+// NewServer creates a Server backed by indexer.
+func NewServer(indexer ports.TextIndexer) *Server {
+	return &Server{indexer: indexer}
+}
+
+// Index implements textindexerpb.TextIndexerServer.
+func (s *Server) Index(ctx context.Context, req *textindexerpb.Document) (*textindexerpb.Document, error) {
+	doc, err := docFromProto(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = s.indexer.Index(ctx, doc); err != nil {
+		return nil, toStatus(err)
+	}
+	return docToProto(doc), nil
+}
+
+// FindByID implements textindexerpb.TextIndexerServer.
+func (s *Server) FindByID(ctx context.Context, req *textindexerpb.LinkID) (*textindexerpb.Document, error) {
+	linkID, err := idFromProto(req.GetLinkId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	doc, err := s.indexer.FindByID(ctx, linkID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return docToProto(doc), nil
+}
+
+// Search implements textindexerpb.TextIndexerServer.
+func (s *Server) Search(req *textindexerpb.Query, stream textindexerpb.TextIndexer_SearchServer) error {
+	queryType := ports.DocumentQueryTypeMatch
+	if req.GetType() == textindexerpb.Query_PHRASE {
+		queryType = ports.DocumentQueryTypePhrase
+	}
+	it, err := s.indexer.Search(stream.Context(), &ports.DocumentQuery{
+		Type:       queryType,
+		Expression: req.GetExpression(),
+		Offset:     req.GetOffset(),
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+	defer func() { _ = it.Close() }()
+
+	if err = stream.Send(&textindexerpb.SearchResult{
+		Result: &textindexerpb.SearchResult_TotalCount{TotalCount: it.TotalCount()},
+	}); err != nil {
+		return err
+	}
+	for it.Next() {
+		if err = stream.Send(&textindexerpb.SearchResult{
+			Result: &textindexerpb.SearchResult_Document{Document: docToProto(it.Document())},
+		}); err != nil {
+			return err
+		}
+	}
+	if err = it.Error(); err != nil {
+		return toStatus(err)
+	}
+	return nil
+}
+
+// UpdateScore implements textindexerpb.TextIndexerServer.
+func (s *Server) UpdateScore(ctx context.Context, req *textindexerpb.UpdateScoreRequest) (*emptypb.Empty, error) {
+	linkID, err := idFromProto(req.GetLinkId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = s.indexer.UpdateScore(ctx, linkID, req.GetScore()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// UpdateAnchorText implements textindexerpb.TextIndexerServer.
+func (s *Server) UpdateAnchorText(ctx context.Context, req *textindexerpb.UpdateAnchorTextRequest) (*emptypb.Empty, error) {
+	linkID, err := idFromProto(req.GetLinkId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = s.indexer.UpdateAnchorText(ctx, linkID, req.GetAnchorText()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// FindNearDuplicates implements textindexerpb.TextIndexerServer.
+func (s *Server) FindNearDuplicates(ctx context.Context, req *textindexerpb.FindNearDuplicatesRequest) (*textindexerpb.DocumentBatch, error) {
+	docs, err := s.indexer.FindNearDuplicates(ctx, req.GetFingerprint(), int(req.GetMaxDistance()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &textindexerpb.DocumentBatch{Documents: make([]*textindexerpb.Document, len(docs))}
+	for i, doc := range docs {
+		res.Documents[i] = docToProto(doc)
+	}
+	return res, nil
+}
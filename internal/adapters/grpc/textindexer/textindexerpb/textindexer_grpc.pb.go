@@ -0,0 +1,347 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: textindexer/textindexerpb/textindexer.proto
+
+package textindexerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	TextIndexer_Index_FullMethodName              = "/textindexer.TextIndexer/Index"
+	TextIndexer_FindByID_FullMethodName           = "/textindexer.TextIndexer/FindByID"
+	TextIndexer_Search_FullMethodName             = "/textindexer.TextIndexer/Search"
+	TextIndexer_UpdateScore_FullMethodName        = "/textindexer.TextIndexer/UpdateScore"
+	TextIndexer_UpdateAnchorText_FullMethodName   = "/textindexer.TextIndexer/UpdateAnchorText"
+	TextIndexer_FindNearDuplicates_FullMethodName = "/textindexer.TextIndexer/FindNearDuplicates"
+)
+
+// TextIndexerClient is the client API for TextIndexer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TextIndexer exposes a text indexer over gRPC. Its methods mirror those of
+// ports.TextIndexer; unknown documents yield a NOT_FOUND status, documents
+// without a link ID an INVALID_ARGUMENT status and near-duplicate lookups
+// beyond the supported distance an OUT_OF_RANGE status.
+type TextIndexerClient interface {
+	// Index returns the document as stored, with its indexed_at and
+	// fingerprint set.
+	Index(ctx context.Context, in *Document, opts ...grpc.CallOption) (*Document, error)
+	FindByID(ctx context.Context, in *LinkID, opts ...grpc.CallOption) (*Document, error)
+	// Search streams the total number of matches first, followed by the
+	// matching documents from the requested offset on.
+	Search(ctx context.Context, in *Query, opts ...grpc.CallOption) (TextIndexer_SearchClient, error)
+	UpdateScore(ctx context.Context, in *UpdateScoreRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateAnchorText(ctx context.Context, in *UpdateAnchorTextRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	FindNearDuplicates(ctx context.Context, in *FindNearDuplicatesRequest, opts ...grpc.CallOption) (*DocumentBatch, error)
+}
+
+type textIndexerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTextIndexerClient(cc grpc.ClientConnInterface) TextIndexerClient {
+	return &textIndexerClient{cc}
+}
+
+func (c *textIndexerClient) Index(ctx context.Context, in *Document, opts ...grpc.CallOption) (*Document, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Document)
+	err := c.cc.Invoke(ctx, TextIndexer_Index_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *textIndexerClient) FindByID(ctx context.Context, in *LinkID, opts ...grpc.CallOption) (*Document, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Document)
+	err := c.cc.Invoke(ctx, TextIndexer_FindByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *textIndexerClient) Search(ctx context.Context, in *Query, opts ...grpc.CallOption) (TextIndexer_SearchClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TextIndexer_ServiceDesc.Streams[0], TextIndexer_Search_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &textIndexerSearchClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TextIndexer_SearchClient interface {
+	Recv() (*SearchResult, error)
+	grpc.ClientStream
+}
+
+type textIndexerSearchClient struct {
+	grpc.ClientStream
+}
+
+func (x *textIndexerSearchClient) Recv() (*SearchResult, error) {
+	m := new(SearchResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *textIndexerClient) UpdateScore(ctx context.Context, in *UpdateScoreRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TextIndexer_UpdateScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *textIndexerClient) UpdateAnchorText(ctx context.Context, in *UpdateAnchorTextRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TextIndexer_UpdateAnchorText_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *textIndexerClient) FindNearDuplicates(ctx context.Context, in *FindNearDuplicatesRequest, opts ...grpc.CallOption) (*DocumentBatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DocumentBatch)
+	err := c.cc.Invoke(ctx, TextIndexer_FindNearDuplicates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TextIndexerServer is the server API for TextIndexer service.
+// All implementations must embed UnimplementedTextIndexerServer
+// for forward compatibility
+//
+// TextIndexer exposes a text indexer over gRPC. Its methods mirror those of
+// ports.TextIndexer; unknown documents yield a NOT_FOUND status, documents
+// without a link ID an INVALID_ARGUMENT status and near-duplicate lookups
+// beyond the supported distance an OUT_OF_RANGE status.
+type TextIndexerServer interface {
+	// Index returns the document as stored, with its indexed_at and
+	// fingerprint set.
+	Index(context.Context, *Document) (*Document, error)
+	FindByID(context.Context, *LinkID) (*Document, error)
+	// Search streams the total number of matches first, followed by the
+	// matching documents from the requested offset on.
+	Search(*Query, TextIndexer_SearchServer) error
+	UpdateScore(context.Context, *UpdateScoreRequest) (*emptypb.Empty, error)
+	UpdateAnchorText(context.Context, *UpdateAnchorTextRequest) (*emptypb.Empty, error)
+	FindNearDuplicates(context.Context, *FindNearDuplicatesRequest) (*DocumentBatch, error)
+	mustEmbedUnimplementedTextIndexerServer()
+}
+
+// UnimplementedTextIndexerServer must be embedded to have forward compatible implementations.
+type UnimplementedTextIndexerServer struct {
+}
+
+func (UnimplementedTextIndexerServer) Index(context.Context, *Document) (*Document, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Index not implemented")
+}
+func (UnimplementedTextIndexerServer) FindByID(context.Context, *LinkID) (*Document, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindByID not implemented")
+}
+func (UnimplementedTextIndexerServer) Search(*Query, TextIndexer_SearchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedTextIndexerServer) UpdateScore(context.Context, *UpdateScoreRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateScore not implemented")
+}
+func (UnimplementedTextIndexerServer) UpdateAnchorText(context.Context, *UpdateAnchorTextRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAnchorText not implemented")
+}
+func (UnimplementedTextIndexerServer) FindNearDuplicates(context.Context, *FindNearDuplicatesRequest) (*DocumentBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindNearDuplicates not implemented")
+}
+func (UnimplementedTextIndexerServer) mustEmbedUnimplementedTextIndexerServer() {}
+
+// UnsafeTextIndexerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TextIndexerServer will
+// result in compilation errors.
+type UnsafeTextIndexerServer interface {
+	mustEmbedUnimplementedTextIndexerServer()
+}
+
+func RegisterTextIndexerServer(s grpc.ServiceRegistrar, srv TextIndexerServer) {
+	s.RegisterService(&TextIndexer_ServiceDesc, srv)
+}
+
+func _TextIndexer_Index_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Document)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TextIndexerServer).Index(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TextIndexer_Index_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TextIndexerServer).Index(ctx, req.(*Document))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TextIndexer_FindByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TextIndexerServer).FindByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TextIndexer_FindByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TextIndexerServer).FindByID(ctx, req.(*LinkID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TextIndexer_Search_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Query)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TextIndexerServer).Search(m, &textIndexerSearchServer{ServerStream: stream})
+}
+
+type TextIndexer_SearchServer interface {
+	Send(*SearchResult) error
+	grpc.ServerStream
+}
+
+type textIndexerSearchServer struct {
+	grpc.ServerStream
+}
+
+func (x *textIndexerSearchServer) Send(m *SearchResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TextIndexer_UpdateScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TextIndexerServer).UpdateScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TextIndexer_UpdateScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TextIndexerServer).UpdateScore(ctx, req.(*UpdateScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TextIndexer_UpdateAnchorText_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAnchorTextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TextIndexerServer).UpdateAnchorText(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TextIndexer_UpdateAnchorText_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TextIndexerServer).UpdateAnchorText(ctx, req.(*UpdateAnchorTextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TextIndexer_FindNearDuplicates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindNearDuplicatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TextIndexerServer).FindNearDuplicates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TextIndexer_FindNearDuplicates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TextIndexerServer).FindNearDuplicates(ctx, req.(*FindNearDuplicatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TextIndexer_ServiceDesc is the grpc.ServiceDesc for TextIndexer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TextIndexer_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "textindexer.TextIndexer",
+	HandlerType: (*TextIndexerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Index",
+			Handler:    _TextIndexer_Index_Handler,
+		},
+		{
+			MethodName: "FindByID",
+			Handler:    _TextIndexer_FindByID_Handler,
+		},
+		{
+			MethodName: "UpdateScore",
+			Handler:    _TextIndexer_UpdateScore_Handler,
+		},
+		{
+			MethodName: "UpdateAnchorText",
+			Handler:    _TextIndexer_UpdateAnchorText_Handler,
+		},
+		{
+			MethodName: "FindNearDuplicates",
+			Handler:    _TextIndexer_FindNearDuplicates_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Search",
+			Handler:       _TextIndexer_Search_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "textindexer/textindexerpb/textindexer.proto",
+}
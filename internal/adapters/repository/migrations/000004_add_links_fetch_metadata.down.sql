@@ -0,0 +1,8 @@
+ALTER TABLE links
+    DROP COLUMN IF EXISTS status_code,
+    DROP COLUMN IF EXISTS content_type,
+    DROP COLUMN IF EXISTS content_length,
+    DROP COLUMN IF EXISTS etag,
+    DROP COLUMN IF EXISTS last_modified,
+    DROP COLUMN IF EXISTS failure_count,
+    DROP COLUMN IF EXISTS last_error;
@@ -0,0 +1,8 @@
+ALTER TABLE links
+    ADD COLUMN IF NOT EXISTS status_code    INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS content_type   STRING NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS content_length INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS etag           STRING NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS last_modified  STRING NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS failure_count  INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_error     STRING NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE edges
+    ADD COLUMN IF NOT EXISTS anchor_text STRING NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS rel         INT NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE edges DROP COLUMN IF EXISTS kind;
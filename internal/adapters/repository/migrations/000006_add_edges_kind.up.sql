@@ -0,0 +1 @@
+ALTER TABLE edges ADD COLUMN IF NOT EXISTS kind INT NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS links_canonical_id_idx;
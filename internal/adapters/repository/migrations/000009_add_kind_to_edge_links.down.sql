@@ -0,0 +1,3 @@
+DELETE FROM edges WHERE EXISTS (SELECT 1 FROM edges AS e WHERE e.src = edges.src AND e.dst = edges.dst AND e.kind < edges.kind);
+DROP INDEX IF EXISTS edges@edge_links CASCADE;
+CREATE UNIQUE INDEX IF NOT EXISTS edge_links ON edges (src, dst);
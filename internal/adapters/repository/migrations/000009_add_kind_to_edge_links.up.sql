@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS edges@edge_links CASCADE;
+CREATE UNIQUE INDEX IF NOT EXISTS edge_links ON edges (src, dst, kind);
@@ -0,0 +1,339 @@
+// Package migrations embeds the SQL migrations of the graph schema and
+// applies them to a database.
+//
+// Versions are tracked in the same schema_migrations table used by
+// golang-migrate, so databases migrated with the migrate binary can be
+// managed with this package and vice versa. On databases with transactional
+// DDL, such as PostgreSQL and SQLite, each migration is applied in the same
+// transaction as the version update, so a failed migration leaves no trace.
+// Elsewhere, like golang-migrate, a migration that fails midway leaves the
+// version marked as dirty until it is fixed by hand and cleared with Force.
+// Callers must not run migrations against the same database concurrently.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+//go:embed *.sql postgres/*.sql
+var files embed.FS
+
+// Dialect selects the flavour of the migrations to apply.
+type Dialect string
+
+const (
+	// CockroachDB selects the migrations written for CockroachDB.
+	CockroachDB Dialect = "cockroachdb"
+	// Postgres selects the migrations written for PostgreSQL.
+	Postgres Dialect = "postgres"
+)
+
+var (
+	// ErrUnknownDialect is returned when requesting migrations for an
+	// unsupported dialect.
+	ErrUnknownDialect = errors.New("unknown migration dialect")
+
+	// ErrDirty is returned when the last migration applied to the database
+	// failed and the schema must be repaired by hand.
+	ErrDirty = errors.New("database is dirty; fix the schema and force a version")
+)
+
+// migrationName matches files named {version}_{title}.{up|down}.sql.
+var migrationName = regexp.MustCompile(`^([0-9]+)_(.+)\.(up|down)\.sql$`)
+
+var createVersionTableQuery = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)
+`
+
+var selectVersionQuery = `
+	SELECT version, dirty FROM schema_migrations LIMIT 1
+`
+
+var clearVersionQuery = `
+	DELETE FROM schema_migrations
+`
+
+var insertVersionQuery = `
+	INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)
+`
+
+// Migration is a single schema change.
+type Migration struct {
+	Version uint
+	Name    string
+
+	up, down string
+}
+
+// Status describes the migration state of a database.
+type Status struct {
+	// Version is the last applied migration, or 0 if none was applied.
+	Version uint
+	// Dirty is set if applying or rolling back Version failed.
+	Dirty bool
+	// Pending lists the migrations that have not been applied yet.
+	Pending []Migration
+}
+
+// Migrator applies a set of migrations to a database.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	// transactional is set if migrations run inside a transaction.
+	transactional bool
+}
+
+// Source returns the embedded migrations for dialect.
+func Source(dialect Dialect) (fs.FS, error) {
+	switch dialect {
+	case CockroachDB:
+		return files, nil
+	case Postgres:
+		return fs.Sub(files, "postgres")
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownDialect, dialect)
+	}
+}
+
+// New returns a Migrator applying the embedded migrations for dialect to db.
+// CockroachDB does not guarantee that schema changes made in a transaction
+// commit atomically, so its migrations run outside transactions.
+func New(db *sql.DB, dialect Dialect) (*Migrator, error) {
+	src, err := Source(dialect)
+	if err != nil {
+		return nil, err
+	}
+	m, err := NewWithSource(db, src)
+	if err != nil {
+		return nil, err
+	}
+	m.transactional = dialect != CockroachDB
+	return m, nil
+}
+
+// NewWithSource returns a Migrator applying the migrations found at the root
+// of src to db. Each migration runs in a transaction, which requires a
+// database with transactional DDL. Files that do not follow the migration
+// naming scheme are ignored.
+func NewWithSource(db *sql.DB, src fs.FS) (*Migrator, error) {
+	entries, err := fs.ReadDir(src, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		match := migrationName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 0)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("read migrations: invalid version in %q", entry.Name())
+		}
+		stmt, err := fs.ReadFile(src, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read migrations: %w", err)
+		}
+
+		m := byVersion[uint(version)]
+		if m == nil {
+			m = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[m.Version] = m
+		}
+		if match[3] == "up" {
+			m.up = string(stmt)
+		} else {
+			m.down = string(stmt)
+		}
+	}
+
+	migrator := &Migrator{db: db, transactional: true}
+	for _, m := range byVersion {
+		migrator.migrations = append(migrator.migrations, *m)
+	}
+	sort.Slice(migrator.migrations, func(i, j int) bool {
+		return migrator.migrations[i].Version < migrator.migrations[j].Version
+	})
+	return migrator, nil
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	current, err := m.cleanVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("migrate up: %w", err)
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version <= current {
+			continue
+		}
+		if err = m.run(ctx, migration.Version, migration.up, migration.Version); err != nil {
+			return fmt.Errorf("migrate up: apply %d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+	return nil
+}
+
+// Down rolls back the last steps applied migrations. A non-positive steps
+// rolls back every migration.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	current, err := m.cleanVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("migrate down: %w", err)
+	}
+
+	for n := 0; current != 0 && (steps <= 0 || n < steps); n++ {
+		i := m.indexOf(current)
+		if i < 0 {
+			return fmt.Errorf("migrate down: no migration found for version %d", current)
+		}
+
+		var prev uint
+		if i > 0 {
+			prev = m.migrations[i-1].Version
+		}
+		migration := m.migrations[i]
+		if err = m.run(ctx, migration.Version, migration.down, prev); err != nil {
+			return fmt.Errorf("migrate down: roll back %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		current = prev
+	}
+	return nil
+}
+
+// Force sets the schema version without running any migration and clears
+// the dirty flag. A version of 0 marks the database as unmigrated.
+func (m *Migrator) Force(ctx context.Context, version uint) error {
+	if err := m.inTx(ctx, func(tx *sql.Tx) error { return setVersion(ctx, tx, version, false) }); err != nil {
+		return fmt.Errorf("migrate force: %w", err)
+	}
+	return nil
+}
+
+// Status reports the schema version of the database and the migrations that
+// are yet to be applied.
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	version, dirty, err := m.version(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("migrate status: %w", err)
+	}
+
+	status := &Status{Version: version, Dirty: dirty}
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status, nil
+}
+
+// indexOf returns the index of the migration with the specified version or
+// -1 if there is none.
+func (m *Migrator) indexOf(version uint) int {
+	i := sort.Search(len(m.migrations), func(i int) bool {
+		return m.migrations[i].Version >= version
+	})
+	if i == len(m.migrations) || m.migrations[i].Version != version {
+		return -1
+	}
+	return i
+}
+
+// run executes stmt and records next as the clean schema version. Outside
+// transactions, version is marked as dirty until stmt succeeds.
+func (m *Migrator) run(ctx context.Context, version uint, stmt string, next uint) error {
+	if m.transactional {
+		return m.inTx(ctx, func(tx *sql.Tx) error {
+			if stmt != "" {
+				if _, err := tx.ExecContext(ctx, stmt); err != nil {
+					return err
+				}
+			}
+			return setVersion(ctx, tx, next, false)
+		})
+	}
+
+	if err := m.inTx(ctx, func(tx *sql.Tx) error { return setVersion(ctx, tx, version, true) }); err != nil {
+		return err
+	}
+	if stmt != "" {
+		if _, err := m.db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return m.inTx(ctx, func(tx *sql.Tx) error { return setVersion(ctx, tx, next, false) })
+}
+
+// cleanVersion returns the current schema version, failing with ErrDirty if
+// the database is dirty.
+func (m *Migrator) cleanVersion(ctx context.Context) (uint, error) {
+	version, dirty, err := m.version(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("%w (version %d)", ErrDirty, version)
+	}
+	return version, nil
+}
+
+func (m *Migrator) version(ctx context.Context) (uint, bool, error) {
+	if _, err := m.db.ExecContext(ctx, createVersionTableQuery); err != nil {
+		return 0, false, err
+	}
+
+	var (
+		version int64
+		dirty   bool
+	)
+	err := m.db.QueryRowContext(ctx, selectVersionQuery).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+	return uint(version), dirty, nil
+}
+
+// inTx runs fn inside a transaction, creating the version table first if
+// needed. The transaction is committed if fn succeeds and rolled back
+// otherwise.
+func (m *Migrator) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	if _, err := m.db.ExecContext(ctx, createVersionTableQuery); err != nil {
+		return err
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err = fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// setVersion replaces the recorded schema version. Version 0 is recorded by
+// leaving the table empty, as golang-migrate does.
+func setVersion(ctx context.Context, tx *sql.Tx, version uint, dirty bool) error {
+	if _, err := tx.ExecContext(ctx, clearVersionQuery); err != nil {
+		return err
+	}
+	if version != 0 || dirty {
+		if _, err := tx.ExecContext(ctx, insertVersionQuery, int64(version), dirty); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func TestEmbeddedSources(t *testing.T) {
+	for _, dialect := range []Dialect{CockroachDB, Postgres} {
+		m, err := New(nil, dialect)
+		require.NoError(t, err)
+
+		var versions []uint
+		for _, migration := range m.migrations {
+			versions = append(versions, migration.Version)
+			assert.NotEmpty(t, migration.up, "missing up migration for %d_%s", migration.Version, migration.Name)
+			assert.NotEmpty(t, migration.down, "missing down migration for %d_%s", migration.Version, migration.Name)
+		}
+		assert.Equal(t, []uint{1, 2, 3, 4, 5, 6, 7, 8, 9}, versions, "unexpected %s migrations", dialect)
+	}
+
+	_, err := New(nil, "oracle")
+	assert.True(t, errors.Is(err, ErrUnknownDialect))
+}
+
+func TestUpDown(t *testing.T) {
+	db := openTestDB(t)
+	m, err := NewWithSource(db, fstest.MapFS{
+		"1_create_a.up.sql":   {Data: []byte("CREATE TABLE a (id INTEGER)")},
+		"1_create_a.down.sql": {Data: []byte("DROP TABLE a")},
+		"2_create_b.up.sql":   {Data: []byte("CREATE TABLE b (id INTEGER)")},
+		"2_create_b.down.sql": {Data: []byte("DROP TABLE b")},
+		"README.md":           {Data: []byte("ignored")},
+	})
+	require.NoError(t, err)
+
+	assertStatus(t, m, 0, 1, 2)
+	require.NoError(t, m.Up(context.TODO()))
+	assertStatus(t, m, 2)
+	assert.True(t, tableExists(t, db, "a") && tableExists(t, db, "b"))
+
+	// Applying migrations again is a no-op.
+	require.NoError(t, m.Up(context.TODO()))
+	assertStatus(t, m, 2)
+
+	require.NoError(t, m.Down(context.TODO(), 1))
+	assertStatus(t, m, 1, 2)
+	assert.True(t, tableExists(t, db, "a"))
+	assert.False(t, tableExists(t, db, "b"))
+
+	require.NoError(t, m.Up(context.TODO()))
+	require.NoError(t, m.Down(context.TODO(), 0))
+	assertStatus(t, m, 0, 1, 2)
+	assert.False(t, tableExists(t, db, "a") || tableExists(t, db, "b"))
+}
+
+func TestDirtyMigration(t *testing.T) {
+	db := openTestDB(t)
+	m, err := NewWithSource(db, fstest.MapFS{
+		"1_create_a.up.sql":   {Data: []byte("CREATE TABLE a (id INTEGER)")},
+		"1_create_a.down.sql": {Data: []byte("DROP TABLE a")},
+		"2_broken.up.sql":     {Data: []byte("CREATE TABLE")},
+		"2_broken.down.sql":   {Data: []byte("")},
+	})
+	require.NoError(t, err)
+	// Run migrations outside transactions, as for CockroachDB.
+	m.transactional = false
+
+	assert.Error(t, m.Up(context.TODO()))
+	status, err := m.Status(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), status.Version)
+	assert.True(t, status.Dirty)
+
+	// A dirty database refuses further migrations until a version is forced.
+	assert.True(t, errors.Is(m.Up(context.TODO()), ErrDirty))
+	assert.True(t, errors.Is(m.Down(context.TODO(), 1), ErrDirty))
+
+	require.NoError(t, m.Force(context.TODO(), 1))
+	assertStatus(t, m, 1, 2)
+	require.NoError(t, m.Down(context.TODO(), 1))
+	assertStatus(t, m, 0, 1, 2)
+}
+
+func TestFailedMigrationIsRolledBack(t *testing.T) {
+	db := openTestDB(t)
+	m, err := NewWithSource(db, fstest.MapFS{
+		"1_create_a.up.sql": {Data: []byte("CREATE TABLE a (id INTEGER)")},
+		"2_broken.up.sql":   {Data: []byte("CREATE TABLE b (id INTEGER); ALTER TABLE a ADD COLUMN name TEXT; CREATE TABLE")},
+	})
+	require.NoError(t, err)
+
+	// The statements of the failed migration must be rolled back along
+	// with the version update, leaving the database clean.
+	assert.Error(t, m.Up(context.TODO()))
+	assertStatus(t, m, 1, 2)
+	assert.False(t, tableExists(t, db, "b"), "expected partial migration to be rolled back")
+
+	var numColumns int
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM pragma_table_info('a')").Scan(&numColumns))
+	assert.Equal(t, 1, numColumns)
+}
+
+func TestForceUnmigratedDatabase(t *testing.T) {
+	db := openTestDB(t)
+	m, err := NewWithSource(db, fstest.MapFS{
+		"1_create_a.up.sql": {Data: []byte("CREATE TABLE a (id INTEGER)")},
+		"2_create_b.up.sql": {Data: []byte("CREATE TABLE b (id INTEGER)")},
+	})
+	require.NoError(t, err)
+
+	// Forcing a version must work before any migration created the version
+	// table, e.g. to adopt a schema that was created by other means.
+	require.NoError(t, m.Force(context.TODO(), 1))
+	assertStatus(t, m, 1, 2)
+}
+
+func openTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "migrations.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func assertStatus(t *testing.T, m *Migrator, version uint, pending ...uint) {
+	status, err := m.Status(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, version, status.Version)
+	assert.False(t, status.Dirty)
+
+	var got []uint
+	for _, migration := range status.Pending {
+		got = append(got, migration.Version)
+	}
+	assert.Equal(t, pending, got)
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	var count int
+	err := db.QueryRow("SELECT count(*) FROM sqlite_master WHERE type='table' AND name=?", name).Scan(&count)
+	require.NoError(t, err)
+	return count == 1
+}
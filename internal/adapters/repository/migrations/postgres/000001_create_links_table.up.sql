@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS links
+(
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    url          TEXT UNIQUE,
+    retrieved_at TIMESTAMP
+);
\ No newline at end of file
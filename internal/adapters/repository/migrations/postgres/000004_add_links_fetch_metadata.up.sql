@@ -0,0 +1,8 @@
+ALTER TABLE links
+    ADD COLUMN IF NOT EXISTS status_code    INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS content_type   TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS content_length BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS etag           TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS last_modified  TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS failure_count  INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_error     TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE edges
+    ADD COLUMN IF NOT EXISTS anchor_text TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS rel         SMALLINT NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE links ADD COLUMN IF NOT EXISTS canonical_id UUID REFERENCES links (id) ON DELETE SET NULL;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS links_canonical_id_idx ON links (canonical_id);
@@ -0,0 +1,3 @@
+DELETE FROM edges WHERE EXISTS (SELECT 1 FROM edges AS e WHERE e.src = edges.src AND e.dst = edges.dst AND e.kind < edges.kind);
+ALTER TABLE edges DROP CONSTRAINT IF EXISTS edge_links;
+ALTER TABLE edges ADD CONSTRAINT edge_links UNIQUE (src, dst);
@@ -0,0 +1,2 @@
+ALTER TABLE edges DROP CONSTRAINT IF EXISTS edge_links;
+ALTER TABLE edges ADD CONSTRAINT edge_links UNIQUE (src, dst, kind);
@@ -1,6 +1,7 @@
 package indextest
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/bruceneco/links-r-us/internal/application/core/domain"
@@ -35,7 +36,7 @@ func (s *SuiteBase) TestIndexDocument(t *testing.T) {
 		IndexedAt: time.Now().Add(-12 * time.Hour).UTC(),
 	}
 
-	err := s.idx.Index(doc)
+	err := s.idx.Index(context.TODO(), doc)
 	assert.Nil(t, err)
 
 	// Update existing Document
@@ -47,7 +48,7 @@ func (s *SuiteBase) TestIndexDocument(t *testing.T) {
 		IndexedAt: time.Now().UTC(),
 	}
 
-	err = s.idx.Index(updatedDoc)
+	err = s.idx.Index(context.TODO(), updatedDoc)
 	assert.Nil(t, err)
 
 	// Insert document without an ID
@@ -55,7 +56,7 @@ func (s *SuiteBase) TestIndexDocument(t *testing.T) {
 		URL: "https://example.com",
 	}
 
-	err = s.idx.Index(incompleteDoc)
+	err = s.idx.Index(context.TODO(), incompleteDoc)
 	assert.True(t, errors.Is(err, ports.TextIndexerErrMissingLinkID))
 }
 
@@ -71,12 +72,12 @@ func (s *SuiteBase) TestIndexDoesNotOverridePageRank(t *testing.T) {
 		IndexedAt: time.Now().Add(-12 * time.Hour).UTC(),
 	}
 
-	err := s.idx.Index(doc)
+	err := s.idx.Index(context.TODO(), doc)
 	assert.Nil(t, err)
 
 	// Update its score
 	expScore := 0.5
-	err = s.idx.UpdateScore(doc.LinkID, expScore)
+	err = s.idx.UpdateScore(context.TODO(), doc.LinkID, expScore)
 	assert.Nil(t, err)
 
 	// Update document
@@ -88,11 +89,11 @@ func (s *SuiteBase) TestIndexDoesNotOverridePageRank(t *testing.T) {
 		IndexedAt: time.Now().UTC(),
 	}
 
-	err = s.idx.Index(updatedDoc)
+	err = s.idx.Index(context.TODO(), updatedDoc)
 	assert.Nil(t, err)
 
 	// Lookup document and verify that PageRank score has not been changed.
-	got, err := s.idx.FindByID(doc.LinkID)
+	got, err := s.idx.FindByID(context.TODO(), doc.LinkID)
 	assert.Nil(t, err)
 	assert.Equal(t, got.PageRank, expScore)
 }
@@ -107,16 +108,16 @@ func (s *SuiteBase) TestFindByID(t *testing.T) {
 		IndexedAt: time.Now().Add(-12 * time.Hour).UTC(),
 	}
 
-	err := s.idx.Index(doc)
+	err := s.idx.Index(context.TODO(), doc)
 	assert.Nil(t, err)
 
 	// Look up doc
-	got, err := s.idx.FindByID(doc.LinkID)
+	got, err := s.idx.FindByID(context.TODO(), doc.LinkID)
 	assert.Nil(t, err)
 	assert.True(t, reflect.DeepEqual(got, doc), "document returned by FindByID does not match inserted document")
 
 	// Look up unknown
-	_, err = s.idx.FindByID(uuid.New())
+	_, err = s.idx.FindByID(context.TODO(), uuid.New())
 	assert.True(t, errors.Is(err, ports.TextIndexerErrNotFound))
 }
 
@@ -140,14 +141,14 @@ func (s *SuiteBase) TestPhraseSearch(t *testing.T) {
 			expIDs = append(expIDs, id)
 		}
 
-		err := s.idx.Index(doc)
+		err := s.idx.Index(context.TODO(), doc)
 		assert.Nil(t, err)
 
-		err = s.idx.UpdateScore(id, float64(numDocs-i))
+		err = s.idx.UpdateScore(context.TODO(), id, float64(numDocs-i))
 		assert.Nil(t, err)
 	}
 
-	it, err := s.idx.Search(&ports.DocumentQuery{
+	it, err := s.idx.Search(context.TODO(), &ports.DocumentQuery{
 		Type:       ports.DocumentQueryTypePhrase,
 		Expression: "lorem dolor ipsum",
 	})
@@ -175,14 +176,14 @@ func (s *SuiteBase) TestMatchSearch(t *testing.T) {
 			expIDs = append(expIDs, id)
 		}
 
-		err := s.idx.Index(doc)
+		err := s.idx.Index(context.TODO(), doc)
 		assert.Nil(t, err)
 
-		err = s.idx.UpdateScore(id, float64(numDocs-i))
+		err = s.idx.UpdateScore(context.TODO(), id, float64(numDocs-i))
 		assert.Nil(t, err)
 	}
 
-	it, err := s.idx.Search(&ports.DocumentQuery{
+	it, err := s.idx.Search(context.TODO(), &ports.DocumentQuery{
 		Type:       ports.DocumentQueryTypeMatch,
 		Expression: "lorem ipsum",
 	})
@@ -206,14 +207,14 @@ func (s *SuiteBase) TestMatchSearchWithOffset(t *testing.T) {
 			Content: "Ovidius poeta in terra pontica",
 		}
 
-		err := s.idx.Index(doc)
+		err := s.idx.Index(context.TODO(), doc)
 		assert.Nil(t, err)
 
-		err = s.idx.UpdateScore(id, float64(numDocs-i))
+		err = s.idx.UpdateScore(context.TODO(), id, float64(numDocs-i))
 		assert.Nil(t, err)
 	}
 
-	it, err := s.idx.Search(&ports.DocumentQuery{
+	it, err := s.idx.Search(context.TODO(), &ports.DocumentQuery{
 		Type:       ports.DocumentQueryTypeMatch,
 		Expression: "poeta",
 		Offset:     20,
@@ -222,7 +223,7 @@ func (s *SuiteBase) TestMatchSearchWithOffset(t *testing.T) {
 	assert.True(t, reflect.DeepEqual(iterateDocs(t, it), expIDs[20:]))
 
 	// Search with offset beyon the total number of results
-	it, err = s.idx.Search(&ports.DocumentQuery{
+	it, err = s.idx.Search(context.TODO(), &ports.DocumentQuery{
 		Type:       ports.DocumentQueryTypeMatch,
 		Expression: "poeta",
 		Offset:     200,
@@ -231,6 +232,33 @@ func (s *SuiteBase) TestMatchSearchWithOffset(t *testing.T) {
 	assert.Len(t, iterateDocs(t, it), 0)
 }
 
+// TestSearchContextCancellation verifies that searching stops with the
+// context error once its context is cancelled.
+func (s *SuiteBase) TestSearchContextCancellation(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		err := s.idx.Index(context.TODO(), &domain.Document{
+			LinkID:  uuid.New(),
+			Content: "Ovidius poeta in terra pontica",
+		})
+		assert.Nil(t, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	it, err := s.idx.Search(ctx, &ports.DocumentQuery{
+		Type:       ports.DocumentQueryTypeMatch,
+		Expression: "poeta",
+	})
+	if err != nil {
+		assert.True(t, errors.Is(err, context.Canceled))
+		return
+	}
+	assert.False(t, it.Next(), "expected iterator to stop after context cancellation")
+	assert.True(t, errors.Is(it.Error(), context.Canceled))
+	assert.Nil(t, it.Close())
+}
+
 // TestUpdateScore checks that PageRank score updates work as expected.
 func (s *SuiteBase) TestUpdateScore(t *testing.T) {
 	var (
@@ -246,14 +274,14 @@ func (s *SuiteBase) TestUpdateScore(t *testing.T) {
 			Content: "Ovidius poeta in terra pontica",
 		}
 
-		err := s.idx.Index(doc)
+		err := s.idx.Index(context.TODO(), doc)
 		assert.Nil(t, err)
 
-		err = s.idx.UpdateScore(id, float64(numDocs-i))
+		err = s.idx.UpdateScore(context.TODO(), id, float64(numDocs-i))
 		assert.Nil(t, err)
 	}
 
-	it, err := s.idx.Search(&ports.DocumentQuery{
+	it, err := s.idx.Search(context.TODO(), &ports.DocumentQuery{
 		Type:       ports.DocumentQueryTypeMatch,
 		Expression: "poeta",
 	})
@@ -263,11 +291,11 @@ func (s *SuiteBase) TestUpdateScore(t *testing.T) {
 	// Update the pagerank scores so that results are sorted in the
 	// reverse order.
 	for i := 0; i < numDocs; i++ {
-		err = s.idx.UpdateScore(expIDs[i], float64(i))
+		err = s.idx.UpdateScore(context.TODO(), expIDs[i], float64(i))
 		assert.Nil(t, err, expIDs[i].String())
 	}
 
-	it, err = s.idx.Search(&ports.DocumentQuery{
+	it, err = s.idx.Search(context.TODO(), &ports.DocumentQuery{
 		Type:       ports.DocumentQueryTypeMatch,
 		Expression: "poeta",
 	})
@@ -279,10 +307,10 @@ func (s *SuiteBase) TestUpdateScore(t *testing.T) {
 // be created when setting the PageRank score for an unknown document.
 func (s *SuiteBase) TestUpdateScoreForUnknownDocument(t *testing.T) {
 	linkID := uuid.New()
-	err := s.idx.UpdateScore(linkID, 0.5)
+	err := s.idx.UpdateScore(context.TODO(), linkID, 0.5)
 	assert.Nil(t, err)
 
-	doc, err := s.idx.FindByID(linkID)
+	doc, err := s.idx.FindByID(context.TODO(), linkID)
 	assert.Nil(t, err)
 
 	assert.Equal(t, doc.URL, "")
@@ -292,6 +320,103 @@ func (s *SuiteBase) TestUpdateScoreForUnknownDocument(t *testing.T) {
 	assert.Equal(t, doc.PageRank, 0.5)
 }
 
+// TestUpdateAnchorText checks that documents can be found by their anchor
+// text and that indexing a document again preserves it.
+func (s *SuiteBase) TestUpdateAnchorText(t *testing.T) {
+	doc := &domain.Document{
+		LinkID:  uuid.New(),
+		URL:     "https://example.com",
+		Title:   "Illustrious examples",
+		Content: "Lorem ipsum dolor",
+	}
+	assert.Nil(t, s.idx.Index(context.TODO(), doc))
+
+	anchorText := []string{"Ovidius poeta", "tristia"}
+	assert.Nil(t, s.idx.UpdateAnchorText(context.TODO(), doc.LinkID, anchorText))
+
+	// Indexing the document again must not drop its anchor text.
+	doc.Content = "Lorem ipsum dolor sit amet"
+	assert.Nil(t, s.idx.Index(context.TODO(), doc))
+
+	got, err := s.idx.FindByID(context.TODO(), doc.LinkID)
+	assert.Nil(t, err)
+	assert.Equal(t, anchorText, got.AnchorText)
+
+	it, err := s.idx.Search(context.TODO(), &ports.DocumentQuery{
+		Type:       ports.DocumentQueryTypeMatch,
+		Expression: "tristia",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []uuid.UUID{doc.LinkID}, iterateDocs(t, it))
+
+	// Anchor text may be known before the document itself is indexed.
+	linkID := uuid.New()
+	assert.Nil(t, s.idx.UpdateAnchorText(context.TODO(), linkID, anchorText))
+	got, err = s.idx.FindByID(context.TODO(), linkID)
+	assert.Nil(t, err)
+	assert.Equal(t, anchorText, got.AnchorText)
+	assert.True(t, got.IndexedAt.IsZero())
+}
+
+// TestFindNearDuplicates checks that documents with almost the same content
+// are found by their fingerprint while unrelated documents are not.
+func (s *SuiteBase) TestFindNearDuplicates(t *testing.T) {
+	content := "Ovidius poeta in terra pontica scripsit tristia et epistulas ex ponto, " +
+		"quibus amicos Romae rogavit ut Augustum placarent et exsilium eius finiretur. " +
+		"Nemo tamen eum revocavit et Tomis mortuus est. " +
+		"Antea Romae vixerat et carmina de amore composuerat, inter quae ars amatoria " +
+		"et remedia amoris numerantur. Metamorphoses quoque scripsit, quindecim libros " +
+		"de formis in nova corpora mutatis, qui a chao usque ad Caesaris mortem pertinent. " +
+		"Causa exsilii numquam plane explicata est, sed ipse carmen et errorem accusavit."
+	doc := &domain.Document{LinkID: uuid.New(), Title: "Ovidius", Content: content}
+	assert.Nil(t, s.idx.Index(context.TODO(), doc))
+	assert.NotZero(t, doc.Fingerprint)
+
+	nearDup := &domain.Document{LinkID: uuid.New(), Title: "Ovidius", Content: content + " Vale!"}
+	assert.Nil(t, s.idx.Index(context.TODO(), nearDup))
+
+	other := &domain.Document{LinkID: uuid.New(), Title: "Illustrious examples", Content: "Lorem ipsum dolor sit amet"}
+	assert.Nil(t, s.idx.Index(context.TODO(), other))
+
+	// Placeholder documents and documents without any words have no
+	// content to compare.
+	assert.Nil(t, s.idx.UpdateScore(context.TODO(), uuid.New(), 0.5))
+	empty := &domain.Document{LinkID: uuid.New()}
+	assert.Nil(t, s.idx.Index(context.TODO(), empty))
+	assert.Zero(t, empty.Fingerprint)
+
+	dups, err := s.idx.FindNearDuplicates(context.TODO(), doc.Fingerprint, domain.NearDuplicateDistance)
+	assert.Nil(t, err)
+	assert.Len(t, dups, 2)
+	if len(dups) > 0 {
+		assert.Equal(t, doc.LinkID, dups[0].LinkID, "expected exact match to be returned first")
+	}
+	var ids []uuid.UUID
+	for _, d := range dups {
+		ids = append(ids, d.LinkID)
+	}
+	assert.ElementsMatch(t, []uuid.UUID{doc.LinkID, nearDup.LinkID}, ids)
+
+	dups, err = s.idx.FindNearDuplicates(context.TODO(), empty.Fingerprint, domain.NearDuplicateDistance)
+	assert.Nil(t, err)
+	assert.Empty(t, dups, "expected empty documents not to match each other")
+
+	_, err = s.idx.FindNearDuplicates(context.TODO(), doc.Fingerprint, domain.NearDuplicateDistance+1)
+	assert.True(t, errors.Is(err, ports.TextIndexerErrDistanceTooLarge), "expected TextIndexerErrDistanceTooLarge, got %v", err)
+
+	// Indexing again keeps the fingerprint in sync with the content.
+	doc.Content = other.Content
+	doc.Title = other.Title
+	assert.Nil(t, s.idx.Index(context.TODO(), doc))
+	dups, err = s.idx.FindNearDuplicates(context.TODO(), other.Fingerprint, 0)
+	assert.Nil(t, err)
+	ids = ids[:0]
+	for _, d := range dups {
+		ids = append(ids, d.LinkID)
+	}
+	assert.ElementsMatch(t, []uuid.UUID{doc.LinkID, other.LinkID}, ids)
+}
+
 func iterateDocs(t *testing.T, it ports.DocumentIterator) []uuid.UUID {
 	var seen []uuid.UUID
 	for it.Next() {
@@ -0,0 +1,80 @@
+package bleve
+
+import (
+	"context"
+	"github.com/blevesearch/bleve/v2"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+)
+
+// documentIterator implements index.Iterator.
+type documentIterator struct {
+	ctx       context.Context
+	idx       bleve.Index
+	searchReq *bleve.SearchRequest
+
+	cumIdx uint64
+	rsIdx  int
+	rs     *bleve.SearchResult
+
+	latchedDoc *domain.Document
+	lastErr    error
+}
+
+// Close the iterator and release any allocated resources.
+func (it *documentIterator) Close() error {
+	it.idx = nil
+	it.searchReq = nil
+	if it.rs != nil {
+		it.cumIdx = it.rs.Total
+	}
+	return nil
+}
+
+// Next loads the next document matching the search query.
+// It returns false if no more documents are available.
+func (it *documentIterator) Next() bool {
+	if it.lastErr != nil || it.rs == nil || it.cumIdx >= it.rs.Total {
+		return false
+	}
+	if it.lastErr = it.ctx.Err(); it.lastErr != nil {
+		return false
+	}
+
+	// Do we need to fetch the next batch?
+	if it.rsIdx >= it.rs.Hits.Len() {
+		it.searchReq.From += it.searchReq.Size
+		if it.rs, it.lastErr = it.idx.SearchInContext(it.ctx, it.searchReq); it.lastErr != nil {
+			return false
+		}
+
+		it.rsIdx = 0
+	}
+
+	// The stored fields of the hit hold the whole document.
+	hit := it.rs.Hits[it.rsIdx]
+	if it.latchedDoc, it.lastErr = decodeHit(hit.ID, hit.Fields); it.lastErr != nil {
+		return false
+	}
+
+	it.cumIdx++
+	it.rsIdx++
+	return true
+}
+
+// Error returns the last error encountered by the iterator.
+func (it *documentIterator) Error() error {
+	return it.lastErr
+}
+
+// Document returns the current document from the result set.
+func (it *documentIterator) Document() *domain.Document {
+	return it.latchedDoc
+}
+
+// TotalCount returns the approximate number of search results.
+func (it *documentIterator) TotalCount() uint64 {
+	if it.rs == nil {
+		return 0
+	}
+	return it.rs.Total
+}
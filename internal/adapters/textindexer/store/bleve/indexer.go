@@ -0,0 +1,325 @@
+// Package bleve implements a text indexer that keeps its bleve index on
+// disk. Documents are read back from the index when needed, so the corpus
+// does not have to fit in memory.
+package bleve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+	index "github.com/blevesearch/bleve_index_api"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The size of each page of results that is fetched by the iterator.
+const batchSize = 10
+
+const (
+	// sourceField is the stored-only field holding the JSON encoded
+	// document, from which documents are decoded.
+	sourceField = "Source"
+	// bandsField is the indexed-only field holding the fingerprint bands
+	// of a document, used to look up near-duplicates.
+	bandsField = "Bands"
+)
+
+type bleveDoc struct {
+	Title      string
+	Content    string
+	AnchorText []string
+	PageRank   float64
+	Source     string
+	Bands      []string
+}
+
+// BleveIndexer is an Indexer implementation that catalogues and searches
+// documents with a bleve index stored on disk.
+type BleveIndexer struct {
+	// mu serializes writers, which read a document before replacing it.
+	mu  sync.Mutex
+	idx bleve.Index
+}
+
+// OpenBleveIndexer creates a text indexer whose bleve index is stored at
+// path, creating it if needed. The caller must invoke Close once the indexer
+// is no longer needed.
+func OpenBleveIndexer(path string) (*BleveIndexer, error) {
+	idx, err := bleve.Open(path)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		idx, err = bleve.New(path, newIndexMapping())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open indexer: %w", err)
+	}
+	return &BleveIndexer{idx: idx}, nil
+}
+
+// newIndexMapping returns the mapping of the index. The source field is
+// stored but never searched and the bands field is searched but never
+// stored; neither takes part in free text queries.
+func newIndexMapping() mapping.IndexMapping {
+	sourceMapping := bleve.NewTextFieldMapping()
+	sourceMapping.Index = false
+	sourceMapping.Store = true
+	sourceMapping.IncludeInAll = false
+
+	bandsMapping := bleve.NewKeywordFieldMapping()
+	bandsMapping.Store = false
+	bandsMapping.IncludeInAll = false
+	bandsMapping.IncludeTermVectors = false
+
+	m := bleve.NewIndexMapping()
+	m.DefaultMapping.AddFieldMappingsAt(sourceField, sourceMapping)
+	m.DefaultMapping.AddFieldMappingsAt(bandsField, bandsMapping)
+	return m
+}
+
+// Close the indexer and release any allocated resources.
+func (i *BleveIndexer) Close() error {
+	return i.idx.Close()
+}
+
+// Index inserts a new document to the index or updates the index entry
+// for and existing document.
+func (i *BleveIndexer) Index(_ context.Context, doc *domain.Document) error {
+	if doc.LinkID == uuid.Nil {
+		return fmt.Errorf("index: %w", ports.TextIndexerErrMissingLinkID)
+	}
+
+	// Strip the monotonic clock reading and location, which do not survive
+	// the round trip through the stored document.
+	doc.IndexedAt = time.Now().UTC().Round(0)
+	doc.Fingerprint = domain.SimHash(doc.Title + "\n" + doc.Content)
+	dcopy := copyDoc(doc)
+	key := dcopy.LinkID.String()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	// If updating, preserve existing PageRank score and anchor text
+	orig, err := i.findByID(key)
+	switch {
+	case err == nil:
+		dcopy.PageRank = orig.PageRank
+		dcopy.AnchorText = orig.AnchorText
+	case !errors.Is(err, ports.TextIndexerErrNotFound):
+		return fmt.Errorf("index: %w", err)
+	}
+
+	if err = i.indexDoc(key, dcopy); err != nil {
+		return fmt.Errorf("index: %w", err)
+	}
+	return nil
+}
+
+// FindByID looks up a document by its link ID.
+func (i *BleveIndexer) FindByID(_ context.Context, linkID uuid.UUID) (*domain.Document, error) {
+	doc, err := i.findByID(linkID.String())
+	if err != nil {
+		return nil, fmt.Errorf("find by ID: %w", err)
+	}
+	return doc, nil
+}
+
+// findByID decodes the document stored under key.
+func (i *BleveIndexer) findByID(key string) (*domain.Document, error) {
+	stored, err := i.idx.Document(key)
+	if err != nil {
+		return nil, err
+	} else if stored == nil {
+		return nil, ports.TextIndexerErrNotFound
+	}
+
+	var src []byte
+	stored.VisitFields(func(field index.Field) {
+		if field.Name() == sourceField {
+			src = field.Value()
+		}
+	})
+	return decodeDoc(key, src)
+}
+
+// Search the index for a particular query and return back a result
+// iterator.
+func (i *BleveIndexer) Search(ctx context.Context, q *ports.DocumentQuery) (ports.DocumentIterator, error) {
+	var bq query.Query
+	switch q.Type {
+	case ports.DocumentQueryTypePhrase:
+		bq = bleve.NewMatchPhraseQuery(q.Expression)
+	default:
+		bq = bleve.NewMatchQuery(q.Expression)
+	}
+
+	searchReq := bleve.NewSearchRequest(bq)
+	searchReq.SortBy([]string{"-PageRank", "-_score"})
+	searchReq.Size = batchSize
+	searchReq.From = int(q.Offset)
+	searchReq.Fields = []string{sourceField}
+	rs, err := i.idx.SearchInContext(ctx, searchReq)
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+
+	return &documentIterator{ctx: ctx, idx: i.idx, searchReq: searchReq, rs: rs, cumIdx: q.Offset}, nil
+}
+
+// UpdateScore updates the PageRank score for a document with the specified
+// link ID. If no such document exists, a placeholder document with the
+// provided score will be created.
+func (i *BleveIndexer) UpdateScore(_ context.Context, linkID uuid.UUID, score float64) error {
+	err := i.update(linkID, func(doc *domain.Document) {
+		doc.PageRank = score
+	})
+	if err != nil {
+		return fmt.Errorf("update score: %w", err)
+	}
+	return nil
+}
+
+// UpdateAnchorText replaces the anchor texts of the document with the
+// specified link ID. If no such document exists, a placeholder document with
+// the provided anchor texts will be created.
+func (i *BleveIndexer) UpdateAnchorText(_ context.Context, linkID uuid.UUID, anchorText []string) error {
+	err := i.update(linkID, func(doc *domain.Document) {
+		doc.AnchorText = slices.Clone(anchorText)
+	})
+	if err != nil {
+		return fmt.Errorf("update anchor text: %w", err)
+	}
+	return nil
+}
+
+// update applies fn to the document with the specified link ID, or to a new
+// placeholder document if none exists, and stores the result.
+func (i *BleveIndexer) update(linkID uuid.UUID, fn func(doc *domain.Document)) error {
+	key := linkID.String()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	doc, err := i.findByID(key)
+	if errors.Is(err, ports.TextIndexerErrNotFound) {
+		doc, err = &domain.Document{LinkID: linkID}, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fn(doc)
+	return i.indexDoc(key, doc)
+}
+
+// FindNearDuplicates returns the indexed documents whose fingerprint is
+// within maxDistance bits of fingerprint, sorted by distance. Placeholder
+// documents created by UpdateScore or UpdateAnchorText and documents without
+// any words are never returned. Candidates are looked up by fingerprint band,
+// so maxDistance may not exceed domain.NearDuplicateDistance.
+func (i *BleveIndexer) FindNearDuplicates(ctx context.Context, fingerprint uint64, maxDistance int) ([]*domain.Document, error) {
+	if maxDistance > domain.NearDuplicateDistance {
+		return nil, fmt.Errorf("find near duplicates: %w", ports.TextIndexerErrDistanceTooLarge)
+	}
+	if fingerprint == 0 {
+		return nil, nil
+	}
+
+	bands := make([]query.Query, 0, domain.FingerprintBands)
+	for _, term := range bandTerms(fingerprint) {
+		q := bleve.NewTermQuery(term)
+		q.SetField(bandsField)
+		bands = append(bands, q)
+	}
+	searchReq := bleve.NewSearchRequestOptions(bleve.NewDisjunctionQuery(bands...), 100, 0, false)
+	searchReq.Fields = []string{sourceField}
+
+	var dups []*domain.Document
+	for {
+		rs, err := i.idx.SearchInContext(ctx, searchReq)
+		if err != nil {
+			return nil, fmt.Errorf("find near duplicates: %w", err)
+		}
+		for _, hit := range rs.Hits {
+			doc, err := decodeHit(hit.ID, hit.Fields)
+			if err != nil {
+				return nil, fmt.Errorf("find near duplicates: %w", err)
+			}
+			if domain.FingerprintDistance(doc.Fingerprint, fingerprint) <= maxDistance {
+				dups = append(dups, doc)
+			}
+		}
+
+		searchReq.From += len(rs.Hits)
+		if len(rs.Hits) == 0 || uint64(searchReq.From) >= rs.Total {
+			break
+		}
+	}
+
+	slices.SortFunc(dups, func(a, b *domain.Document) int {
+		da, db := domain.FingerprintDistance(a.Fingerprint, fingerprint), domain.FingerprintDistance(b.Fingerprint, fingerprint)
+		if da != db {
+			return da - db
+		}
+		return strings.Compare(a.LinkID.String(), b.LinkID.String())
+	})
+	return dups, nil
+}
+
+func copyDoc(d *domain.Document) *domain.Document {
+	dcopy := new(domain.Document)
+	*dcopy = *d
+	dcopy.AnchorText = slices.Clone(d.AnchorText)
+	return dcopy
+}
+
+// indexDoc adds d to the bleve index under key. The caller must hold the
+// lock.
+func (i *BleveIndexer) indexDoc(key string, d *domain.Document) error {
+	src, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	return i.idx.Index(key, bleveDoc{
+		Title:      d.Title,
+		Content:    d.Content,
+		AnchorText: d.AnchorText,
+		PageRank:   d.PageRank,
+		Source:     string(src),
+		Bands:      bandTerms(d.Fingerprint),
+	})
+}
+
+// bandTerms returns the terms under which the bands of fingerprint are
+// indexed. Documents without a fingerprint have no bands.
+func bandTerms(fingerprint uint64) []string {
+	if fingerprint == 0 {
+		return nil
+	}
+	terms := make([]string, 0, domain.FingerprintBands)
+	for n := 0; n < domain.FingerprintBands; n++ {
+		terms = append(terms, fmt.Sprintf("%d:%04x", n, domain.FingerprintBand(fingerprint, n)))
+	}
+	return terms
+}
+
+// decodeHit decodes the document held in the stored fields of a search hit.
+func decodeHit(key string, fields map[string]interface{}) (*domain.Document, error) {
+	src, _ := fields[sourceField].(string)
+	return decodeDoc(key, []byte(src))
+}
+
+func decodeDoc(key string, src []byte) (*domain.Document, error) {
+	doc := new(domain.Document)
+	if err := json.Unmarshal(src, doc); err != nil {
+		return nil, fmt.Errorf("decode document %s: %w", key, err)
+	}
+	return doc, nil
+}
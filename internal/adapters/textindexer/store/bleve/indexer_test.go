@@ -0,0 +1,119 @@
+package bleve
+
+import (
+	"context"
+	"github.com/bruceneco/links-r-us/internal/adapters/textindexer/index/indextest"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"path/filepath"
+	"testing"
+)
+
+func Test(t *testing.T) {
+	suite.Run(t, new(BleveIndexerTestSuite))
+}
+
+type BleveIndexerTestSuite struct {
+	suite.Suite
+	base indextest.SuiteBase
+	idx  *BleveIndexer
+}
+
+func (s *BleveIndexerTestSuite) SetupTest() {
+	idx, err := OpenBleveIndexer(filepath.Join(s.T().TempDir(), "index.bleve"))
+	s.Require().Nil(err)
+	s.idx = idx
+	s.base.SetIndexer(idx)
+}
+func (s *BleveIndexerTestSuite) TearDownTest() {
+	s.Nil(s.idx.Close())
+}
+func (s *BleveIndexerTestSuite) TestIndexDocument() {
+	s.base.TestIndexDocument(s.T())
+}
+func (s *BleveIndexerTestSuite) TestIndexDoesNotOverridePageRank() {
+	s.base.TestIndexDoesNotOverridePageRank(s.T())
+}
+func (s *BleveIndexerTestSuite) TestFindByID() {
+	s.base.TestFindByID(s.T())
+}
+func (s *BleveIndexerTestSuite) TestPhraseSearch() {
+	s.base.TestPhraseSearch(s.T())
+}
+func (s *BleveIndexerTestSuite) TestMatchSearch() {
+	s.base.TestMatchSearch(s.T())
+}
+func (s *BleveIndexerTestSuite) TestMatchSearchWithOffset() {
+	s.base.TestMatchSearchWithOffset(s.T())
+}
+func (s *BleveIndexerTestSuite) TestSearchContextCancellation() {
+	s.base.TestSearchContextCancellation(s.T())
+}
+func (s *BleveIndexerTestSuite) TestUpdateScore() {
+	s.base.TestUpdateScore(s.T())
+}
+func (s *BleveIndexerTestSuite) TestUpdateScoreForUnknownDocument() {
+	s.base.TestUpdateScoreForUnknownDocument(s.T())
+}
+func (s *BleveIndexerTestSuite) TestUpdateAnchorText() {
+	s.base.TestUpdateAnchorText(s.T())
+}
+func (s *BleveIndexerTestSuite) TestFindNearDuplicates() {
+	s.base.TestFindNearDuplicates(s.T())
+}
+
+func TestReopenIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.bleve")
+
+	idx, err := OpenBleveIndexer(path)
+	require.NoError(t, err)
+	doc := &domain.Document{
+		LinkID:  uuid.New(),
+		URL:     "https://example.com",
+		Title:   "Illustrious examples",
+		Content: "Ovidius poeta in terra pontica",
+	}
+	require.NoError(t, idx.Index(context.TODO(), doc))
+	require.NoError(t, idx.UpdateScore(context.TODO(), doc.LinkID, 0.5))
+	require.NoError(t, idx.UpdateAnchorText(context.TODO(), doc.LinkID, []string{"tristia"}))
+	placeholderID := uuid.New()
+	require.NoError(t, idx.UpdateScore(context.TODO(), placeholderID, 0.25))
+	require.NoError(t, idx.Close())
+
+	restored, err := OpenBleveIndexer(path)
+	require.NoError(t, err)
+	defer func() { _ = restored.Close() }()
+
+	got, err := restored.FindByID(context.TODO(), doc.LinkID)
+	require.NoError(t, err)
+	assert.Equal(t, doc.URL, got.URL)
+	assert.Equal(t, doc.Title, got.Title)
+	assert.Equal(t, doc.Content, got.Content)
+	assert.True(t, doc.IndexedAt.Equal(got.IndexedAt), "expected IndexedAt to be restored")
+	assert.Equal(t, doc.Fingerprint, got.Fingerprint)
+	assert.Equal(t, 0.5, got.PageRank)
+	assert.Equal(t, []string{"tristia"}, got.AnchorText)
+
+	got, err = restored.FindByID(context.TODO(), placeholderID)
+	require.NoError(t, err)
+	assert.Equal(t, 0.25, got.PageRank)
+
+	it, err := restored.Search(context.TODO(), &ports.DocumentQuery{
+		Type:       ports.DocumentQueryTypeMatch,
+		Expression: "poeta",
+	})
+	require.NoError(t, err)
+	require.True(t, it.Next(), "expected restored document to be searchable")
+	assert.Equal(t, doc.LinkID, it.Document().LinkID)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Close())
+
+	dups, err := restored.FindNearDuplicates(context.TODO(), doc.Fingerprint, 0)
+	require.NoError(t, err)
+	require.Len(t, dups, 1)
+	assert.Equal(t, doc.LinkID, dups[0].LinkID)
+}
@@ -0,0 +1,55 @@
+package memory
+
+import "github.com/bruceneco/links-r-us/internal/application/core/domain"
+
+// bandIndex maps each band of the indexed fingerprints to the keys of the
+// documents having that band, so near-duplicates are found without scanning
+// every document. Documents without a fingerprint are not indexed.
+type bandIndex [domain.FingerprintBands]map[uint16]map[string]struct{}
+
+// add records that the document with the specified key has fingerprint.
+func (b *bandIndex) add(key string, fingerprint uint64) {
+	if fingerprint == 0 {
+		return
+	}
+	for n := range b {
+		if b[n] == nil {
+			b[n] = make(map[uint16]map[string]struct{})
+		}
+		band := domain.FingerprintBand(fingerprint, n)
+		if b[n][band] == nil {
+			b[n][band] = make(map[string]struct{})
+		}
+		b[n][band][key] = struct{}{}
+	}
+}
+
+// remove forgets that the document with the specified key has fingerprint.
+func (b *bandIndex) remove(key string, fingerprint uint64) {
+	if fingerprint == 0 {
+		return
+	}
+	for n := range b {
+		band := domain.FingerprintBand(fingerprint, n)
+		delete(b[n][band], key)
+		if len(b[n][band]) == 0 {
+			delete(b[n], band)
+		}
+	}
+}
+
+// candidates returns the keys of the documents that share at least one band
+// with fingerprint. They include every document whose fingerprint is within
+// domain.NearDuplicateDistance of it.
+func (b *bandIndex) candidates(fingerprint uint64) map[string]struct{} {
+	keys := make(map[string]struct{})
+	if fingerprint == 0 {
+		return keys
+	}
+	for n := range b {
+		for key := range b[n][domain.FingerprintBand(fingerprint, n)] {
+			keys[key] = struct{}{}
+		}
+	}
+	return keys
+}
@@ -1,12 +1,14 @@
 package memory
 
 import (
+	"context"
 	"github.com/blevesearch/bleve/v2"
 	"github.com/bruceneco/links-r-us/internal/application/core/domain"
 )
 
 // documentIterator implements index.Iterator.
 type documentIterator struct {
+	ctx       context.Context
 	idx       *InMemoryIndexer
 	searchReq *bleve.SearchRequest
 
@@ -34,11 +36,14 @@ func (it *documentIterator) Next() bool {
 	if it.lastErr != nil || it.rs == nil || it.cumIdx >= it.rs.Total {
 		return false
 	}
+	if it.lastErr = it.ctx.Err(); it.lastErr != nil {
+		return false
+	}
 
 	// Do we need to fetch the next batch?
 	if it.rsIdx >= it.rs.Hits.Len() {
 		it.searchReq.From += it.searchReq.Size
-		if it.rs, it.lastErr = it.idx.idx.Search(it.searchReq); it.lastErr != nil {
+		if it.rs, it.lastErr = it.idx.idx.SearchInContext(it.ctx, it.searchReq); it.lastErr != nil {
 			return false
 		}
 
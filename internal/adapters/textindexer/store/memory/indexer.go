@@ -1,12 +1,15 @@
 package memory
 
 import (
+	"context"
 	"fmt"
 	"github.com/blevesearch/bleve/v2"
 	"github.com/blevesearch/bleve/v2/search/query"
 	"github.com/bruceneco/links-r-us/internal/application/core/domain"
 	"github.com/bruceneco/links-r-us/internal/ports"
 	"github.com/google/uuid"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 )
@@ -15,16 +18,18 @@ import (
 const batchSize = 10
 
 type bleveDoc struct {
-	Title    string
-	Content  string
-	PageRank float64
+	Title      string
+	Content    string
+	AnchorText []string
+	PageRank   float64
 }
 
 // InMemoryIndexer is an Indexer implementation that uses an in-memory
 // bleve instance to catalogue and search documents.
 type InMemoryIndexer struct {
-	mu   sync.RWMutex
-	docs map[string]*domain.Document
+	mu    sync.RWMutex
+	docs  map[string]*domain.Document
+	bands bandIndex
 
 	idx bleve.Index
 }
@@ -51,33 +56,40 @@ func (i *InMemoryIndexer) Close() error {
 
 // Index inserts a new document to the index or updates the index entry
 // for and existing document.
-func (i *InMemoryIndexer) Index(doc *domain.Document) error {
+func (i *InMemoryIndexer) Index(_ context.Context, doc *domain.Document) error {
 	if doc.LinkID == uuid.Nil {
 		return fmt.Errorf("index: %w", ports.TextIndexerErrMissingLinkID)
 	}
 
 	doc.IndexedAt = time.Now()
+	doc.Fingerprint = domain.SimHash(doc.Title + "\n" + doc.Content)
 	dcopy := copyDoc(doc)
 	key := dcopy.LinkID.String()
 
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
-	// If updating, preserve existing PageRank score
-	if orig, exists := i.docs[key]; exists {
+	// If updating, preserve existing PageRank score and anchor text
+	orig, exists := i.docs[key]
+	if exists {
 		dcopy.PageRank = orig.PageRank
+		dcopy.AnchorText = orig.AnchorText
 	}
 
 	if err := i.idx.Index(key, makeBleveDoc(dcopy)); err != nil {
 		return fmt.Errorf("index: %w", err)
 	}
 
+	if exists {
+		i.bands.remove(key, orig.Fingerprint)
+	}
+	i.bands.add(key, dcopy.Fingerprint)
 	i.docs[key] = dcopy
 	return nil
 }
 
 // FindByID looks up a document by its link ID.
-func (i *InMemoryIndexer) FindByID(linkID uuid.UUID) (*domain.Document, error) {
+func (i *InMemoryIndexer) FindByID(_ context.Context, linkID uuid.UUID) (*domain.Document, error) {
 	return i.findByID(linkID.String())
 }
 
@@ -95,7 +107,7 @@ func (i *InMemoryIndexer) findByID(linkID string) (*domain.Document, error) {
 
 // Search the index for a particular query and return back a result
 // iterator.
-func (i *InMemoryIndexer) Search(q *ports.DocumentQuery) (ports.DocumentIterator, error) {
+func (i *InMemoryIndexer) Search(ctx context.Context, q *ports.DocumentQuery) (ports.DocumentIterator, error) {
 	var bq query.Query
 	switch q.Type {
 	case ports.DocumentQueryTypePhrase:
@@ -108,18 +120,18 @@ func (i *InMemoryIndexer) Search(q *ports.DocumentQuery) (ports.DocumentIterator
 	searchReq.SortBy([]string{"-PageRank", "-_score"})
 	searchReq.Size = batchSize
 	searchReq.From = int(q.Offset)
-	rs, err := i.idx.Search(searchReq)
+	rs, err := i.idx.SearchInContext(ctx, searchReq)
 	if err != nil {
 		return nil, fmt.Errorf("search: %w", err)
 	}
 
-	return &documentIterator{idx: i, searchReq: searchReq, rs: rs, cumIdx: q.Offset}, nil
+	return &documentIterator{ctx: ctx, idx: i, searchReq: searchReq, rs: rs, cumIdx: q.Offset}, nil
 }
 
 // UpdateScore updates the PageRank score for a document with the specified
 // link ID. If no such document exists, a placeholder document with the
 // provided score will be created.
-func (i *InMemoryIndexer) UpdateScore(linkID uuid.UUID, score float64) error {
+func (i *InMemoryIndexer) UpdateScore(_ context.Context, linkID uuid.UUID, score float64) error {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
@@ -138,16 +150,72 @@ func (i *InMemoryIndexer) UpdateScore(linkID uuid.UUID, score float64) error {
 	return nil
 }
 
+// UpdateAnchorText replaces the anchor texts of the document with the
+// specified link ID. If no such document exists, a placeholder document with
+// the provided anchor texts will be created.
+func (i *InMemoryIndexer) UpdateAnchorText(_ context.Context, linkID uuid.UUID, anchorText []string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	key := linkID.String()
+	doc, found := i.docs[key]
+	if !found {
+		doc = &domain.Document{LinkID: linkID}
+		i.docs[key] = doc
+	}
+
+	doc.AnchorText = slices.Clone(anchorText)
+	if err := i.idx.Index(key, makeBleveDoc(doc)); err != nil {
+		return fmt.Errorf("update anchor text: %w", err)
+	}
+
+	return nil
+}
+
+// FindNearDuplicates returns the indexed documents whose fingerprint is
+// within maxDistance bits of fingerprint, sorted by distance. Placeholder
+// documents created by UpdateScore or UpdateAnchorText and documents without
+// any words are never returned. Candidates are looked up by fingerprint band,
+// so maxDistance may not exceed domain.NearDuplicateDistance.
+func (i *InMemoryIndexer) FindNearDuplicates(_ context.Context, fingerprint uint64, maxDistance int) ([]*domain.Document, error) {
+	if maxDistance > domain.NearDuplicateDistance {
+		return nil, fmt.Errorf("find near duplicates: %w", ports.TextIndexerErrDistanceTooLarge)
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var dups []*domain.Document
+	for key := range i.bands.candidates(fingerprint) {
+		d := i.docs[key]
+		if domain.FingerprintDistance(d.Fingerprint, fingerprint) > maxDistance {
+			continue
+		}
+		dups = append(dups, copyDoc(d))
+	}
+
+	slices.SortFunc(dups, func(a, b *domain.Document) int {
+		da, db := domain.FingerprintDistance(a.Fingerprint, fingerprint), domain.FingerprintDistance(b.Fingerprint, fingerprint)
+		if da != db {
+			return da - db
+		}
+		return strings.Compare(a.LinkID.String(), b.LinkID.String())
+	})
+	return dups, nil
+}
+
 func copyDoc(d *domain.Document) *domain.Document {
 	dcopy := new(domain.Document)
 	*dcopy = *d
+	dcopy.AnchorText = slices.Clone(d.AnchorText)
 	return dcopy
 }
 
 func makeBleveDoc(d *domain.Document) bleveDoc {
 	return bleveDoc{
-		Title:    d.Title,
-		Content:  d.Content,
-		PageRank: d.PageRank,
+		Title:      d.Title,
+		Content:    d.Content,
+		AnchorText: d.AnchorText,
+		PageRank:   d.PageRank,
 	}
 }
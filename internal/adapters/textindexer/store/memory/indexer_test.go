@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"github.com/bruceneco/links-r-us/internal/adapters/textindexer/index/indextest"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"testing"
+)
+
+func Test(t *testing.T) {
+	suite.Run(t, new(InMemoryIndexerTestSuite))
+}
+
+type InMemoryIndexerTestSuite struct {
+	suite.Suite
+	base indextest.SuiteBase
+	idx  io.Closer
+}
+
+func (s *InMemoryIndexerTestSuite) SetupTest() {
+	idx, err := NewInMemoryIndexer()
+	s.Require().Nil(err)
+	s.idx = idx.(io.Closer)
+	s.base.SetIndexer(idx)
+}
+func (s *InMemoryIndexerTestSuite) TearDownTest() {
+	s.Nil(s.idx.Close())
+}
+func (s *InMemoryIndexerTestSuite) TestIndexDocument() {
+	s.base.TestIndexDocument(s.T())
+}
+func (s *InMemoryIndexerTestSuite) TestIndexDoesNotOverridePageRank() {
+	s.base.TestIndexDoesNotOverridePageRank(s.T())
+}
+func (s *InMemoryIndexerTestSuite) TestFindByID() {
+	s.base.TestFindByID(s.T())
+}
+func (s *InMemoryIndexerTestSuite) TestPhraseSearch() {
+	s.base.TestPhraseSearch(s.T())
+}
+func (s *InMemoryIndexerTestSuite) TestMatchSearch() {
+	s.base.TestMatchSearch(s.T())
+}
+func (s *InMemoryIndexerTestSuite) TestMatchSearchWithOffset() {
+	s.base.TestMatchSearchWithOffset(s.T())
+}
+func (s *InMemoryIndexerTestSuite) TestSearchContextCancellation() {
+	s.base.TestSearchContextCancellation(s.T())
+}
+func (s *InMemoryIndexerTestSuite) TestUpdateScore() {
+	s.base.TestUpdateScore(s.T())
+}
+func (s *InMemoryIndexerTestSuite) TestUpdateScoreForUnknownDocument() {
+	s.base.TestUpdateScoreForUnknownDocument(s.T())
+}
+func (s *InMemoryIndexerTestSuite) TestUpdateAnchorText() {
+	s.base.TestUpdateAnchorText(s.T())
+}
+func (s *InMemoryIndexerTestSuite) TestFindNearDuplicates() {
+	s.base.TestFindNearDuplicates(s.T())
+}
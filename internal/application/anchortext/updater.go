@@ -0,0 +1,84 @@
+// Package anchortext keeps the anchor texts indexed for each document in
+// sync with the edges pointing to its link, so pages can be found by how
+// other pages describe them.
+package anchortext
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+)
+
+// MaxAnchorTexts bounds the number of anchor texts indexed for a document,
+// so heavily linked pages do not end up with unbounded documents.
+const MaxAnchorTexts = 100
+
+// Updater copies the anchor texts of the edges pointing to a link into the
+// document of the link.
+type Updater struct {
+	graph   repository.GraphRepository
+	indexer ports.TextIndexer
+}
+
+// NewUpdater creates an Updater reading edges from graph and updating the
+// documents of indexer.
+func NewUpdater(graph repository.GraphRepository, indexer ports.TextIndexer) *Updater {
+	return &Updater{graph: graph, indexer: indexer}
+}
+
+// Update replaces the anchor texts of the document of linkID with the
+// distinct, non-empty anchor texts of the link edges pointing to it, in the
+// order the graph returns them. Redirect edges carry no anchor text and are
+// ignored. No placeholder document is created for a link that has neither
+// a document nor anchor texts.
+func (u *Updater) Update(ctx context.Context, linkID uuid.UUID) error {
+	texts, err := u.collect(ctx, linkID)
+	if err != nil {
+		return fmt.Errorf("update anchor text: %w", err)
+	}
+
+	if len(texts) == 0 {
+		_, err = u.indexer.FindByID(ctx, linkID)
+		if errors.Is(err, ports.TextIndexerErrNotFound) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("update anchor text: %w", err)
+		}
+	}
+	if err = u.indexer.UpdateAnchorText(ctx, linkID, texts); err != nil {
+		return fmt.Errorf("update anchor text: %w", err)
+	}
+	return nil
+}
+
+// collect returns the distinct anchor texts of the link edges pointing to
+// linkID.
+func (u *Updater) collect(ctx context.Context, linkID uuid.UUID) ([]string, error) {
+	it, err := u.graph.InEdges(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = it.Close() }()
+
+	var (
+		texts []string
+		seen  = make(map[string]bool)
+	)
+	for it.Next() && len(texts) < MaxAnchorTexts {
+		edge := it.Edge()
+		if edge.Kind != domain.EdgeKindLink || edge.AnchorText == "" || seen[edge.AnchorText] {
+			continue
+		}
+		seen[edge.AnchorText] = true
+		texts = append(texts, edge.AnchorText)
+	}
+	if err = it.Error(); err != nil {
+		return nil, err
+	}
+	return texts, nil
+}
@@ -0,0 +1,80 @@
+package anchortext
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/repository/memory"
+	indexmemory "github.com/bruceneco/links-r-us/internal/adapters/textindexer/store/memory"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDeps(t *testing.T) (repository.GraphRepository, ports.TextIndexer) {
+	indexer, err := indexmemory.NewInMemoryIndexer()
+	require.NoError(t, err)
+	return memory.NewInMemoryGraph(), indexer
+}
+
+func upsertLink(t *testing.T, g repository.GraphRepository, url string) *domain.Link {
+	link := &domain.Link{URL: url}
+	require.NoError(t, g.UpsertLink(context.TODO(), link))
+	return link
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.TODO()
+	g, indexer := newDeps(t)
+	dst := upsertLink(t, g, "https://example.com/gophers")
+
+	edges := []*domain.Edge{
+		{AnchorText: "all about gophers"},
+		{AnchorText: "gopher facts"},
+		{AnchorText: "all about gophers"},
+		{},
+		{AnchorText: "moved here", Kind: domain.EdgeKindRedirect},
+	}
+	for i, edge := range edges {
+		src := upsertLink(t, g, "https://example.com/src/"+string(rune('a'+i)))
+		edge.Src, edge.Dst, edge.UpdatedAt = src.ID, dst.ID, time.Now()
+		require.NoError(t, g.UpsertEdge(ctx, edge))
+	}
+
+	u := NewUpdater(g, indexer)
+	require.NoError(t, u.Update(ctx, dst.ID))
+
+	doc, err := indexer.FindByID(ctx, dst.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"all about gophers", "gopher facts"}, doc.AnchorText)
+
+	it, err := indexer.Search(ctx, &ports.DocumentQuery{Type: ports.DocumentQueryTypeMatch, Expression: "facts"})
+	require.NoError(t, err)
+	require.True(t, it.Next(), "expected the document to be found by its anchor text")
+	assert.Equal(t, dst.ID, it.Document().LinkID)
+	require.NoError(t, it.Close())
+
+	// Removing the edges clears the anchor texts of the existing document.
+	require.NoError(t, g.RemoveStaleEdges(ctx, edges[0].Src, time.Now()))
+	require.NoError(t, g.RemoveStaleEdges(ctx, edges[1].Src, time.Now()))
+	require.NoError(t, g.RemoveStaleEdges(ctx, edges[2].Src, time.Now()))
+	require.NoError(t, u.Update(ctx, dst.ID))
+
+	doc, err = indexer.FindByID(ctx, dst.ID)
+	require.NoError(t, err)
+	assert.Empty(t, doc.AnchorText)
+}
+
+func TestUpdateWithoutAnchorText(t *testing.T) {
+	ctx := context.TODO()
+	g, indexer := newDeps(t)
+	dst := upsertLink(t, g, "https://example.com/lonely")
+
+	require.NoError(t, NewUpdater(g, indexer).Update(ctx, dst.ID))
+
+	_, err := indexer.FindByID(ctx, dst.ID)
+	assert.ErrorIs(t, err, ports.TextIndexerErrNotFound, "expected no placeholder document to be created")
+}
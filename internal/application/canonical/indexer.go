@@ -0,0 +1,52 @@
+// Package canonical makes sure the contents of links that duplicate another
+// link are only indexed under the canonical link.
+package canonical
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+)
+
+// Indexer is a ports.TextIndexer that stores documents under the canonical
+// link of their LinkID, as recorded in the graph by MergeLink. Every other
+// method is passed through to the wrapped indexer.
+type Indexer struct {
+	ports.TextIndexer
+	graph repository.GraphRepository
+}
+
+// NewIndexer wraps indexer so that documents are indexed under the
+// canonical link of their LinkID in graph.
+func NewIndexer(indexer ports.TextIndexer, graph repository.GraphRepository) *Indexer {
+	return &Indexer{TextIndexer: indexer, graph: graph}
+}
+
+// Index stores doc under the canonical link of doc.LinkID. If the link
+// duplicates another link, doc.LinkID and doc.URL are replaced with the ID
+// and URL of the canonical link before doc is indexed, so the duplicate has
+// no document of its own. Documents of links unknown to the graph are
+// indexed as they are.
+func (i *Indexer) Index(ctx context.Context, doc *domain.Document) error {
+	link, err := i.graph.FindLink(ctx, doc.LinkID)
+	switch {
+	case errors.Is(err, repository.GraphErrNotFound):
+		return i.TextIndexer.Index(ctx, doc)
+	case err != nil:
+		return fmt.Errorf("index canonical document: %w", err)
+	}
+
+	if canonicalID := link.CanonicalLinkID(); canonicalID != doc.LinkID {
+		canonicalLink, err := i.graph.FindLink(ctx, canonicalID)
+		if err != nil {
+			return fmt.Errorf("index canonical document: %w", err)
+		}
+		doc.LinkID = canonicalLink.ID
+		doc.URL = canonicalLink.URL
+	}
+	return i.TextIndexer.Index(ctx, doc)
+}
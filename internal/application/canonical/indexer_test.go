@@ -0,0 +1,67 @@
+package canonical
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/repository/memory"
+	indexmemory "github.com/bruceneco/links-r-us/internal/adapters/textindexer/store/memory"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDeps(t *testing.T) (repository.GraphRepository, ports.TextIndexer) {
+	indexer, err := indexmemory.NewInMemoryIndexer()
+	require.NoError(t, err)
+	return memory.NewInMemoryGraph(), indexer
+}
+
+func upsertLink(t *testing.T, g repository.GraphRepository, url string) *domain.Link {
+	link := &domain.Link{URL: url}
+	require.NoError(t, g.UpsertLink(context.TODO(), link))
+	return link
+}
+
+func TestIndexDuplicate(t *testing.T) {
+	ctx := context.TODO()
+	g, wrapped := newDeps(t)
+	canonical := upsertLink(t, g, "https://example.com/article")
+	dup := upsertLink(t, g, "https://example.com/article?utm_source=feed")
+	require.NoError(t, g.MergeLink(ctx, dup.ID, canonical.ID))
+
+	indexer := NewIndexer(wrapped, g)
+	require.NoError(t, indexer.Index(ctx, &domain.Document{
+		LinkID:  dup.ID,
+		URL:     dup.URL,
+		Title:   "Article",
+		Content: "the article body",
+	}))
+
+	doc, err := wrapped.FindByID(ctx, canonical.ID)
+	require.NoError(t, err)
+	assert.Equal(t, canonical.URL, doc.URL)
+	assert.Equal(t, "Article", doc.Title)
+
+	_, err = wrapped.FindByID(ctx, dup.ID)
+	assert.ErrorIs(t, err, ports.TextIndexerErrNotFound, "expected nothing to be indexed under the duplicate")
+}
+
+func TestIndexCanonical(t *testing.T) {
+	ctx := context.TODO()
+	g, wrapped := newDeps(t)
+	link := upsertLink(t, g, "https://example.com/article")
+	unknownID := uuid.New()
+
+	indexer := NewIndexer(wrapped, g)
+	for _, id := range []uuid.UUID{link.ID, unknownID} {
+		require.NoError(t, indexer.Index(ctx, &domain.Document{LinkID: id, URL: "https://example.com/" + id.String(), Title: "Page"}))
+
+		doc, err := wrapped.FindByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/"+id.String(), doc.URL)
+	}
+}
@@ -15,4 +15,13 @@ type Document struct {
 
 	IndexedAt time.Time
 	PageRank  float64
+
+	// AnchorText lists the anchor texts of the edges pointing to the
+	// document, so it can be found by how other pages describe it.
+	AnchorText []string
+
+	// Fingerprint is the SimHash of the title and content of the document,
+	// computed when it is indexed. Documents whose fingerprints are within
+	// NearDuplicateDistance of each other are near-duplicates.
+	Fingerprint uint64
 }
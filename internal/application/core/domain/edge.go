@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"fmt"
 	"github.com/google/uuid"
+	"strings"
 	"time"
 )
 
@@ -15,4 +17,71 @@ type Edge struct {
 	Dst uuid.UUID
 	// UpdatedAt is the datetime of last Edge visiting.
 	UpdatedAt time.Time
+	// AnchorText is the text of the anchor the Edge was discovered from.
+	AnchorText string
+	// Rel holds the flags of the rel attribute of the anchor the Edge was
+	// discovered from.
+	Rel EdgeRel
+	// Kind tells how the Src Link leads to the Dst Link.
+	Kind EdgeKind
+}
+
+// EdgeKind is the type of relationship an Edge represents.
+type EdgeKind uint8
+
+const (
+	// EdgeKindLink is an Edge discovered from an anchor in the Src page.
+	EdgeKindLink EdgeKind = iota
+	// EdgeKindRedirect is an Edge from a Link that redirects, through an
+	// HTTP redirect or a meta refresh, to the final Link of the redirect
+	// chain. The Src Link has no content of its own, so its score should
+	// be transferred to Dst and its content should not be indexed.
+	EdgeKindRedirect
+)
+
+// String returns the name of the EdgeKind.
+func (k EdgeKind) String() string {
+	switch k {
+	case EdgeKindLink:
+		return "link"
+	case EdgeKindRedirect:
+		return "redirect"
+	default:
+		return fmt.Sprintf("EdgeKind(%d)", uint8(k))
+	}
+}
+
+// EdgeRel is a set of link relations that qualify an Edge.
+type EdgeRel uint8
+
+const (
+	// EdgeRelNoFollow marks an Edge the source page does not endorse.
+	EdgeRelNoFollow EdgeRel = 1 << iota
+	// EdgeRelSponsored marks an Edge created as part of an advertisement or
+	// sponsorship.
+	EdgeRelSponsored
+	// EdgeRelUGC marks an Edge found in user-generated content.
+	EdgeRelUGC
+)
+
+// ParseEdgeRel returns the flags named by the space-separated values of an
+// HTML rel attribute. Unknown values are ignored.
+func ParseEdgeRel(attr string) EdgeRel {
+	var rel EdgeRel
+	for _, value := range strings.Fields(strings.ToLower(attr)) {
+		switch value {
+		case "nofollow":
+			rel |= EdgeRelNoFollow
+		case "sponsored":
+			rel |= EdgeRelSponsored
+		case "ugc":
+			rel |= EdgeRelUGC
+		}
+	}
+	return rel
+}
+
+// Has reports whether every flag in flags is set.
+func (r EdgeRel) Has(flags EdgeRel) bool {
+	return r&flags == flags
 }
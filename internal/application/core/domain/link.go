@@ -13,4 +13,72 @@ type Link struct {
 	URL string
 	// RetrievedAt is when the Link was retrieved.
 	RetrievedAt time.Time
+	// CanonicalID is the ID of the Link this Link duplicates, as declared
+	// by a rel=canonical link element, or uuid.Nil if the Link is canonical.
+	// It is only set when merging links and is left untouched by upserts.
+	CanonicalID uuid.UUID
+
+	// The fields below describe the outcome of the most recent attempt to
+	// fetch the Link. They are only replaced when a Link is upserted with a
+	// more recent RetrievedAt, so discovering a URL again never discards
+	// what was learnt by fetching it.
+
+	// StatusCode is the HTTP status code of the last response, or 0 if no
+	// response was received.
+	StatusCode int
+	// ContentType is the Content-Type header of the last response.
+	ContentType string
+	// ContentLength is the size in bytes of the last response body, or 0 if
+	// it is unknown, such as when the Link has not been fetched yet.
+	ContentLength int64
+	// ETag is the ETag header of the last response.
+	ETag string
+	// LastModified is the Last-Modified header of the last response, kept
+	// verbatim so it can be sent back in conditional requests.
+	LastModified string
+	// FailureCount is the number of consecutive failed fetch attempts.
+	FailureCount int
+	// LastError describes why the last fetch attempt failed, if it did.
+	LastError string
+}
+
+// CanonicalLinkID returns the ID of the canonical Link, under which the
+// content of the Link is indexed and its edges are recorded.
+func (l *Link) CanonicalLinkID() uuid.UUID {
+	if l.CanonicalID != uuid.Nil {
+		return l.CanonicalID
+	}
+	return l.ID
+}
+
+// ConditionalHeaders returns the HTTP headers that make a request for the
+// Link conditional on its content having changed since it was last fetched.
+// It returns nil if the last response carried neither an ETag nor a
+// Last-Modified header, in which case the Link must be fetched in full.
+func (l *Link) ConditionalHeaders() map[string]string {
+	if l.ETag == "" && l.LastModified == "" {
+		return nil
+	}
+
+	headers := make(map[string]string, 2)
+	if l.ETag != "" {
+		headers["If-None-Match"] = l.ETag
+	}
+	if l.LastModified != "" {
+		headers["If-Modified-Since"] = l.LastModified
+	}
+	return headers
+}
+
+// NotModified returns a copy of the Link that records a fetch at
+// retrievedAt answered with 304 Not Modified. The metadata of the previous
+// full response is kept, since a 304 response describes the same content,
+// and the failure count is reset. Graph repositories apply it to stored
+// links in TouchLink.
+func (l *Link) NotModified(retrievedAt time.Time) *Link {
+	link := *l
+	link.RetrievedAt = retrievedAt
+	link.FailureCount = 0
+	link.LastError = ""
+	return &link
 }
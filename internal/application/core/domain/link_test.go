@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditionalHeaders(t *testing.T) {
+	specs := []struct {
+		descr        string
+		etag         string
+		lastModified string
+		exp          map[string]string
+	}{
+		{
+			descr: "etag only",
+			etag:  `"v1"`,
+			exp:   map[string]string{"If-None-Match": `"v1"`},
+		},
+		{
+			descr:        "last-modified only",
+			lastModified: "Wed, 21 Oct 2015 07:28:00 GMT",
+			exp:          map[string]string{"If-Modified-Since": "Wed, 21 Oct 2015 07:28:00 GMT"},
+		},
+		{
+			descr:        "etag and last-modified",
+			etag:         `W/"v2"`,
+			lastModified: "Wed, 21 Oct 2015 07:28:00 GMT",
+			exp: map[string]string{
+				"If-None-Match":     `W/"v2"`,
+				"If-Modified-Since": "Wed, 21 Oct 2015 07:28:00 GMT",
+			},
+		},
+		{
+			descr: "neither",
+		},
+	}
+
+	for _, spec := range specs {
+		link := &Link{URL: "https://example.com", ETag: spec.etag, LastModified: spec.lastModified}
+		assert.Equal(t, spec.exp, link.ConditionalHeaders(), spec.descr)
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	fetchedAt := time.Now().Add(-24 * time.Hour)
+	link := &Link{
+		ID:            uuid.New(),
+		URL:           "https://example.com",
+		RetrievedAt:   fetchedAt,
+		CanonicalID:   uuid.New(),
+		StatusCode:    200,
+		ContentType:   "text/html",
+		ContentLength: 1024,
+		ETag:          `"v1"`,
+		LastModified:  "Wed, 21 Oct 2015 07:28:00 GMT",
+		FailureCount:  2,
+		LastError:     "connection reset by peer",
+	}
+	orig := *link
+
+	now := time.Now()
+	got := link.NotModified(now)
+
+	exp := orig
+	exp.RetrievedAt = now
+	exp.FailureCount = 0
+	exp.LastError = ""
+	assert.Equal(t, &exp, got)
+	assert.Equal(t, orig, *link, "expected receiver to be left unchanged")
+	assert.NotSame(t, link, got)
+}
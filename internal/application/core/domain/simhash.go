@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+// NearDuplicateDistance is the largest number of differing bits between the
+// fingerprints of two documents that are considered near-duplicates.
+const NearDuplicateDistance = 3
+
+// ShingleSize is the number of consecutive words hashed together as one
+// SimHash feature. Hashing word sequences rather than single words makes the
+// fingerprint depend on word order, so unrelated texts built from a common
+// vocabulary do not look alike.
+const ShingleSize = 3
+
+// FingerprintBands is the number of equally sized bands a fingerprint is
+// split into by FingerprintBand. Since it exceeds NearDuplicateDistance, two
+// fingerprints within that distance agree on at least one band, which lets
+// indexers find near-duplicates with exact band lookups instead of comparing
+// every fingerprint.
+const FingerprintBands = NearDuplicateDistance + 1
+
+// SimHash returns a 64-bit fingerprint of text. Unlike a cryptographic hash,
+// texts that share most of their shingles yield fingerprints that differ in
+// only a few bits, so near-duplicates can be found by their
+// FingerprintDistance. Texts without any words have the fingerprint 0, which
+// is never returned for other texts, so it can be used to tell that there is
+// nothing to compare.
+func SimHash(text string) uint64 {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	if len(words) == 0 {
+		return 0
+	}
+
+	// Texts shorter than a shingle form a single feature.
+	var weights [64]int
+	numShingles := max(len(words)-ShingleSize+1, 1)
+	for start := 0; start < numShingles; start++ {
+		shingle := words[start:min(start+ShingleSize, len(words))]
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(strings.Join(shingle, " ")))
+		sum := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			fingerprint |= 1 << bit
+		}
+	}
+	if fingerprint == 0 {
+		fingerprint = 1
+	}
+	return fingerprint
+}
+
+// FingerprintDistance returns the number of bits that differ between two
+// fingerprints returned by SimHash.
+func FingerprintDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// FingerprintBand returns band n, counting from zero, of the
+// FingerprintBands bands of fingerprint.
+func FingerprintBand(fingerprint uint64, n int) uint16 {
+	return uint16(fingerprint >> (n * 64 / FingerprintBands))
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimHashEmptyText(t *testing.T) {
+	assert.Zero(t, SimHash(""))
+	assert.Zero(t, SimHash(" \n\t-- !"))
+	assert.NotZero(t, SimHash("hello"))
+}
+
+func TestSimHashWordOrder(t *testing.T) {
+	text := "the quick brown fox jumps over the lazy dog"
+	assert.Equal(t, SimHash(text), SimHash("The quick, brown fox jumps over the lazy dog!"))
+	assert.Greater(t, FingerprintDistance(SimHash(text), SimHash("the lazy dog jumps over the quick brown fox")), NearDuplicateDistance,
+		"expected reordered words to yield a different fingerprint")
+}
+
+func TestFingerprintBands(t *testing.T) {
+	fingerprint := uint64(0x0123456789abcdef)
+	var bands []uint16
+	for n := 0; n < FingerprintBands; n++ {
+		bands = append(bands, FingerprintBand(fingerprint, n))
+	}
+	assert.Equal(t, []uint16{0xcdef, 0x89ab, 0x4567, 0x0123}, bands)
+
+	// Flipping NearDuplicateDistance bits leaves at least one band intact.
+	near := fingerprint ^ (1 | 1<<16 | 1<<32)
+	var shared int
+	for n := 0; n < FingerprintBands; n++ {
+		if FingerprintBand(near, n) == FingerprintBand(fingerprint, n) {
+			shared++
+		}
+	}
+	assert.Equal(t, 1, shared)
+}
@@ -0,0 +1,591 @@
+// Package crawler periodically fetches the links of the graph, records the
+// links and edges found in each page and indexes the page contents.
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bruceneco/links-r-us/internal/application/anchortext"
+	"github.com/bruceneco/links-r-us/internal/application/canonical"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/partition"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultWorkers         = 4
+	defaultReCrawlInterval = 7 * 24 * time.Hour
+	defaultInterval        = 5 * time.Minute
+	defaultMaxBodySize     = 4 << 20
+	defaultFetchTimeout    = 30 * time.Second
+	defaultUserAgent       = "links-r-us crawler"
+)
+
+// Config holds the dependencies and settings of a Crawler. Zero values are
+// replaced with sensible defaults.
+type Config struct {
+	// Graph provides the links to crawl and records what is found in them.
+	Graph repository.GraphRepository
+	// Indexer receives the contents of the crawled pages.
+	Indexer ports.TextIndexer
+
+	// Client performs the HTTP requests. It must follow redirects.
+	Client *http.Client
+	// UserAgent is sent with every request.
+	UserAgent string
+	// Workers is the number of links fetched concurrently.
+	Workers int
+	// MaxBodySize is the number of bytes of each response that are read.
+	MaxBodySize int64
+
+	// PartitionDetector assigns each crawler instance the part of the graph
+	// it crawls, so that several instances can share the work. Defaults to
+	// a single partition covering the whole graph.
+	PartitionDetector partition.Detector
+
+	// ReCrawlInterval is the time after which a link is fetched again.
+	ReCrawlInterval time.Duration
+	// Interval is the time Run waits between passes.
+	Interval time.Duration
+
+	// Logger receives the outcome of each pass run by Run.
+	Logger *slog.Logger
+}
+
+func (cfg *Config) validate() error {
+	var err error
+	if cfg.Graph == nil {
+		err = errors.Join(err, errors.New("graph not provided"))
+	}
+	if cfg.Indexer == nil {
+		err = errors.Join(err, errors.New("indexer not provided"))
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: defaultFetchTimeout}
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = defaultUserAgent
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultWorkers
+	}
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = defaultMaxBodySize
+	}
+	if cfg.PartitionDetector == nil {
+		cfg.PartitionDetector = partition.Fixed{Partition: 0, NumPartitions: 1}
+	}
+	if cfg.ReCrawlInterval <= 0 {
+		cfg.ReCrawlInterval = defaultReCrawlInterval
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultInterval
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	return err
+}
+
+// Crawler fetches the links of a graph.
+type Crawler struct {
+	cfg     Config
+	indexer *canonical.Indexer
+	anchors *anchortext.Updater
+}
+
+// NewCrawler creates a Crawler using cfg.
+func NewCrawler(cfg Config) (*Crawler, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("crawler: config validation failed: %w", err)
+	}
+	return &Crawler{
+		cfg:     cfg,
+		indexer: canonical.NewIndexer(cfg.Indexer, cfg.Graph),
+		anchors: anchortext.NewUpdater(cfg.Graph, cfg.Indexer),
+	}, nil
+}
+
+// Run crawls the graph every cfg.Interval until ctx is cancelled. Failed
+// passes are logged and retried on the next tick.
+func (c *Crawler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		start := time.Now()
+		if n, err := c.Crawl(ctx); err != nil {
+			switch {
+			case ctx.Err() != nil:
+				return nil
+			case errors.Is(err, partition.ErrNoPartitionDataAvailableYet):
+				c.cfg.Logger.Warn("crawler pass skipped: no partition data available yet")
+			default:
+				c.cfg.Logger.Error("crawler pass failed", "err", err)
+			}
+		} else {
+			c.cfg.Logger.Info("crawler pass completed", "links", n, "took", time.Since(start))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Crawl fetches every link of the partition assigned by
+// cfg.PartitionDetector that was not retrieved within cfg.ReCrawlInterval
+// and returns the number of links fetched. Errors affecting a single link
+// are recorded on the link and do not stop the pass.
+func (c *Crawler) Crawl(ctx context.Context) (int, error) {
+	ids, err := c.partitionRange(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("crawl: %w", err)
+	}
+	links, err := c.cfg.Graph.Links(ctx, ids, time.Now().Add(-c.cfg.ReCrawlInterval))
+	if err != nil {
+		return 0, fmt.Errorf("crawl: %w", err)
+	}
+
+	var (
+		wg    sync.WaitGroup
+		queue = make(chan *domain.Link)
+	)
+	for i := 0; i < c.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for link := range queue {
+				if err := c.CrawlLink(ctx, link); err != nil && ctx.Err() == nil {
+					c.cfg.Logger.Warn("failed to crawl link", "url", link.URL, "err", err)
+				}
+			}
+		}()
+	}
+
+	var n int
+	for links.Next() {
+		select {
+		case queue <- links.Link():
+			n++
+		case <-ctx.Done():
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	err = links.Error()
+	if closeErr := links.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		return n, fmt.Errorf("crawl: %w", err)
+	}
+	return n, nil
+}
+
+// partitionRange returns the IDs of the links assigned to this instance.
+func (c *Crawler) partitionRange(ctx context.Context) (repository.IDRange, error) {
+	p, numPartitions, err := c.cfg.PartitionDetector.PartitionInfo(ctx)
+	if err != nil {
+		return repository.IDRange{}, err
+	}
+	r, err := partition.NewFullRange(numPartitions)
+	if err != nil {
+		return repository.IDRange{}, err
+	}
+	from, to, err := r.PartitionExtents(p)
+	if err != nil {
+		return repository.IDRange{}, err
+	}
+	return repository.NewIDRange(from, to), nil
+}
+
+// CrawlLink fetches link and records the outcome in the graph. If the
+// response is an HTML page, the links found in it are upserted along with
+// the edges pointing to them, edges the page no longer contains are
+// removed and the page is indexed. The returned error reports failures to
+// update the graph or the indexer; fetch failures are recorded on the link
+// instead.
+//
+// If the request is redirected, the response is recorded under the final
+// link of the redirect chain, and every link of the chain gets a redirect
+// edge to it instead of its own edges and document. Pages redirecting with
+// a meta refresh element are handled alike, except that the target link is
+// left to be fetched by a later pass.
+//
+// If the page declares a canonical URL with a <link rel="canonical">
+// element, link is merged into the canonical link, which receives the
+// edges and document of the page.
+//
+// Links fetched before are requested conditionally. A 304 Not Modified
+// response is recorded with TouchLink, which keeps the edges of the link,
+// without extracting or indexing anything. Pages whose title and text have
+// not changed since they were last indexed are not indexed again.
+func (c *Crawler) CrawlLink(ctx context.Context, link *domain.Link) error {
+	fetchedAt := time.Now()
+	resp, err := c.fetch(ctx, link)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return c.recordFailure(ctx, link, fetchedAt, 0, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotModified {
+		if err = c.cfg.Graph.TouchLink(ctx, link.ID, fetchedAt); err != nil {
+			return fmt.Errorf("crawl link: %w", err)
+		}
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.cfg.MaxBodySize))
+	if err != nil {
+		return c.recordFailure(ctx, link, fetchedAt, resp.StatusCode, fmt.Errorf("read body: %w", err))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return c.recordFailure(ctx, link, fetchedAt, resp.StatusCode, fmt.Errorf("unexpected status %q", resp.Status))
+	}
+
+	target := link
+	if finalURL := withoutFragment(resp.Request.URL); finalURL != link.URL {
+		if target, err = c.recordRedirects(ctx, link, redirects(resp), finalURL, fetchedAt); err != nil {
+			return fmt.Errorf("crawl link: %w", err)
+		}
+	}
+
+	fetched := *target
+	fetched.RetrievedAt = fetchedAt
+	fetched.StatusCode = resp.StatusCode
+	fetched.ContentType = resp.Header.Get("Content-Type")
+	fetched.ContentLength = int64(len(body))
+	fetched.ETag = resp.Header.Get("ETag")
+	fetched.LastModified = resp.Header.Get("Last-Modified")
+	fetched.FailureCount = 0
+	fetched.LastError = ""
+	if err = c.cfg.Graph.UpsertLink(ctx, &fetched); err != nil {
+		return fmt.Errorf("crawl link: %w", err)
+	}
+
+	if !isHTML(fetched.ContentType) {
+		return nil
+	}
+	p, err := extract(resp.Request.URL, bytes.NewReader(body))
+	if err != nil {
+		return c.recordFailure(ctx, &fetched, fetchedAt, resp.StatusCode, err)
+	}
+	if p.refresh != "" && p.refresh != fetched.URL {
+		if err = c.recordRefresh(ctx, &fetched, p.refresh, fetchedAt); err != nil {
+			return fmt.Errorf("crawl link: %w", err)
+		}
+		return nil
+	}
+	if err = c.recordCanonical(ctx, &fetched, p.canonical); err != nil {
+		return fmt.Errorf("crawl link: %w", err)
+	}
+	if err = c.recordEdges(ctx, &fetched, p, fetchedAt); err != nil {
+		return fmt.Errorf("crawl link: %w", err)
+	}
+	if err = c.index(ctx, &fetched, p); err != nil {
+		return fmt.Errorf("crawl link: %w", err)
+	}
+	return nil
+}
+
+// fetch requests link, conditionally on it having changed if it was
+// fetched before.
+func (c *Crawler) fetch(ctx context.Context, link *domain.Link) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range link.ConditionalHeaders() {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("User-Agent", c.cfg.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.1")
+	return c.cfg.Client.Do(req)
+}
+
+// redirects returns the redirect responses that led to resp, in the order
+// they were received.
+func redirects(resp *http.Response) []*http.Response {
+	var chain []*http.Response
+	for r := resp.Request.Response; r != nil; r = r.Request.Response {
+		chain = append(chain, r)
+	}
+	slices.Reverse(chain)
+	return chain
+}
+
+// recordRedirects records the links of a redirect chain that started at
+// link and ended at finalURL, along with a redirect edge from each of them
+// to the final link, which is returned.
+func (c *Crawler) recordRedirects(ctx context.Context, link *domain.Link, hops []*http.Response, finalURL string, fetchedAt time.Time) (*domain.Link, error) {
+	target := &domain.Link{URL: finalURL}
+	if err := c.cfg.Graph.UpsertLink(ctx, target); err != nil {
+		return nil, fmt.Errorf("record redirects: %w", err)
+	}
+
+	for i, hop := range hops {
+		src := &domain.Link{URL: withoutFragment(hop.Request.URL)}
+		if i == 0 {
+			src = link
+		}
+		if src.URL == target.URL {
+			continue
+		}
+
+		redirected := *src
+		redirected.RetrievedAt = fetchedAt
+		redirected.StatusCode = hop.StatusCode
+		redirected.ContentType = hop.Header.Get("Content-Type")
+		redirected.ContentLength = 0
+		redirected.ETag = ""
+		redirected.LastModified = ""
+		redirected.FailureCount = 0
+		redirected.LastError = ""
+		if err := c.cfg.Graph.UpsertLink(ctx, &redirected); err != nil {
+			return nil, fmt.Errorf("record redirects: %w", err)
+		}
+		if err := c.redirect(ctx, &redirected, target, fetchedAt); err != nil {
+			return nil, fmt.Errorf("record redirects: %w", err)
+		}
+	}
+	return target, nil
+}
+
+// recordRefresh records that link redirects to targetURL with a meta
+// refresh element.
+func (c *Crawler) recordRefresh(ctx context.Context, link *domain.Link, targetURL string, fetchedAt time.Time) error {
+	target := &domain.Link{URL: targetURL}
+	if err := c.cfg.Graph.UpsertLink(ctx, target); err != nil {
+		return fmt.Errorf("record refresh: %w", err)
+	}
+	if err := c.redirect(ctx, link, target, fetchedAt); err != nil {
+		return fmt.Errorf("record refresh: %w", err)
+	}
+	return nil
+}
+
+// redirect replaces the edges of src with a redirect edge to dst.
+func (c *Crawler) redirect(ctx context.Context, src, dst *domain.Link, fetchedAt time.Time) error {
+	return c.replaceEdges(ctx, src, []*domain.Edge{{
+		Src:       src.ID,
+		Dst:       dst.ID,
+		UpdatedAt: fetchedAt,
+		Kind:      domain.EdgeKindRedirect,
+	}}, fetchedAt)
+}
+
+// recordFailure records a failed attempt to fetch link at fetchedAt. The
+// metadata of the last successful response is kept.
+func (c *Crawler) recordFailure(ctx context.Context, link *domain.Link, fetchedAt time.Time, statusCode int, cause error) error {
+	failed := *link
+	failed.RetrievedAt = fetchedAt
+	failed.StatusCode = statusCode
+	failed.FailureCount++
+	failed.LastError = cause.Error()
+	if err := c.cfg.Graph.UpsertLink(ctx, &failed); err != nil {
+		return fmt.Errorf("record failure: %w", err)
+	}
+	return nil
+}
+
+// recordCanonical merges link into the link of canonicalURL, or makes link
+// canonical again if canonicalURL is empty or link itself. link is updated
+// with the stored values afterwards.
+func (c *Crawler) recordCanonical(ctx context.Context, link *domain.Link, canonicalURL string) error {
+	canonicalID := link.ID
+	if canonicalURL != "" && canonicalURL != link.URL {
+		canonicalLink := &domain.Link{URL: canonicalURL}
+		if err := c.cfg.Graph.UpsertLink(ctx, canonicalLink); err != nil {
+			return fmt.Errorf("record canonical: %w", err)
+		}
+		canonicalID = canonicalLink.ID
+	}
+	if canonicalID == link.CanonicalLinkID() {
+		return nil
+	}
+
+	if err := c.cfg.Graph.MergeLink(ctx, link.ID, canonicalID); err != nil {
+		return fmt.Errorf("record canonical: %w", err)
+	}
+	stored, err := c.cfg.Graph.FindLink(ctx, link.ID)
+	if err != nil {
+		return fmt.Errorf("record canonical: %w", err)
+	}
+	*link = *stored
+	return nil
+}
+
+// recordEdges upserts the links found in p along with the edges from link
+// to them. The edges of a link that duplicates another link are recorded
+// under the canonical link, whose other edges are left alone, as they are
+// maintained by crawling the canonical link itself.
+func (c *Crawler) recordEdges(ctx context.Context, link *domain.Link, p *page, fetchedAt time.Time) error {
+	srcID := link.CanonicalLinkID()
+	targets := make([]*domain.Link, 0, len(p.links))
+	for _, l := range p.links {
+		if l.url != link.URL {
+			targets = append(targets, &domain.Link{URL: l.url})
+		}
+	}
+	if len(targets) != 0 {
+		if err := c.cfg.Graph.UpsertLinks(ctx, targets); err != nil {
+			return fmt.Errorf("record edges: %w", err)
+		}
+	}
+
+	edges := make([]*domain.Edge, 0, len(targets))
+	ids := make(map[string]*domain.Link, len(targets))
+	for _, target := range targets {
+		ids[target.URL] = target
+	}
+	for _, l := range p.links {
+		target, ok := ids[l.url]
+		if !ok || target.ID == srcID {
+			continue
+		}
+		edges = append(edges, &domain.Edge{
+			Src:        srcID,
+			Dst:        target.ID,
+			UpdatedAt:  fetchedAt,
+			AnchorText: l.anchorText,
+			Rel:        l.rel,
+			Kind:       domain.EdgeKindLink,
+		})
+	}
+	var err error
+	if srcID != link.ID {
+		err = c.addEdges(ctx, edges)
+	} else {
+		err = c.replaceEdges(ctx, link, edges, fetchedAt)
+	}
+	if err != nil {
+		return fmt.Errorf("record edges: %w", err)
+	}
+	return nil
+}
+
+// addEdges upserts edges and updates the anchor texts of the links they
+// point to.
+func (c *Crawler) addEdges(ctx context.Context, edges []*domain.Edge) error {
+	if len(edges) == 0 {
+		return nil
+	}
+	if err := c.cfg.Graph.UpsertEdges(ctx, edges); err != nil {
+		return err
+	}
+	for _, edge := range edges {
+		if err := c.anchors.Update(ctx, edge.Dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceEdges upserts edges, which must all originate from src, and
+// removes the other edges of src. The anchor texts of the links the edges
+// of src point to, or used to point to, are then updated.
+func (c *Crawler) replaceEdges(ctx context.Context, src *domain.Link, edges []*domain.Edge, fetchedAt time.Time) error {
+	affected, err := c.outEdgeTargets(ctx, src.ID)
+	if err != nil {
+		return err
+	}
+	for _, edge := range edges {
+		affected[edge.Dst] = struct{}{}
+	}
+
+	if len(edges) != 0 {
+		if err = c.cfg.Graph.UpsertEdges(ctx, edges); err != nil {
+			return err
+		}
+	}
+	if err = c.cfg.Graph.RemoveStaleEdges(ctx, src.ID, fetchedAt); err != nil {
+		return err
+	}
+
+	delete(affected, src.ID)
+	for id := range affected {
+		if err = c.anchors.Update(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// outEdgeTargets returns the set of links the edges of id point to.
+func (c *Crawler) outEdgeTargets(ctx context.Context, id uuid.UUID) (map[uuid.UUID]struct{}, error) {
+	it, err := c.cfg.Graph.OutEdges(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = it.Close() }()
+
+	targets := make(map[uuid.UUID]struct{})
+	for it.Next() {
+		targets[it.Edge().Dst] = struct{}{}
+	}
+	return targets, it.Error()
+}
+
+// index stores the contents of p as the document of the canonical link of
+// link, unless that document already holds the same title and text.
+func (c *Crawler) index(ctx context.Context, link *domain.Link, p *page) error {
+	existing, err := c.cfg.Indexer.FindByID(ctx, link.CanonicalLinkID())
+	switch {
+	case err == nil && !existing.IndexedAt.IsZero() && existing.Title == p.title && existing.Content == p.content:
+		return nil
+	case err != nil && !errors.Is(err, ports.TextIndexerErrNotFound):
+		return fmt.Errorf("index: %w", err)
+	}
+
+	doc := &domain.Document{
+		LinkID:  link.ID,
+		URL:     link.URL,
+		Title:   p.title,
+		Content: p.content,
+	}
+	if err = c.indexer.Index(ctx, doc); err != nil {
+		return fmt.Errorf("index: %w", err)
+	}
+	return nil
+}
+
+// withoutFragment returns u as a string, without its fragment.
+func withoutFragment(u *url.URL) string {
+	stripped := *u
+	stripped.Fragment = ""
+	stripped.RawFragment = ""
+	return stripped.String()
+}
+
+// isHTML reports whether contentType denotes an HTML document. Responses
+// without a content type are assumed to be HTML.
+func isHTML(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "text/html" || mediaType == "application/xhtml+xml")
+}
@@ -0,0 +1,449 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/repository/memory"
+	indexmemory "github.com/bruceneco/links-r-us/internal/adapters/textindexer/store/memory"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/partition"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// site is a test web server whose pages can be changed between crawls.
+type site struct {
+	*httptest.Server
+	mu    sync.Mutex
+	pages map[string]func(w http.ResponseWriter, r *http.Request)
+}
+
+func newSite(t *testing.T) *site {
+	s := &site{pages: make(map[string]func(w http.ResponseWriter, r *http.Request))}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		handler := s.pages[r.URL.Path]
+		s.mu.Unlock()
+		if handler == nil {
+			http.NotFound(w, r)
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *site) handle(path string, handler func(w http.ResponseWriter, r *http.Request)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pages[path] = handler
+}
+
+func (s *site) html(path, body string) {
+	s.handle(path, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = fmt.Fprint(w, body)
+	})
+}
+
+type deps struct {
+	graph   repository.GraphRepository
+	indexer ports.TextIndexer
+	crawler *Crawler
+}
+
+func newDeps(t *testing.T) *deps {
+	indexer, err := indexmemory.NewInMemoryIndexer()
+	require.NoError(t, err)
+	d := &deps{graph: memory.NewInMemoryGraph(), indexer: indexer}
+	d.crawler, err = NewCrawler(Config{Graph: d.graph, Indexer: d.indexer})
+	require.NoError(t, err)
+	return d
+}
+
+func (d *deps) upsertLink(t *testing.T, url string) *domain.Link {
+	link := &domain.Link{URL: url}
+	require.NoError(t, d.graph.UpsertLink(context.TODO(), link))
+	return link
+}
+
+func (d *deps) findLink(t *testing.T, url string) *domain.Link {
+	link, err := d.graph.FindLinkByURL(context.TODO(), url)
+	require.NoError(t, err, url)
+	return link
+}
+
+func (d *deps) outEdges(t *testing.T, id uuid.UUID) []*domain.Edge {
+	it, err := d.graph.OutEdges(context.TODO(), id)
+	require.NoError(t, err)
+	var edges []*domain.Edge
+	for it.Next() {
+		edges = append(edges, it.Edge())
+	}
+	require.NoError(t, it.Error())
+	require.NoError(t, it.Close())
+	return edges
+}
+
+func (d *deps) anchorText(t *testing.T, id uuid.UUID) []string {
+	doc, err := d.indexer.FindByID(context.TODO(), id)
+	require.NoError(t, err)
+	return doc.AnchorText
+}
+
+func TestCrawlLink(t *testing.T) {
+	ctx := context.TODO()
+	s := newSite(t)
+	s.html("/", `<html><head><title>Home</title></head><body>
+		<p>Welcome home.</p>
+		<a href="/about">About us</a>
+		<a href="/ads" rel="sponsored">Buy now</a>
+		<a href="/">Home</a>
+	</body></html>`)
+	d := newDeps(t)
+
+	home := d.upsertLink(t, s.URL+"/")
+	require.NoError(t, d.crawler.CrawlLink(ctx, home))
+
+	stored := d.findLink(t, home.URL)
+	assert.Equal(t, http.StatusOK, stored.StatusCode)
+	assert.Equal(t, "text/html; charset=utf-8", stored.ContentType)
+	assert.Equal(t, `"v1"`, stored.ETag)
+	assert.NotZero(t, stored.ContentLength)
+	assert.False(t, stored.RetrievedAt.IsZero())
+
+	about := d.findLink(t, s.URL+"/about")
+	ads := d.findLink(t, s.URL+"/ads")
+	assert.True(t, about.RetrievedAt.IsZero(), "expected discovered links not to be fetched yet")
+
+	edges := make(map[uuid.UUID]*domain.Edge)
+	for _, edge := range d.outEdges(t, home.ID) {
+		edges[edge.Dst] = edge
+	}
+	require.Len(t, edges, 2, "expected the edge to the page itself to be skipped")
+	assert.Equal(t, "About us", edges[about.ID].AnchorText)
+	assert.Equal(t, domain.EdgeRelSponsored, edges[ads.ID].Rel)
+	assert.Equal(t, []string{"About us"}, d.anchorText(t, about.ID))
+
+	doc, err := d.indexer.FindByID(ctx, home.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Home", doc.Title)
+	assert.Equal(t, "Welcome home. About us Buy now Home", doc.Content)
+	assert.Equal(t, home.URL, doc.URL)
+}
+
+func TestCrawlLinkRemovesStaleEdges(t *testing.T) {
+	ctx := context.TODO()
+	s := newSite(t)
+	s.html("/", `<a href="/a">A</a><a href="/b">B</a>`)
+	d := newDeps(t)
+
+	home := d.upsertLink(t, s.URL+"/")
+	require.NoError(t, d.crawler.CrawlLink(ctx, home))
+	require.Len(t, d.outEdges(t, home.ID), 2)
+	a := d.findLink(t, s.URL+"/a")
+	assert.Equal(t, []string{"A"}, d.anchorText(t, a.ID))
+
+	s.html("/", `<a href="/b">B</a>`)
+	require.NoError(t, d.crawler.CrawlLink(ctx, d.findLink(t, home.URL)))
+
+	edges := d.outEdges(t, home.ID)
+	require.Len(t, edges, 1)
+	assert.Equal(t, d.findLink(t, s.URL+"/b").ID, edges[0].Dst)
+	assert.Empty(t, d.anchorText(t, a.ID), "expected the anchor text of the removed edge to be dropped")
+	assert.Equal(t, []string{"B"}, d.anchorText(t, edges[0].Dst))
+}
+
+func TestCrawlLinkFailure(t *testing.T) {
+	ctx := context.TODO()
+	s := newSite(t)
+	d := newDeps(t)
+
+	missing := d.upsertLink(t, s.URL+"/missing")
+	require.NoError(t, d.crawler.CrawlLink(ctx, missing))
+	require.NoError(t, d.crawler.CrawlLink(ctx, d.findLink(t, missing.URL)))
+
+	stored := d.findLink(t, missing.URL)
+	assert.Equal(t, http.StatusNotFound, stored.StatusCode)
+	assert.Equal(t, 2, stored.FailureCount)
+	assert.Contains(t, stored.LastError, "404")
+
+	_, err := d.indexer.FindByID(ctx, missing.ID)
+	assert.ErrorIs(t, err, ports.TextIndexerErrNotFound)
+}
+
+func TestCrawlLinkNonHTML(t *testing.T) {
+	ctx := context.TODO()
+	s := newSite(t)
+	s.handle("/report.pdf", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = fmt.Fprint(w, "%PDF-1.4 <a href=\"/hidden\">")
+	})
+	d := newDeps(t)
+
+	report := d.upsertLink(t, s.URL+"/report.pdf")
+	require.NoError(t, d.crawler.CrawlLink(ctx, report))
+
+	stored := d.findLink(t, report.URL)
+	assert.Equal(t, "application/pdf", stored.ContentType)
+	assert.Empty(t, d.outEdges(t, report.ID))
+	_, err := d.indexer.FindByID(ctx, report.ID)
+	assert.ErrorIs(t, err, ports.TextIndexerErrNotFound)
+}
+
+func TestCrawlLinkRedirect(t *testing.T) {
+	ctx := context.TODO()
+	s := newSite(t)
+	s.handle("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/older", http.StatusMovedPermanently)
+	})
+	s.handle("/older", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new#top", http.StatusFound)
+	})
+	s.html("/new", `<title>New</title><a href="/other">Other</a>`)
+	d := newDeps(t)
+
+	old := d.upsertLink(t, s.URL+"/old")
+	require.NoError(t, d.crawler.CrawlLink(ctx, old))
+
+	newLink := d.findLink(t, s.URL+"/new")
+	assert.Equal(t, http.StatusOK, newLink.StatusCode)
+	assert.False(t, newLink.RetrievedAt.IsZero(), "expected the final link to be recorded as fetched")
+	doc, err := d.indexer.FindByID(ctx, newLink.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "New", doc.Title)
+	require.Len(t, d.outEdges(t, newLink.ID), 1)
+
+	for url, status := range map[string]int{s.URL + "/old": http.StatusMovedPermanently, s.URL + "/older": http.StatusFound} {
+		hop := d.findLink(t, url)
+		assert.Equal(t, status, hop.StatusCode, url)
+		assert.False(t, hop.RetrievedAt.IsZero(), url)
+
+		edges := d.outEdges(t, hop.ID)
+		require.Len(t, edges, 1, url)
+		assert.Equal(t, domain.EdgeKindRedirect, edges[0].Kind, url)
+		assert.Equal(t, newLink.ID, edges[0].Dst, url)
+
+		_, err = d.indexer.FindByID(ctx, hop.ID)
+		assert.ErrorIs(t, err, ports.TextIndexerErrNotFound, "expected no document for redirecting link %s", url)
+	}
+}
+
+func TestCrawlLinkMetaRefresh(t *testing.T) {
+	ctx := context.TODO()
+	s := newSite(t)
+	s.html("/", `<a href="/a">A</a>`)
+	d := newDeps(t)
+
+	home := d.upsertLink(t, s.URL+"/")
+	require.NoError(t, d.crawler.CrawlLink(ctx, home))
+
+	s.html("/", `<html><head><meta http-equiv="refresh" content="0; url=/moved"></head>
+		<body>Redirecting to <a href="/moved">the new home</a></body></html>`)
+	require.NoError(t, d.crawler.CrawlLink(ctx, d.findLink(t, home.URL)))
+
+	moved := d.findLink(t, s.URL+"/moved")
+	assert.True(t, moved.RetrievedAt.IsZero(), "expected the refresh target to be left to a later pass")
+
+	edges := d.outEdges(t, home.ID)
+	require.Len(t, edges, 1)
+	assert.Equal(t, domain.EdgeKindRedirect, edges[0].Kind)
+	assert.Equal(t, moved.ID, edges[0].Dst)
+
+	_, err := d.indexer.FindByID(ctx, moved.ID)
+	assert.ErrorIs(t, err, ports.TextIndexerErrNotFound, "expected no anchor text to be indexed for redirect edges")
+	assert.Empty(t, d.anchorText(t, d.findLink(t, s.URL+"/a").ID))
+}
+
+func TestCrawlLinkCanonical(t *testing.T) {
+	ctx := context.TODO()
+	s := newSite(t)
+	s.html("/article", `<head><title>Article</title><link rel="canonical" href="/posts/1"></head>
+		<body><a href="/related">Related</a></body>`)
+	d := newDeps(t)
+
+	dup := d.upsertLink(t, s.URL+"/article")
+	require.NoError(t, d.crawler.CrawlLink(ctx, dup))
+
+	canonicalLink := d.findLink(t, s.URL+"/posts/1")
+	assert.Equal(t, canonicalLink.ID, d.findLink(t, dup.URL).CanonicalID)
+
+	doc, err := d.indexer.FindByID(ctx, canonicalLink.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Article", doc.Title)
+	assert.Equal(t, canonicalLink.URL, doc.URL)
+	_, err = d.indexer.FindByID(ctx, dup.ID)
+	assert.ErrorIs(t, err, ports.TextIndexerErrNotFound, "expected nothing to be indexed under the duplicate")
+
+	assert.Empty(t, d.outEdges(t, dup.ID))
+	edges := d.outEdges(t, canonicalLink.ID)
+	require.Len(t, edges, 1)
+	assert.Equal(t, d.findLink(t, s.URL+"/related").ID, edges[0].Dst)
+
+	// Dropping the canonical element makes the link canonical again.
+	s.html("/article", `<title>Article</title>`)
+	require.NoError(t, d.crawler.CrawlLink(ctx, d.findLink(t, dup.URL)))
+	assert.Equal(t, uuid.Nil, d.findLink(t, dup.URL).CanonicalID)
+	_, err = d.indexer.FindByID(ctx, dup.ID)
+	assert.NoError(t, err)
+}
+
+func TestCrawlLinkNotModified(t *testing.T) {
+	ctx := context.TODO()
+	s := newSite(t)
+	var conditional http.Header
+	s.handle("/", func(w http.ResponseWriter, r *http.Request) {
+		conditional = r.Header.Clone()
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		_, _ = fmt.Fprint(w, `<title>Home</title><a href="/a">A</a>`)
+	})
+	d := newDeps(t)
+
+	home := d.upsertLink(t, s.URL+"/")
+	require.NoError(t, d.crawler.CrawlLink(ctx, home))
+	assert.Empty(t, conditional.Get("If-None-Match"), "expected the first request not to be conditional")
+	first := d.findLink(t, home.URL)
+	edgesBefore := d.outEdges(t, home.ID)
+	require.Len(t, edgesBefore, 1)
+	docBefore, err := d.indexer.FindByID(ctx, home.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, d.crawler.CrawlLink(ctx, first))
+	assert.Equal(t, `"v1"`, conditional.Get("If-None-Match"))
+	assert.Equal(t, "Wed, 21 Oct 2015 07:28:00 GMT", conditional.Get("If-Modified-Since"))
+
+	second := d.findLink(t, home.URL)
+	assert.True(t, second.RetrievedAt.After(first.RetrievedAt), "expected RetrievedAt to be bumped")
+	assert.Equal(t, http.StatusOK, second.StatusCode, "expected the metadata of the full response to be kept")
+	assert.Equal(t, `"v1"`, second.ETag)
+
+	edgesAfter := d.outEdges(t, home.ID)
+	require.Len(t, edgesAfter, 1, "expected the edges to be kept")
+	assert.True(t, edgesAfter[0].UpdatedAt.After(edgesBefore[0].UpdatedAt), "expected the edges to be refreshed")
+
+	docAfter, err := d.indexer.FindByID(ctx, home.ID)
+	require.NoError(t, err)
+	assert.Equal(t, docBefore.IndexedAt, docAfter.IndexedAt, "expected the page not to be indexed again")
+}
+
+func TestCrawlLinkReindexOnlyOnChange(t *testing.T) {
+	ctx := context.TODO()
+	s := newSite(t)
+	s.html("/", `<title>Home</title><p>Hello</p>`)
+	d := newDeps(t)
+
+	home := d.upsertLink(t, s.URL+"/")
+	require.NoError(t, d.crawler.CrawlLink(ctx, home))
+	first, err := d.indexer.FindByID(ctx, home.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, d.crawler.CrawlLink(ctx, d.findLink(t, home.URL)))
+	unchanged, err := d.indexer.FindByID(ctx, home.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first.IndexedAt, unchanged.IndexedAt, "expected an unchanged page not to be indexed again")
+
+	s.html("/", `<title>Home</title><p>Hello again</p>`)
+	require.NoError(t, d.crawler.CrawlLink(ctx, d.findLink(t, home.URL)))
+	changed, err := d.indexer.FindByID(ctx, home.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello again", changed.Content)
+	assert.True(t, changed.IndexedAt.After(first.IndexedAt), "expected a changed page to be indexed again")
+}
+
+func TestCrawl(t *testing.T) {
+	ctx := context.TODO()
+	s := newSite(t)
+	s.html("/", `<a href="/a">A</a>`)
+	s.html("/a", `<a href="/">Home</a>`)
+	d := newDeps(t)
+	d.upsertLink(t, s.URL+"/")
+
+	n, err := d.crawler.Crawl(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// The link discovered by the first pass is crawled by the second one,
+	// while the link fetched by the first pass is not due yet.
+	n, err = d.crawler.Crawl(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.False(t, d.findLink(t, s.URL+"/a").RetrievedAt.IsZero())
+
+	n, err = d.crawler.Crawl(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestCrawlPartitioned(t *testing.T) {
+	ctx := context.TODO()
+	s := newSite(t)
+	d := newDeps(t)
+	for i := 0; i < 10; i++ {
+		path := fmt.Sprintf("/%d", i)
+		s.html(path, "<title>Page</title>")
+		d.upsertLink(t, s.URL+path)
+	}
+
+	// Each instance crawls its own share of the links.
+	var total int
+	for p := 0; p < 2; p++ {
+		c, err := NewCrawler(Config{
+			Graph:             d.graph,
+			Indexer:           d.indexer,
+			PartitionDetector: partition.Fixed{Partition: p, NumPartitions: 2},
+		})
+		require.NoError(t, err)
+		n, err := c.Crawl(ctx)
+		require.NoError(t, err)
+		total += n
+	}
+	assert.Equal(t, 10, total)
+
+	n, err := d.crawler.Crawl(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n, "expected every link to be crawled by one of the partitions")
+}
+
+type noPartitionData struct{}
+
+func (noPartitionData) PartitionInfo(context.Context) (int, int, error) {
+	return -1, -1, partition.ErrNoPartitionDataAvailableYet
+}
+
+func TestCrawlNoPartitionData(t *testing.T) {
+	d := newDeps(t)
+	d.upsertLink(t, "https://example.com/")
+	c, err := NewCrawler(Config{Graph: d.graph, Indexer: d.indexer, PartitionDetector: noPartitionData{}})
+	require.NoError(t, err)
+
+	_, err = c.Crawl(context.TODO())
+	assert.ErrorIs(t, err, partition.ErrNoPartitionDataAvailableYet)
+}
+
+func TestCrawlCancelled(t *testing.T) {
+	s := newSite(t)
+	d := newDeps(t)
+	d.upsertLink(t, s.URL+"/")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	_, err := d.crawler.Crawl(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
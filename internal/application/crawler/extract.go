@@ -0,0 +1,187 @@
+package crawler
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// page holds what the crawler extracts from an HTML document.
+type page struct {
+	title   string
+	content string
+	links   []pageLink
+	// refresh is the URL a <meta http-equiv="refresh"> element redirects
+	// to, if the page has one.
+	refresh string
+	// canonical is the URL of the page declared by a
+	// <link rel="canonical"> element, if the page has one.
+	canonical string
+}
+
+// pageLink is an anchor found in a page.
+type pageLink struct {
+	url        string
+	anchorText string
+	rel        domain.EdgeRel
+}
+
+// skippedElements are the elements whose text is not part of the content of
+// a page.
+var skippedElements = map[atom.Atom]bool{
+	atom.Head:     true,
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+}
+
+// extract parses the HTML document read from r, which was served from base.
+// Anchor URLs are resolved against base, or against the href of a <base>
+// element if the document has one. Only the first anchor pointing to each
+// http or https URL is kept.
+func extract(base *url.URL, r io.Reader) (*page, error) {
+	root, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("parse html: %w", err)
+	}
+
+	var (
+		p       page
+		content strings.Builder
+		seen    = make(map[string]bool)
+	)
+	// Elements below a skipped element are still visited, as <head> holds
+	// the <title> and <base> elements, but their text is left out.
+	var walk func(n *html.Node, skipped bool)
+	walk = func(n *html.Node, skipped bool) {
+		if n.Type == html.ElementNode {
+			switch n.DataAtom {
+			case atom.Title:
+				if p.title == "" {
+					p.title = collapse(text(n))
+				}
+				return
+			case atom.Meta:
+				if equiv, _ := attr(n, "http-equiv"); p.refresh == "" && strings.EqualFold(equiv, "refresh") {
+					content, _ := attr(n, "content")
+					p.refresh = refreshURL(base, content)
+				}
+			case atom.Link:
+				rel, _ := attr(n, "rel")
+				href, ok := attr(n, "href")
+				if ok && p.canonical == "" && slices.Contains(strings.Fields(strings.ToLower(rel)), "canonical") {
+					p.canonical, _ = resolve(base, href)
+				}
+			case atom.Base:
+				if href, ok := attr(n, "href"); ok {
+					if u, err := base.Parse(href); err == nil {
+						base = u
+					}
+				}
+			case atom.A:
+				href, ok := attr(n, "href")
+				if !ok {
+					break
+				}
+				target, ok := resolve(base, href)
+				if !ok || seen[target] {
+					break
+				}
+				seen[target] = true
+				relAttr, _ := attr(n, "rel")
+				p.links = append(p.links, pageLink{
+					url:        target,
+					anchorText: collapse(text(n)),
+					rel:        domain.ParseEdgeRel(relAttr),
+				})
+			}
+		}
+		if n.Type == html.TextNode && !skipped {
+			content.WriteString(n.Data)
+			content.WriteByte(' ')
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, skipped || (c.Type == html.ElementNode && skippedElements[c.DataAtom]))
+		}
+	}
+	walk(root, false)
+
+	p.content = collapse(content.String())
+	return &p, nil
+}
+
+// resolve returns the absolute form of href relative to base, without its
+// fragment. It reports false for URLs the crawler cannot fetch.
+func resolve(base *url.URL, href string) (string, bool) {
+	u, err := base.Parse(strings.TrimSpace(href))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", false
+	}
+	return withoutFragment(u), true
+}
+
+// refreshURL returns the absolute URL the content of a meta refresh element
+// redirects to, such as "0; url=/moved". It returns an empty string if the
+// element only reloads the page or the URL cannot be fetched.
+func refreshURL(base *url.URL, content string) string {
+	_, target, ok := strings.Cut(content, ";")
+	if !ok {
+		if _, target, ok = strings.Cut(content, ","); !ok {
+			return ""
+		}
+	}
+	target = strings.TrimSpace(target)
+	if len(target) < 4 || !strings.EqualFold(target[:3], "url") {
+		return ""
+	}
+	target = strings.TrimSpace(target[3:])
+	if !strings.HasPrefix(target, "=") {
+		return ""
+	}
+	target = strings.Trim(strings.TrimSpace(target[1:]), `"'`)
+	if target == "" {
+		return ""
+	}
+	resolved, _ := resolve(base, target)
+	return resolved
+}
+
+// attr returns the value of the attribute key of n.
+func attr(n *html.Node, key string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Namespace == "" && strings.EqualFold(a.Key, key) {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+// text returns the concatenated text of the nodes below n.
+func text(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+			sb.WriteByte(' ')
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return sb.String()
+}
+
+// collapse trims s and replaces each run of white space in it with a single
+// space.
+func collapse(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
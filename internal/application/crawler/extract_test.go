@@ -0,0 +1,98 @@
+package crawler
+
+import (
+	"html"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	base, err := url.Parse("https://example.com/docs/index.html")
+	require.NoError(t, err)
+
+	doc := `<!DOCTYPE html>
+<html>
+<head>
+	<title>  Example
+	Docs </title>
+	<style>body { color: red; }</style>
+</head>
+<body>
+	<h1>Welcome</h1>
+	<script>var hidden = "not content";</script>
+	<p>Read the <a href="guide.html">user   guide</a> or the <a href="/api#v2">API</a>.</p>
+	<a href="guide.html#install">Installing</a>
+	<a href="https://other.example/" rel="nofollow ugc">Elsewhere</a>
+	<a href="mailto:docs@example.com">Mail us</a>
+	<a href="javascript:void(0)">Nothing</a>
+	<a>No href</a>
+</body>
+</html>`
+
+	p, err := extract(base, strings.NewReader(doc))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Example Docs", p.title)
+	assert.Equal(t, "Welcome Read the user guide or the API . Installing Elsewhere Mail us Nothing No href", p.content)
+	assert.Equal(t, []pageLink{
+		{url: "https://example.com/docs/guide.html", anchorText: "user guide"},
+		{url: "https://example.com/api", anchorText: "API"},
+		{url: "https://other.example/", anchorText: "Elsewhere", rel: domain.EdgeRelNoFollow | domain.EdgeRelUGC},
+	}, p.links)
+}
+
+func TestExtractBaseElement(t *testing.T) {
+	base, err := url.Parse("https://example.com/a/b.html")
+	require.NoError(t, err)
+
+	doc := `<html><head><base href="https://cdn.example.com/root/"></head>
+<body><a href="page.html">Page</a></body></html>`
+
+	p, err := extract(base, strings.NewReader(doc))
+	require.NoError(t, err)
+	assert.Equal(t, []pageLink{{url: "https://cdn.example.com/root/page.html", anchorText: "Page"}}, p.links)
+}
+
+func TestExtractMetaRefresh(t *testing.T) {
+	base, err := url.Parse("https://example.com/old/page.html")
+	require.NoError(t, err)
+
+	specs := []struct {
+		content string
+		exp     string
+	}{
+		{content: "0; url=/new", exp: "https://example.com/new"},
+		{content: "5;URL='https://other.example/#top'", exp: "https://other.example/"},
+		{content: `0, url = "moved.html"`, exp: "https://example.com/old/moved.html"},
+		{content: "30"},
+		{content: "0; url=javascript:void(0)"},
+		{content: "0; refresh=/nowhere"},
+	}
+	for _, spec := range specs {
+		doc := `<html><head><meta http-equiv="Refresh" content="` + html.EscapeString(spec.content) + `"></head></html>`
+		p, err := extract(base, strings.NewReader(doc))
+		require.NoError(t, err, spec.content)
+		assert.Equal(t, spec.exp, p.refresh, spec.content)
+	}
+}
+
+func TestExtractCanonical(t *testing.T) {
+	base, err := url.Parse("https://example.com/products?id=42&ref=ad")
+	require.NoError(t, err)
+
+	doc := `<html><head>
+	<link rel="stylesheet" href="/style.css">
+	<link rel="Canonical" href="/products/42#details">
+	<link rel="canonical" href="/ignored">
+</head><body></body></html>`
+
+	p, err := extract(base, strings.NewReader(doc))
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/products/42", p.canonical)
+	assert.Empty(t, p.links, "expected link elements not to be treated as anchors")
+}
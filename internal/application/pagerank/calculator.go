@@ -0,0 +1,315 @@
+// Package pagerank periodically computes the PageRank score of every link in
+// the graph and stores it in the text indexer, where it is used to rank
+// search results.
+package pagerank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultDampingFactor = 0.85
+	defaultTolerance     = 1e-6
+	defaultMaxIterations = 100
+	defaultInterval      = time.Hour
+)
+
+// Config holds the dependencies and settings of a Calculator. Zero values
+// are replaced with sensible defaults.
+type Config struct {
+	// Graph is the link graph the scores are computed from.
+	Graph repository.GraphRepository
+	// Indexer is where the computed scores are stored.
+	Indexer ports.TextIndexer
+
+	// DampingFactor is the probability of following an outgoing edge
+	// rather than jumping to a random link.
+	DampingFactor float64
+	// Tolerance is the total score change below which the scores are
+	// considered to have converged.
+	Tolerance float64
+	// MaxIterations bounds the number of iterations of a single pass.
+	MaxIterations int
+	// Interval is the time Run waits between passes.
+	Interval time.Duration
+	// IgnoreNoFollow leaves out the edges marked with
+	// domain.EdgeRelNoFollow, so pages do not pass score to links they do
+	// not endorse.
+	IgnoreNoFollow bool
+
+	// Logger receives the outcome of each pass run by Run.
+	Logger *slog.Logger
+}
+
+func (cfg *Config) validate() error {
+	var err error
+	if cfg.Graph == nil {
+		err = errors.Join(err, errors.New("graph not provided"))
+	}
+	if cfg.Indexer == nil {
+		err = errors.Join(err, errors.New("indexer not provided"))
+	}
+	if cfg.DampingFactor < 0 || cfg.DampingFactor >= 1 {
+		err = errors.Join(err, fmt.Errorf("damping factor %v outside [0, 1)", cfg.DampingFactor))
+	}
+	if cfg.DampingFactor == 0 {
+		cfg.DampingFactor = defaultDampingFactor
+	}
+	if cfg.Tolerance <= 0 {
+		cfg.Tolerance = defaultTolerance
+	}
+	if cfg.MaxIterations <= 0 {
+		cfg.MaxIterations = defaultMaxIterations
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultInterval
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	return err
+}
+
+// Calculator computes PageRank scores for the links of a graph.
+type Calculator struct {
+	cfg Config
+}
+
+// NewCalculator creates a Calculator using cfg.
+func NewCalculator(cfg Config) (*Calculator, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("pagerank: config validation failed: %w", err)
+	}
+	return &Calculator{cfg: cfg}, nil
+}
+
+// Run updates the scores every cfg.Interval until ctx is cancelled. Failed
+// passes are logged and retried on the next tick.
+func (c *Calculator) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		start := time.Now()
+		if err := c.Update(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			c.cfg.Logger.Error("pagerank pass failed", "err", err)
+		} else {
+			c.cfg.Logger.Info("pagerank pass completed", "took", time.Since(start))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Update computes the score of every canonical link in the graph that does
+// not redirect and stores it in the indexer.
+func (c *Calculator) Update(ctx context.Context) error {
+	g, err := c.load(ctx)
+	if err != nil {
+		return fmt.Errorf("update scores: %w", err)
+	}
+
+	scores := g.scores(c.cfg.DampingFactor, c.cfg.Tolerance, c.cfg.MaxIterations)
+	for v, id := range g.ids {
+		if err = c.cfg.Indexer.UpdateScore(ctx, id, scores[v]); err != nil {
+			return fmt.Errorf("update scores: %w", err)
+		}
+	}
+	return nil
+}
+
+// load reads the links and edges of the graph into memory. Links that
+// duplicate another link, or redirect to another link, are left out, and
+// the edges pointing to them are attributed to their canonical link or to
+// the final link of the redirect chain instead, which transfers their score.
+func (c *Calculator) load(ctx context.Context) (*graph, error) {
+	now := time.Now()
+
+	var canonical, duplicates []*domain.Link
+	links, err := c.cfg.Graph.Links(ctx, repository.FullIDRange(), now)
+	if err != nil {
+		return nil, fmt.Errorf("load links: %w", err)
+	}
+	for links.Next() {
+		link := links.Link()
+		if link.CanonicalID != uuid.Nil {
+			duplicates = append(duplicates, link)
+		} else {
+			canonical = append(canonical, link)
+		}
+	}
+	if err = closeIterator(links); err != nil {
+		return nil, fmt.Errorf("load links: %w", err)
+	}
+
+	var edgeList []*domain.Edge
+	redirects := make(map[uuid.UUID]uuid.UUID)
+	edges, err := c.cfg.Graph.Edges(ctx, repository.FullIDRange(), now)
+	if err != nil {
+		return nil, fmt.Errorf("load edges: %w", err)
+	}
+	for edges.Next() {
+		edge := edges.Edge()
+		switch {
+		case edge.Kind == domain.EdgeKindRedirect:
+			redirects[edge.Src] = edge.Dst
+		case c.cfg.IgnoreNoFollow && edge.Rel.Has(domain.EdgeRelNoFollow):
+		default:
+			edgeList = append(edgeList, edge)
+		}
+	}
+	if err = closeIterator(edges); err != nil {
+		return nil, fmt.Errorf("load edges: %w", err)
+	}
+
+	g := &graph{nodes: make(map[uuid.UUID]int)}
+	for _, link := range canonical {
+		if _, ok := finalTarget(redirects, link.ID); !ok {
+			g.addNode(link.ID)
+		}
+	}
+	for _, link := range duplicates {
+		target := link.CanonicalID
+		if final, ok := finalTarget(redirects, target); ok {
+			target = final
+		}
+		g.alias(link.ID, target)
+	}
+	// Redirects are aliased last, as they may point to a duplicate.
+	for _, link := range canonical {
+		if target, ok := finalTarget(redirects, link.ID); ok {
+			g.alias(link.ID, target)
+		}
+	}
+	for _, edge := range edgeList {
+		g.addEdge(edge)
+	}
+	return g, nil
+}
+
+// finalTarget follows the redirects starting at id and returns the link the
+// chain ends at. It reports false if id does not redirect or the chain
+// loops, in which case the links of the loop are ranked on their own.
+func finalTarget(redirects map[uuid.UUID]uuid.UUID, id uuid.UUID) (uuid.UUID, bool) {
+	target, ok := redirects[id]
+	if !ok {
+		return uuid.Nil, false
+	}
+	seen := map[uuid.UUID]bool{id: true}
+	for !seen[target] {
+		seen[target] = true
+		next, ok := redirects[target]
+		if !ok {
+			return target, true
+		}
+		target = next
+	}
+	return uuid.Nil, false
+}
+
+// closeIterator closes it and returns the first error it encountered.
+func closeIterator(it repository.Iterator) error {
+	err := it.Error()
+	if closeErr := it.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// graph is the in-memory adjacency list PageRank is computed on. Nodes are
+// numbered in the order their links were loaded.
+type graph struct {
+	ids   []uuid.UUID
+	nodes map[uuid.UUID]int
+	out   [][]int
+}
+
+func (g *graph) addNode(id uuid.UUID) {
+	g.nodes[id] = len(g.ids)
+	g.ids = append(g.ids, id)
+	g.out = append(g.out, nil)
+}
+
+// alias makes the edges of id count as edges of the node of target, if
+// target is part of the graph.
+func (g *graph) alias(id, target uuid.UUID) {
+	if v, ok := g.nodes[target]; ok {
+		g.nodes[id] = v
+	}
+}
+
+// addEdge records edge unless it loops back to its source or references a
+// link that is not part of the graph.
+func (g *graph) addEdge(edge *domain.Edge) {
+	src, srcOK := g.nodes[edge.Src]
+	dst, dstOK := g.nodes[edge.Dst]
+	if !srcOK || !dstOK || src == dst {
+		return
+	}
+	g.out[src] = append(g.out[src], dst)
+}
+
+// scores runs the power iteration until the total change of the scores
+// drops below tolerance or maxIterations is reached. The scores of dangling
+// nodes, which have no outgoing edges, are spread evenly over every node, so
+// the scores always add up to 1.
+func (g *graph) scores(dampingFactor, tolerance float64, maxIterations int) []float64 {
+	n := len(g.ids)
+	if n == 0 {
+		return nil
+	}
+
+	rank := make([]float64, n)
+	for v := range rank {
+		rank[v] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+	for iter := 0; iter < maxIterations; iter++ {
+		var dangling float64
+		for v, out := range g.out {
+			if len(out) == 0 {
+				dangling += rank[v]
+			}
+		}
+		base := (1-dampingFactor)/float64(n) + dampingFactor*dangling/float64(n)
+		for v := range next {
+			next[v] = base
+		}
+		for v, out := range g.out {
+			if len(out) == 0 {
+				continue
+			}
+			share := dampingFactor * rank[v] / float64(len(out))
+			for _, w := range out {
+				next[w] += share
+			}
+		}
+
+		var delta float64
+		for v := range rank {
+			delta += math.Abs(next[v] - rank[v])
+		}
+		rank, next = next, rank
+		if delta < tolerance {
+			break
+		}
+	}
+	return rank
+}
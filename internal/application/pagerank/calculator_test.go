@@ -0,0 +1,178 @@
+package pagerank
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bruceneco/links-r-us/internal/adapters/graph/repository/memory"
+	indexmemory "github.com/bruceneco/links-r-us/internal/adapters/textindexer/store/memory"
+	"github.com/bruceneco/links-r-us/internal/application/core/domain"
+	"github.com/bruceneco/links-r-us/internal/ports"
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoresCycle(t *testing.T) {
+	g := newTestGraph(3)
+	g.out[0] = []int{1}
+	g.out[1] = []int{2}
+	g.out[2] = []int{0}
+
+	for _, score := range g.scores(defaultDampingFactor, defaultTolerance, defaultMaxIterations) {
+		assert.InDelta(t, 1.0/3, score, 1e-6)
+	}
+}
+
+func TestScoresDangling(t *testing.T) {
+	g := newTestGraph(3)
+	g.out[0] = []int{2}
+	g.out[1] = []int{2}
+
+	scores := g.scores(defaultDampingFactor, defaultTolerance, defaultMaxIterations)
+	assert.InDelta(t, 1.0, scores[0]+scores[1]+scores[2], 1e-6, "expected scores to add up to 1")
+	assert.InDelta(t, scores[0], scores[1], 1e-9)
+	assert.Greater(t, scores[2], scores[0])
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.TODO()
+	g, indexer := newDeps(t)
+
+	a := upsertLink(t, g, "https://example.com/a")
+	b := upsertLink(t, g, "https://example.com/b")
+	c := upsertLink(t, g, "https://example.com/c")
+	dup := upsertLink(t, g, "https://example.com/c?ref=a")
+	require.NoError(t, g.MergeLink(ctx, dup.ID, c.ID))
+
+	upsertEdge(t, g, a.ID, b.ID)
+	upsertEdge(t, g, b.ID, c.ID)
+	upsertEdge(t, g, c.ID, a.ID)
+	upsertEdge(t, g, a.ID, dup.ID)
+
+	calc, err := NewCalculator(Config{Graph: g, Indexer: indexer})
+	require.NoError(t, err)
+	require.NoError(t, calc.Update(ctx))
+
+	scores := make(map[uuid.UUID]float64)
+	for _, link := range []*domain.Link{a, b, c} {
+		doc, err := indexer.FindByID(ctx, link.ID)
+		require.NoError(t, err)
+		scores[link.ID] = doc.PageRank
+	}
+	assert.InDelta(t, 1.0, scores[a.ID]+scores[b.ID]+scores[c.ID], 1e-6, "expected scores to add up to 1")
+	assert.Greater(t, scores[c.ID], scores[b.ID], "expected the edge to the duplicate to count towards the canonical link")
+
+	_, err = indexer.FindByID(ctx, dup.ID)
+	assert.True(t, errors.Is(err, ports.TextIndexerErrNotFound), "expected no score for the duplicate link")
+}
+
+func TestUpdateIgnoreNoFollow(t *testing.T) {
+	ctx := context.TODO()
+	g, indexer := newDeps(t)
+
+	a := upsertLink(t, g, "https://example.com/a")
+	b := upsertLink(t, g, "https://example.com/b")
+	c := upsertLink(t, g, "https://example.com/c")
+	upsertEdge(t, g, a.ID, b.ID)
+	nofollow := &domain.Edge{Src: a.ID, Dst: c.ID, Rel: domain.EdgeRelNoFollow, UpdatedAt: time.Now().Add(-time.Hour)}
+	require.NoError(t, g.UpsertEdge(ctx, nofollow))
+
+	score := func(ignoreNoFollow bool, id uuid.UUID) float64 {
+		calc, err := NewCalculator(Config{Graph: g, Indexer: indexer, IgnoreNoFollow: ignoreNoFollow})
+		require.NoError(t, err)
+		require.NoError(t, calc.Update(ctx))
+		doc, err := indexer.FindByID(ctx, id)
+		require.NoError(t, err)
+		return doc.PageRank
+	}
+
+	assert.InDelta(t, score(false, b.ID), score(false, c.ID), 1e-9, "expected nofollow edges to count by default")
+	assert.Greater(t, score(true, b.ID), score(true, c.ID), "expected nofollow edges to be ignored")
+}
+
+func TestUpdateRedirects(t *testing.T) {
+	ctx := context.TODO()
+	g, indexer := newDeps(t)
+
+	a := upsertLink(t, g, "https://example.com/a")
+	b := upsertLink(t, g, "https://example.com/b")
+	old := upsertLink(t, g, "http://example.com/c")
+	moved := upsertLink(t, g, "https://example.com/c-old")
+	c := upsertLink(t, g, "https://example.com/c")
+	upsertEdge(t, g, a.ID, b.ID)
+	upsertEdge(t, g, b.ID, old.ID)
+	upsertEdge(t, g, a.ID, moved.ID)
+	for _, src := range []*domain.Link{old, moved} {
+		redirect := &domain.Edge{Src: src.ID, Dst: c.ID, Kind: domain.EdgeKindRedirect, UpdatedAt: time.Now().Add(-time.Hour)}
+		require.NoError(t, g.UpsertEdge(ctx, redirect))
+	}
+
+	calc, err := NewCalculator(Config{Graph: g, Indexer: indexer})
+	require.NoError(t, err)
+	require.NoError(t, calc.Update(ctx))
+
+	scores := make(map[uuid.UUID]float64)
+	for _, link := range []*domain.Link{a, b, c} {
+		doc, err := indexer.FindByID(ctx, link.ID)
+		require.NoError(t, err)
+		scores[link.ID] = doc.PageRank
+	}
+	assert.InDelta(t, 1.0, scores[a.ID]+scores[b.ID]+scores[c.ID], 1e-6, "expected the redirecting links to hold no score")
+	assert.Greater(t, scores[c.ID], scores[b.ID], "expected the edges to the redirecting links to count towards their target")
+	for _, src := range []*domain.Link{old, moved} {
+		_, err = indexer.FindByID(ctx, src.ID)
+		assert.ErrorIs(t, err, ports.TextIndexerErrNotFound, "expected no score for a redirecting link")
+	}
+}
+
+func TestFinalTarget(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+
+	target, ok := finalTarget(map[uuid.UUID]uuid.UUID{a: b, b: c}, a)
+	assert.True(t, ok)
+	assert.Equal(t, c, target)
+
+	_, ok = finalTarget(map[uuid.UUID]uuid.UUID{a: b}, c)
+	assert.False(t, ok, "expected links without redirects to have no target")
+
+	_, ok = finalTarget(map[uuid.UUID]uuid.UUID{a: b, b: c, c: a}, a)
+	assert.False(t, ok, "expected redirect loops to have no target")
+}
+
+func TestNewCalculatorValidation(t *testing.T) {
+	_, err := NewCalculator(Config{})
+	assert.Error(t, err)
+
+	g, indexer := newDeps(t)
+	_, err = NewCalculator(Config{Graph: g, Indexer: indexer, DampingFactor: 1})
+	assert.Error(t, err)
+}
+
+func newTestGraph(n int) *graph {
+	g := &graph{nodes: make(map[uuid.UUID]int)}
+	for i := 0; i < n; i++ {
+		g.addNode(uuid.New())
+	}
+	return g
+}
+
+func newDeps(t *testing.T) (repository.GraphRepository, ports.TextIndexer) {
+	indexer, err := indexmemory.NewInMemoryIndexer()
+	require.NoError(t, err)
+	return memory.NewInMemoryGraph(), indexer
+}
+
+func upsertLink(t *testing.T, g repository.GraphRepository, url string) *domain.Link {
+	link := &domain.Link{URL: url, RetrievedAt: time.Now().Add(-time.Hour)}
+	require.NoError(t, g.UpsertLink(context.TODO(), link))
+	return link
+}
+
+func upsertEdge(t *testing.T, g repository.GraphRepository, src, dst uuid.UUID) {
+	edge := &domain.Edge{Src: src, Dst: dst, UpdatedAt: time.Now().Add(-time.Hour)}
+	require.NoError(t, g.UpsertEdge(context.TODO(), edge))
+}
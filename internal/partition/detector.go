@@ -0,0 +1,105 @@
+package partition
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// ErrNoPartitionDataAvailableYet is returned by a Detector when it cannot
+// yet locate the current instance among its peers, e.g. because DNS records
+// have not propagated.
+var ErrNoPartitionDataAvailableYet = xerrors.New("no partition data available yet")
+
+// Detector is implemented by types that can figure out which partition is
+// assigned to the current instance and the total number of partitions.
+type Detector interface {
+	PartitionInfo(ctx context.Context) (partition, numPartitions int, err error)
+}
+
+// Fixed is a Detector that always returns a statically configured
+// partition assignment.
+type Fixed struct {
+	// Partition is the partition assigned to the current instance.
+	Partition int
+	// NumPartitions is the total number of partitions.
+	NumPartitions int
+}
+
+// PartitionInfo implements Detector.
+func (f Fixed) PartitionInfo(_ context.Context) (int, int, error) {
+	if f.NumPartitions <= 0 {
+		return -1, -1, fmt.Errorf("partition info: %w", ErrInvalidPartitionCount)
+	}
+	if f.Partition < 0 || f.Partition >= f.NumPartitions {
+		return -1, -1, fmt.Errorf("partition info: %w", ErrInvalidPartition)
+	}
+	return f.Partition, f.NumPartitions, nil
+}
+
+// SRVResolver is implemented by types that can resolve DNS SRV records.
+// *net.Resolver satisfies this interface.
+type SRVResolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// DNSDetector is a Detector that resolves the SRV records of a headless
+// service and uses the position of the current host within the sorted list
+// of targets as its partition number.
+type DNSDetector struct {
+	srvName  string
+	resolver SRVResolver
+	hostname func() (string, error)
+}
+
+// NewDNSDetector creates a DNSDetector that resolves srvName using the
+// default resolver and identifies the current instance by its hostname.
+func NewDNSDetector(srvName string) *DNSDetector {
+	return NewDNSDetectorWithResolver(srvName, net.DefaultResolver, os.Hostname)
+}
+
+// NewDNSDetectorWithResolver creates a DNSDetector that resolves srvName
+// using resolver and identifies the current instance via hostname.
+func NewDNSDetectorWithResolver(srvName string, resolver SRVResolver, hostname func() (string, error)) *DNSDetector {
+	return &DNSDetector{srvName: srvName, resolver: resolver, hostname: hostname}
+}
+
+// PartitionInfo implements Detector.
+func (d *DNSDetector) PartitionInfo(ctx context.Context) (int, int, error) {
+	host, err := d.hostname()
+	if err != nil {
+		return -1, -1, fmt.Errorf("partition info: %w", err)
+	}
+
+	_, records, err := d.resolver.LookupSRV(ctx, "", "", d.srvName)
+	if err != nil {
+		return -1, -1, fmt.Errorf("partition info: %w", err)
+	}
+
+	// A target may be listed more than once, e.g. when it exposes several
+	// ports; count each peer only once so the partition count is not
+	// inflated.
+	seen := make(map[string]struct{}, len(records))
+	peers := make([]string, 0, len(records))
+	for _, rec := range records {
+		peer := strings.TrimSuffix(rec.Target, ".")
+		if _, dup := seen[peer]; dup {
+			continue
+		}
+		seen[peer] = struct{}{}
+		peers = append(peers, peer)
+	}
+	sort.Strings(peers)
+
+	for partition, peer := range peers {
+		if peer == host || strings.HasPrefix(peer, host+".") {
+			return partition, len(peers), nil
+		}
+	}
+	return -1, -1, fmt.Errorf("partition info: %w", ErrNoPartitionDataAvailableYet)
+}
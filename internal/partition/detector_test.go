@@ -0,0 +1,99 @@
+package partition
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeResolver struct {
+	targets []string
+	err     error
+}
+
+func (r fakeResolver) LookupSRV(_ context.Context, _, _, _ string) (string, []*net.SRV, error) {
+	if r.err != nil {
+		return "", nil, r.err
+	}
+	records := make([]*net.SRV, 0, len(r.targets))
+	for _, target := range r.targets {
+		records = append(records, &net.SRV{Target: target, Port: 8080})
+	}
+	return "", records, nil
+}
+
+func hostname(name string) func() (string, error) {
+	return func() (string, error) { return name, nil }
+}
+
+func TestFixedDetector(t *testing.T) {
+	partition, numPartitions, err := Fixed{Partition: 1, NumPartitions: 3}.PartitionInfo(context.TODO())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, partition)
+	assert.Equal(t, 3, numPartitions)
+
+	_, _, err = Fixed{Partition: 3, NumPartitions: 3}.PartitionInfo(context.TODO())
+	assert.True(t, errors.Is(err, ErrInvalidPartition))
+
+	_, _, err = Fixed{}.PartitionInfo(context.TODO())
+	assert.True(t, errors.Is(err, ErrInvalidPartitionCount))
+}
+
+func TestDNSDetector(t *testing.T) {
+	resolver := fakeResolver{targets: []string{
+		"crawler-2.crawler.default.svc.cluster.local.",
+		"crawler-0.crawler.default.svc.cluster.local.",
+		"crawler-1.crawler.default.svc.cluster.local.",
+	}}
+
+	specs := []struct {
+		host      string
+		partition int
+	}{
+		{host: "crawler-0", partition: 0},
+		{host: "crawler-1", partition: 1},
+		{host: "crawler-2.crawler.default.svc.cluster.local", partition: 2},
+	}
+
+	for _, spec := range specs {
+		det := NewDNSDetectorWithResolver("crawler", resolver, hostname(spec.host))
+		partition, numPartitions, err := det.PartitionInfo(context.TODO())
+		assert.Nil(t, err, spec.host)
+		assert.Equal(t, spec.partition, partition, spec.host)
+		assert.Equal(t, 3, numPartitions, spec.host)
+	}
+}
+
+func TestDNSDetectorDuplicateTargets(t *testing.T) {
+	resolver := fakeResolver{targets: []string{
+		"crawler-1.crawler.",
+		"crawler-0.crawler.",
+		"crawler-1.crawler.",
+		"crawler-0.crawler",
+	}}
+	det := NewDNSDetectorWithResolver("crawler", resolver, hostname("crawler-1"))
+
+	partition, numPartitions, err := det.PartitionInfo(context.TODO())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, partition)
+	assert.Equal(t, 2, numPartitions)
+}
+
+func TestDNSDetectorHostNotListed(t *testing.T) {
+	resolver := fakeResolver{targets: []string{"crawler-0.crawler.", "crawler-10.crawler."}}
+	det := NewDNSDetectorWithResolver("crawler", resolver, hostname("crawler-1"))
+
+	_, _, err := det.PartitionInfo(context.TODO())
+	assert.True(t, errors.Is(err, ErrNoPartitionDataAvailableYet))
+}
+
+func TestDNSDetectorResolverError(t *testing.T) {
+	lookupErr := errors.New("lookup failed")
+	det := NewDNSDetectorWithResolver("crawler", fakeResolver{err: lookupErr}, hostname("crawler-0"))
+
+	_, _, err := det.PartitionInfo(context.TODO())
+	assert.True(t, errors.Is(err, lookupErr))
+}
@@ -0,0 +1,130 @@
+// Package partition splits the UUID space into contiguous ranges so that
+// multiple instances of a service can process disjoint parts of the graph.
+package partition
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+)
+
+var (
+	// ErrInvalidPartitionCount is returned when attempting to split a range
+	// into a non-positive number of partitions.
+	ErrInvalidPartitionCount = xerrors.New("number of partitions must be at least 1")
+
+	// ErrTooManyPartitions is returned when attempting to split a range
+	// into more partitions than it has UUIDs, which would leave some of
+	// them empty.
+	ErrTooManyPartitions = xerrors.New("number of partitions exceeds the size of the range")
+
+	// ErrInvalidRangeExtents is returned when the start of a range is not
+	// smaller than its end.
+	ErrInvalidRangeExtents = xerrors.New("range start UUID must be less than the end UUID")
+
+	// ErrInvalidPartition is returned when looking up a partition number
+	// that does not belong to the range.
+	ErrInvalidPartition = xerrors.New("invalid partition number")
+
+	// ErrOutOfRange is returned when looking up the partition of a UUID that
+	// does not belong to the range.
+	ErrOutOfRange = xerrors.New("UUID is outside the range")
+)
+
+// Range represents a contiguous UUID region which is split into a number of
+// partitions of (nearly) equal size.
+type Range struct {
+	start       uuid.UUID
+	rangeSplits []uuid.UUID
+}
+
+// NewFullRange creates a new range that covers the full UUID space, from
+// uuid.Nil to repository.MaxID, and splits it into numPartitions.
+func NewFullRange(numPartitions int) (Range, error) {
+	return NewRange(uuid.Nil, repository.MaxID, numPartitions)
+}
+
+// NewRange creates a new range [start, end] and splits it into numPartitions.
+func NewRange(start, end uuid.UUID, numPartitions int) (Range, error) {
+	if bytes.Compare(start[:], end[:]) >= 0 {
+		return Range{}, fmt.Errorf("new range: %w", ErrInvalidRangeExtents)
+	}
+	if numPartitions <= 0 {
+		return Range{}, fmt.Errorf("new range: %w", ErrInvalidPartitionCount)
+	}
+
+	// Calculate the size of each partition as: ((end - start) / numPartitions)
+	tokenRange := new(big.Int).Sub(
+		new(big.Int).SetBytes(end[:]),
+		new(big.Int).SetBytes(start[:]),
+	)
+	partSize := new(big.Int).Div(tokenRange, big.NewInt(int64(numPartitions)))
+	if partSize.Sign() == 0 {
+		return Range{}, fmt.Errorf("new range: %w", ErrTooManyPartitions)
+	}
+
+	// By setting the split point of the *last* partition to end we always
+	// cover the full range even if it is not evenly divisible by
+	// numPartitions.
+	startInt := new(big.Int).SetBytes(start[:])
+	splits := make([]uuid.UUID, numPartitions)
+	for partition := 0; partition < numPartitions-1; partition++ {
+		offset := new(big.Int).Mul(partSize, big.NewInt(int64(partition+1)))
+		offset.Add(offset, startInt)
+		offset.FillBytes(splits[partition][:])
+	}
+	splits[numPartitions-1] = end
+
+	return Range{start: start, rangeSplits: splits}, nil
+}
+
+// NumPartitions returns the number of partitions in the range.
+func (r Range) NumPartitions() int {
+	return len(r.rangeSplits)
+}
+
+// Extents returns the start and end UUIDs of the range.
+func (r Range) Extents() (uuid.UUID, uuid.UUID) {
+	return r.start, r.rangeSplits[len(r.rangeSplits)-1]
+}
+
+// PartitionExtents returns the [from, to) UUIDs of the specified partition.
+// The upper bound of the last partition is the end of the range itself,
+// which callers must treat as inclusive so that the range end is not left
+// uncovered. For a full range that end is repository.MaxID, which a
+// repository.IDRange already treats as unbounded.
+func (r Range) PartitionExtents(partition int) (from, to uuid.UUID, err error) {
+	if partition < 0 || partition >= len(r.rangeSplits) {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("partition extents: %w", ErrInvalidPartition)
+	}
+
+	if partition == 0 {
+		from = r.start
+	} else {
+		from = r.rangeSplits[partition-1]
+	}
+	return from, r.rangeSplits[partition], nil
+}
+
+// PartitionForID returns the partition that contains the specified UUID.
+func (r Range) PartitionForID(id uuid.UUID) (int, error) {
+	start, end := r.Extents()
+	if bytes.Compare(id[:], start[:]) < 0 || bytes.Compare(id[:], end[:]) > 0 {
+		return -1, fmt.Errorf("partition for ID: %w", ErrOutOfRange)
+	}
+
+	// Find the first split point that is greater than id; the range end
+	// belongs to the last partition.
+	partition := sort.Search(len(r.rangeSplits), func(i int) bool {
+		return bytes.Compare(id[:], r.rangeSplits[i][:]) < 0
+	})
+	if partition == len(r.rangeSplits) {
+		partition--
+	}
+	return partition, nil
+}
@@ -0,0 +1,106 @@
+package partition
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/bruceneco/links-r-us/internal/ports/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRangeErrors(t *testing.T) {
+	_, err := NewRange(repository.MaxID, uuid.Nil, 1)
+	assert.True(t, errors.Is(err, ErrInvalidRangeExtents))
+
+	_, err = NewRange(uuid.Nil, uuid.Nil, 1)
+	assert.True(t, errors.Is(err, ErrInvalidRangeExtents))
+
+	_, err = NewFullRange(0)
+	assert.True(t, errors.Is(err, ErrInvalidPartitionCount))
+
+	// A range of 4 UUIDs cannot be split into more than 4 partitions.
+	start := uuid.MustParse("40000000-0000-0000-0000-000000000000")
+	end := uuid.MustParse("40000000-0000-0000-0000-000000000004")
+	_, err = NewRange(start, end, 5)
+	assert.True(t, errors.Is(err, ErrTooManyPartitions))
+	r, err := NewRange(start, end, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, r.NumPartitions())
+}
+
+func TestFullRangeExtents(t *testing.T) {
+	for _, numPartitions := range []int{1, 2, 3, 10, 11, 255, 1000} {
+		r, err := NewFullRange(numPartitions)
+		assert.Nil(t, err)
+		assert.Equal(t, numPartitions, r.NumPartitions())
+
+		start, end := r.Extents()
+		assert.Equal(t, uuid.Nil, start)
+		assert.Equal(t, repository.MaxID, end)
+
+		// Partitions must be contiguous and cover the full range.
+		var prevTo uuid.UUID
+		for partition := 0; partition < numPartitions; partition++ {
+			from, to, err := r.PartitionExtents(partition)
+			assert.Nil(t, err)
+			assert.Equal(t, prevTo, from, "partition %d/%d is not contiguous", partition, numPartitions)
+			assert.Equal(t, -1, bytes.Compare(from[:], to[:]), "partition %d/%d is empty", partition, numPartitions)
+			prevTo = to
+		}
+		assert.Equal(t, repository.MaxID, prevTo, "last partition must end at MaxID")
+	}
+}
+
+func TestPartitionExtentsErrors(t *testing.T) {
+	r, err := NewFullRange(4)
+	assert.Nil(t, err)
+
+	_, _, err = r.PartitionExtents(-1)
+	assert.True(t, errors.Is(err, ErrInvalidPartition))
+	_, _, err = r.PartitionExtents(4)
+	assert.True(t, errors.Is(err, ErrInvalidPartition))
+}
+
+func TestPartitionForID(t *testing.T) {
+	r, err := NewFullRange(4)
+	assert.Nil(t, err)
+
+	specs := []struct {
+		id        string
+		partition int
+	}{
+		{id: "00000000-0000-0000-0000-000000000000", partition: 0},
+		{id: "3fffffff-ffff-ffff-ffff-fffffffffffe", partition: 0},
+		{id: "40000000-0000-0000-0000-000000000000", partition: 1},
+		{id: "7fffffff-ffff-ffff-ffff-fffffffffffd", partition: 1},
+		{id: "80000000-0000-0000-0000-000000000000", partition: 2},
+		{id: "c0000000-0000-0000-0000-000000000000", partition: 3},
+		{id: "ffffffff-ffff-ffff-ffff-ffffffffffff", partition: 3},
+	}
+
+	for _, spec := range specs {
+		got, err := r.PartitionForID(uuid.MustParse(spec.id))
+		assert.Nil(t, err, spec.id)
+		assert.Equal(t, spec.partition, got, spec.id)
+	}
+}
+
+func TestPartitionForIDOutOfRange(t *testing.T) {
+	r, err := NewRange(
+		uuid.MustParse("40000000-0000-0000-0000-000000000000"),
+		uuid.MustParse("80000000-0000-0000-0000-000000000000"),
+		2,
+	)
+	assert.Nil(t, err)
+
+	_, err = r.PartitionForID(uuid.Nil)
+	assert.True(t, errors.Is(err, ErrOutOfRange))
+	_, err = r.PartitionForID(repository.MaxID)
+	assert.True(t, errors.Is(err, ErrOutOfRange))
+
+	got, err := r.PartitionForID(uuid.MustParse("80000000-0000-0000-0000-000000000000"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, got)
+}
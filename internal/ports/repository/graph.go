@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"github.com/bruceneco/links-r-us/internal/application/core/domain"
 	"github.com/google/uuid"
 	"golang.org/x/xerrors"
@@ -9,20 +10,59 @@ import (
 
 // GraphRepository is the port to manage the relation between many domain.Link and their domain.Edge.
 type GraphRepository interface {
-	// UpsertLink updates an existing entry or insert it if it does not exist.
-	UpsertLink(link *domain.Link) error
+	// UpsertLink updates an existing entry or insert it if it does not exist. An existing entry is only
+	// overwritten if link has a more recent RetrievedAt, and link is then updated with the stored values.
+	UpsertLink(ctx context.Context, link *domain.Link) error
+	// UpsertLinks upserts a batch of links in a single operation, assigning an ID to each of them.
+	UpsertLinks(ctx context.Context, links []*domain.Link) error
 	// FindLink retrieves a domain.Link using its uuid.
-	FindLink(id uuid.UUID) (*domain.Link, error)
+	FindLink(ctx context.Context, id uuid.UUID) (*domain.Link, error)
+	// FindLinkByURL retrieves a domain.Link using its URL.
+	FindLinkByURL(ctx context.Context, url string) (*domain.Link, error)
+	// FindLinks retrieves the domain.Link entries matching ids, in the same order. Unknown ids are skipped.
+	FindLinks(ctx context.Context, ids []uuid.UUID) ([]*domain.Link, error)
+	// TouchLink records that the domain.Link id was fetched at retrievedAt and found unchanged, as after a 304 Not
+	// Modified response. If retrievedAt is newer than the stored RetrievedAt, the link is updated as by
+	// domain.Link.NotModified. The UpdatedAt of its outgoing edges is refreshed either way so that
+	// RemoveStaleEdges keeps them.
+	TouchLink(ctx context.Context, id uuid.UUID, retrievedAt time.Time) error
+	// RemoveLink deletes a domain.Link along with every domain.Edge pointing to or from it. The links that
+	// duplicate it become canonical again.
+	RemoveLink(ctx context.Context, id uuid.UUID) error
+	// MergeLink marks the domain.Link id as a duplicate of canonicalID and moves the domain.Edge entries pointing
+	// to or from id over to the canonical link, along with any link that duplicates id. Edges between the two links
+	// and edges the canonical link already has are dropped. If canonicalID duplicates another link, that link is
+	// used instead. Merging a link with itself makes it canonical again.
+	MergeLink(ctx context.Context, id, canonicalID uuid.UUID) error
 
 	// UpsertEdge updates an existing Edge or insert it if it does not exist.
-	UpsertEdge(edge *domain.Edge) error
+	// Edges are identified by their Src, Dst and Kind, so a link may both
+	// redirect and link to the same target.
+	UpsertEdge(ctx context.Context, edge *domain.Edge) error
+	// UpsertEdges upserts a batch of edges in a single operation. If any edge references an unknown
+	// link, none of the edges are stored.
+	UpsertEdges(ctx context.Context, edges []*domain.Edge) error
+	// RemoveEdge deletes a domain.Edge using its uuid.
+	RemoveEdge(ctx context.Context, id uuid.UUID) error
 	// RemoveStaleEdges deletes domain.Edge based on its uuid and the last update.
-	RemoveStaleEdges(fromID uuid.UUID, updatedBefore time.Time) error
+	RemoveStaleEdges(ctx context.Context, fromID uuid.UUID, updatedBefore time.Time) error
 
-	// Links retrieves a list of domain.Link from an uuid to another uuid based on their retrieved datetime.
-	Links(fromID, toID uuid.UUID, retrievedBefore time.Time) (LinkIterator, error)
-	// Edges retrieves a list of domain.Edge from an uuid to another uuid based on their update datetime.
-	Edges(fromID, toID uuid.UUID, updatedBefore time.Time) (EdgeIterator, error)
+	// Links retrieves the domain.Link entries whose IDs belong to ids and that were retrieved before
+	// retrievedBefore. Iteration stops with the context error once ctx is cancelled.
+	Links(ctx context.Context, ids IDRange, retrievedBefore time.Time) (LinkIterator, error)
+	// Edges retrieves the domain.Edge entries whose source IDs belong to srcIDs and that were updated before
+	// updatedBefore. Iteration stops with the context error once ctx is cancelled.
+	Edges(ctx context.Context, srcIDs IDRange, updatedBefore time.Time) (EdgeIterator, error)
+
+	// InEdges retrieves the domain.Edge entries pointing to a domain.Link. Unknown links yield no edges.
+	InEdges(ctx context.Context, linkID uuid.UUID) (EdgeIterator, error)
+	// OutEdges retrieves the domain.Edge entries originating from a domain.Link. Unknown links yield no edges.
+	OutEdges(ctx context.Context, linkID uuid.UUID) (EdgeIterator, error)
+	// Degree returns the number of domain.Edge entries pointing to and originating from a domain.Link.
+	Degree(ctx context.Context, linkID uuid.UUID) (inDegree, outDegree int, err error)
+
+	// Stats computes summary figures about the graph contents.
+	Stats(ctx context.Context, opts GraphStatsOptions) (*GraphStats, error)
 }
 
 // LinkIterator is implemented by structs that can iterate a list of domain.Link from graph.
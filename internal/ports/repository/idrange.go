@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"bytes"
+
+	"github.com/google/uuid"
+)
+
+// MaxID is the largest possible link ID. When used as the upper bound of an
+// IDRange it denotes a range that extends to the end of the ID space, MaxID
+// included.
+var MaxID = uuid.UUID{
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+}
+
+// IDRange selects the domain.Link IDs in the half-open interval [From, To).
+// Adjacent ranges sharing a boundary therefore never select the same ID. A
+// To equal to MaxID is a sentinel for an unbounded range, so that the last
+// of a set of adjacent ranges can also cover MaxID itself.
+type IDRange struct {
+	From uuid.UUID
+	To   uuid.UUID
+}
+
+// NewIDRange returns the range [from, to).
+func NewIDRange(from, to uuid.UUID) IDRange {
+	return IDRange{From: from, To: to}
+}
+
+// FullIDRange returns a range covering every possible ID.
+func FullIDRange() IDRange {
+	return IDRange{From: uuid.Nil, To: MaxID}
+}
+
+// Unbounded returns true if the range extends to the end of the ID space.
+func (r IDRange) Unbounded() bool {
+	return r.To == MaxID
+}
+
+// Contains returns true if id belongs to the range.
+func (r IDRange) Contains(id uuid.UUID) bool {
+	if bytes.Compare(id[:], r.From[:]) < 0 {
+		return false
+	}
+	return r.Unbounded() || bytes.Compare(id[:], r.To[:]) < 0
+}
@@ -0,0 +1,29 @@
+package repository
+
+import "time"
+
+// GraphStatsOptions configures the figures computed by GraphRepository.Stats.
+type GraphStatsOptions struct {
+	// RetrievedWithin is the window used to count recently retrieved domain.Link entries.
+	RetrievedWithin time.Duration
+	// NumPartitions is the number of UUID partitions used to break down the edge count. Defaults to 1.
+	NumPartitions int
+}
+
+// GraphStats summarizes the contents of a graph.
+type GraphStats struct {
+	// Links is the total number of domain.Link entries.
+	Links int
+	// Edges is the total number of domain.Edge entries.
+	Edges int
+	// UnretrievedLinks is the number of domain.Link entries with a zero RetrievedAt.
+	UnretrievedLinks int
+	// RecentlyRetrievedLinks is the number of domain.Link entries retrieved within GraphStatsOptions.RetrievedWithin.
+	RecentlyRetrievedLinks int
+	// EdgesPerPartition holds the number of domain.Edge entries whose source belongs to each partition.
+	EdgesPerPartition []int
+	// InDegreeHistogram maps an in-degree to the number of domain.Link entries with that in-degree.
+	InDegreeHistogram map[int]int
+	// OutDegreeHistogram maps an out-degree to the number of domain.Link entries with that out-degree.
+	OutDegreeHistogram map[int]int
+}
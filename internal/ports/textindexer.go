@@ -1,16 +1,25 @@
 package ports
 
 import (
+	"context"
 	"errors"
 	"github.com/bruceneco/links-r-us/internal/application/core/domain"
 	"github.com/google/uuid"
 )
 
 type TextIndexer interface {
-	Index(doc *domain.Document) error
-	FindByID(linkID uuid.UUID) (*domain.Document, error)
-	Search(query *DocumentQuery) (DocumentIterator, error)
-	UpdateScore(linkID uuid.UUID, score float64) error
+	Index(ctx context.Context, doc *domain.Document) error
+	FindByID(ctx context.Context, linkID uuid.UUID) (*domain.Document, error)
+	Search(ctx context.Context, query *DocumentQuery) (DocumentIterator, error)
+	UpdateScore(ctx context.Context, linkID uuid.UUID, score float64) error
+	// UpdateAnchorText replaces the anchor texts indexed for a document. Like UpdateScore, it creates a
+	// placeholder document if none exists, and the anchor texts are preserved when the document is indexed again.
+	UpdateAnchorText(ctx context.Context, linkID uuid.UUID, anchorText []string) error
+	// FindNearDuplicates returns the indexed documents whose fingerprint differs from the given one in at most
+	// maxDistance bits, closest first. Index computes the fingerprint of each document with domain.SimHash.
+	// Documents with a zero fingerprint have no words and are neither returned nor matched. A maxDistance
+	// larger than domain.NearDuplicateDistance yields TextIndexerErrDistanceTooLarge.
+	FindNearDuplicates(ctx context.Context, fingerprint uint64, maxDistance int) ([]*domain.Document, error)
 }
 
 type DocumentQueryType uint8
@@ -41,4 +50,8 @@ var (
 	// ErrMissingLinkID is returned when attempting to index a document
 	// that does not specify a valid link ID.
 	TextIndexerErrMissingLinkID = errors.New("document does not provide a valid linkID")
+
+	// TextIndexerErrDistanceTooLarge is returned when looking for
+	// near-duplicates further apart than domain.NearDuplicateDistance.
+	TextIndexerErrDistanceTooLarge = errors.New("fingerprint distance exceeds the near-duplicate distance")
 )